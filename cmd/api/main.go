@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"web3-smart/internal/worker/api"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/logger"
+)
+
+// 只读查询接口：钱包摘要、持仓、交易记录、排行与统计
+
+func main() {
+	// 初始化配置文件
+	cfg := config.InitConfig()
+
+	// 初始化 trace provider
+	logger.InitTrace("web3-smart", "api")
+	// 启动主 span
+	ctx, span := logger.StartSpan(context.Background(), "main", "main")
+	defer span.End()
+
+	// 创建 root logger 并注入 trace 上下文
+	rootLogger := logger.NewLogger("api")
+	logger.SetLogLevel(cfg.Log.Level)
+	tl := logger.WithTrace(ctx, rootLogger)
+
+	// 初始化 repository
+	repo := repository.New(cfg, tl)
+
+	// 启动 HTTP 服务
+	server := api.NewServer(cfg, tl, repo)
+	server.Run()
+
+	// 监听操作系统信号
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	tl.Info("Received shutdown signal, starting graceful shutdown...")
+
+	_ = server.Stop(ctx)
+	repo.Close()
+
+	tl.Info("API server stopped.")
+}
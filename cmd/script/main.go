@@ -7,6 +7,7 @@ import (
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/job"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/writer"
 	"web3-smart/pkg/logger"
 
 	"go.uber.org/zap"
@@ -30,6 +31,9 @@ func main() {
 	logger.SetLogLevel(cfg.Log.Level)
 	tl := logger.WithTrace(ctx, rootLogger)
 
+	// 迁移 writer 的批次与并发取自 async_writer.writers.*_migration，需在创建 writer 之前设置
+	writer.Configure(cfg.AsyncWriter)
+
 	// 初始化 repository
 	repo := repository.New(cfg, tl)
 	defer repo.Close()
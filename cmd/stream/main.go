@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/consumer"
+	"web3-smart/internal/worker/grpcapi"
+	"web3-smart/internal/worker/monitor"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/stream"
+	"web3-smart/pkg/logger"
+)
+
+// 聪明钱成交实时推送网关：消费 topic_smart_trade，推送给 WebSocket / SSE / gRPC 客户端；
+// 配置 grpc.addr 时同时提供 SmartMoneyService 查询接口
+
+func main() {
+	// 初始化配置文件
+	cfg := config.InitConfig()
+
+	// 初始化 trace provider
+	logger.InitTrace("web3-smart", "stream")
+	// 启动主 span
+	ctx, span := logger.StartSpan(context.Background(), "main", "main")
+	defer span.End()
+
+	// 创建 root logger 并注入 trace 上下文
+	rootLogger := logger.NewLogger("stream")
+	logger.SetLogLevel(cfg.Log.Level)
+	tl := logger.WithTrace(ctx, rootLogger)
+
+	// 初始化 repository（回放读取 metrics redis）
+	repo := repository.New(cfg, tl)
+
+	hub := stream.NewHub(cfg.Stream, tl)
+	server := stream.NewServer(cfg, tl, repo, hub)
+	grpcServer := grpcapi.NewServer(cfg, tl, repo, server)
+	metrics := monitor.NewMetricsServer(cfg.Monitor, nil)
+	smartTradeConsumer := consumer.NewStreamConsumer(cfg, tl, hub)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	metrics.Run()
+	server.Run()
+	grpcServer.Run()
+	go smartTradeConsumer.Run(ctx)
+
+	// 监听操作系统信号
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	tl.Info("Received shutdown signal, starting graceful shutdown...")
+
+	_ = smartTradeConsumer.Stop()
+	_ = server.Stop(ctx)
+	_ = grpcServer.Stop(ctx)
+	_ = metrics.Stop(ctx)
+	repo.Close()
+
+	tl.Info("Stream server stopped.")
+}
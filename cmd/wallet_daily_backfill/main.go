@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/job"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/logger"
+
+	"go.uber.org/zap"
+)
+
+// 一次性任务：从 t_smart_transaction 回填 t_smart_wallet_daily
+//
+//	wallet_daily_backfill -from 2025-01-01 -to 2025-01-31
+//
+// 不指定时默认回填最近 30 个 UTC 自然日（不含当天）。
+
+func main() {
+	startTime := time.Now()
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	fromStr := flag.String("from", yesterday.AddDate(0, 0, -29).Format(time.DateOnly), "起始日期（UTC，含）")
+	toStr := flag.String("to", yesterday.Format(time.DateOnly), "结束日期（UTC，含）")
+	flag.Parse()
+
+	// 初始化配置文件
+	cfg := config.InitConfig()
+
+	// 初始化 trace provider
+	logger.InitTrace("web3-smart", "wallet_daily_backfill")
+	// 启动主 span
+	ctx, span := logger.StartSpan(context.Background(), "main", "main")
+	defer span.End()
+
+	// 创建 root logger 并注入 trace 上下文
+	rootLogger := logger.NewLogger("wallet_daily_backfill")
+	logger.SetLogLevel(cfg.Log.Level)
+	tl := logger.WithTrace(ctx, rootLogger)
+
+	from, err := time.Parse(time.DateOnly, *fromStr)
+	if err != nil {
+		tl.Error("Invalid -from", zap.String("from", *fromStr), zap.Error(err))
+		os.Exit(1)
+	}
+	to, err := time.Parse(time.DateOnly, *toStr)
+	if err != nil {
+		tl.Error("Invalid -to", zap.String("to", *toStr), zap.Error(err))
+		os.Exit(1)
+	}
+
+	// 初始化 repository
+	repo := repository.New(cfg, tl)
+	defer repo.Close()
+
+	tl.Info("Starting wallet daily backfill...", zap.String("from", *fromStr), zap.String("to", *toStr))
+	walletDaily := job.NewWalletDailySnapshot(cfg, repo, tl)
+	if err := walletDaily.Backfill(ctx, from, to); err != nil {
+		tl.Error("Failed to backfill wallet daily", zap.Error(err))
+		os.Exit(1)
+	}
+	tl.Info("Task completed successfully", zap.Duration("taken_time", time.Since(startTime)))
+}
@@ -0,0 +1,25 @@
+SET search_path = dex_query_v1, public, dex_query, extensions, pg_catalog;
+
+CREATE TABLE dex_query_v1.t_smart_job_history (
+  id bigserial PRIMARY KEY,
+  job_name VARCHAR(128) NOT NULL,
+  instance VARCHAR(256) NOT NULL,
+  trigger VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  attempts INT NOT NULL,
+  lease_token BIGINT NOT NULL DEFAULT 0,
+  started_at BIGINT NOT NULL,
+  finished_at BIGINT NOT NULL,
+  duration_ms BIGINT NOT NULL,
+  error TEXT
+);
+
+CREATE INDEX idx_smart_job_history_job ON dex_query_v1.t_smart_job_history (job_name, id);
+CREATE INDEX idx_smart_job_history_started_at ON dex_query_v1.t_smart_job_history (started_at);
+
+COMMENT ON TABLE dex_query_v1.t_smart_job_history IS '定时作业执行记录';
+COMMENT ON COLUMN dex_query_v1.t_smart_job_history.instance IS '执行实例（hostname-pid）';
+COMMENT ON COLUMN dex_query_v1.t_smart_job_history.trigger IS 'schedule / manual / startup';
+COMMENT ON COLUMN dex_query_v1.t_smart_job_history.status IS 'success / failed / timeout';
+COMMENT ON COLUMN dex_query_v1.t_smart_job_history.attempts IS '含重试的执行次数';
+COMMENT ON COLUMN dex_query_v1.t_smart_job_history.lease_token IS 'singleton 作业的租约 fencing token';
@@ -0,0 +1,21 @@
+
+CREATE TABLE smart_money_flow (
+    chain_id BIGINT,
+    token_address VARCHAR(128),
+    resolution VARCHAR(8),
+    bucket_time BIGINT,          -- 桶起始时间（毫秒）
+    buy_value DECIMAL(38,10),
+    sell_value DECIMAL(38,10),
+    net_flow DECIMAL(38,10),
+    buy_txns BIGINT,
+    sell_txns BIGINT,
+    buyers BIGINT,
+    sellers BIGINT,
+    updated_at BIGINT
+)
+UNIQUE KEY (chain_id, token_address, resolution, bucket_time)
+DISTRIBUTED BY HASH(chain_id, token_address) BUCKETS 16
+PROPERTIES (
+    "replication_allocation" = "tag.location.default: 2",
+    "enable_unique_key_merge_on_write" = "true"
+);
@@ -41,6 +41,7 @@ CREATE TABLE dex_query_v1.t_smart_wallet (
   unrealized_profit_1d DECIMAL(50,20) NOT NULL DEFAULT 0,
   total_cost_1d DECIMAL(50,20) NOT NULL DEFAULT 0,
   avg_realized_profit_1d DECIMAL(50,20) NOT NULL DEFAULT 0,
+  max_drawdown_30d DECIMAL(50,20) NOT NULL DEFAULT 0,
   distribution_gt500_30d INTEGER,
   distribution_200to500_30d INTEGER,
   distribution_0to200_30d INTEGER,
@@ -63,6 +64,7 @@ CREATE TABLE dex_query_v1.t_smart_wallet (
   distribution_lt50_percentage_7d DECIMAL(50,20) NOT NULL DEFAULT 0,
   last_transaction_time BIGINT,
   is_active BOOLEAN,
+  analyze_fence BIGINT NOT NULL DEFAULT 0,
   updated_at bigint NOT NULL,
   created_at bigint NOT NULL,
 
@@ -110,6 +112,7 @@ COMMENT ON COLUMN dex_query_v1.t_smart_wallet.pnl_percentage_1d IS '1天已实
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.unrealized_profit_1d IS '1天未实现盈亏USD';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.total_cost_1d IS '1天总成本USD';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.avg_realized_profit_1d IS '1天平均已实现盈亏USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet.max_drawdown_30d IS '30天资产净值最大回撤（0-1）';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.distribution_gt500_30d IS '30天收益大于500%的笔数';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.distribution_200to500_30d IS '30天收益200-500%的笔数';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.distribution_0to200_30d IS '30天收益0-200%的笔数';
@@ -132,4 +135,8 @@ COMMENT ON COLUMN dex_query_v1.t_smart_wallet.distribution_n50to0_percentage_7d
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.distribution_lt50_percentage_7d IS '7天收益小于-50%的百分比';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.last_transaction_time IS '最近交易时间';
 COMMENT ON COLUMN dex_query_v1.t_smart_wallet.is_active IS '是否活跃';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet.analyze_fence IS 'smart_money_analyze 最近一次写入的 fencing token，token 更小的写入被拒绝';
 
+-- 存量表新增列
+-- ALTER TABLE dex_query_v1.t_smart_wallet ADD COLUMN max_drawdown_30d DECIMAL(50,20) NOT NULL DEFAULT 0;
+-- ALTER TABLE dex_query_v1.t_smart_wallet ADD COLUMN analyze_fence BIGINT NOT NULL DEFAULT 0;
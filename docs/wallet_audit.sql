@@ -0,0 +1,20 @@
+SET search_path = dex_query_v1, public, dex_query, extensions, pg_catalog;
+
+CREATE TABLE dex_query_v1.t_smart_wallet_audit (
+  id bigserial PRIMARY KEY,
+  chain_id BIGINT NOT NULL,
+  wallet_address VARCHAR(512) NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  operator VARCHAR(128) NOT NULL,
+  before JSONB,
+  after JSONB,
+  created_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_smart_wallet_audit_wallet ON dex_query_v1.t_smart_wallet_audit (chain_id, wallet_address, id);
+
+COMMENT ON TABLE dex_query_v1.t_smart_wallet_audit IS '钱包人工变更审计';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_audit.action IS 'track / tags / profile';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_audit.operator IS '操作人（管理接口 X-Operator 请求头）';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_audit.before IS '变更前的人工字段快照';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_audit.after IS '变更后的人工字段快照';
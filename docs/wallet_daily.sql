@@ -0,0 +1,32 @@
+SET search_path = dex_query_v1, public, dex_query, extensions, pg_catalog;
+
+CREATE TABLE dex_query_v1.t_smart_wallet_daily (
+  id bigserial PRIMARY KEY,
+  chain_id BIGINT NOT NULL,
+  wallet_address VARCHAR(100) NOT NULL,
+  day DATE NOT NULL,
+  realized_pnl DECIMAL(50,20) NOT NULL DEFAULT 0,
+  unrealized_pnl DECIMAL(50,20) NOT NULL DEFAULT 0,
+  balance DECIMAL(50,20) NOT NULL DEFAULT 0,
+  balance_usd DECIMAL(50,20) NOT NULL DEFAULT 0,
+  buy_num INTEGER NOT NULL DEFAULT 0,
+  sell_num INTEGER NOT NULL DEFAULT 0,
+  open_positions INTEGER NOT NULL DEFAULT 0,
+  snapshot_at BIGINT NOT NULL DEFAULT 0,
+  created_at BIGINT NOT NULL,
+  updated_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX uk_smart_wallet_daily ON dex_query_v1.t_smart_wallet_daily (chain_id, wallet_address, day);
+CREATE INDEX idx_smart_wallet_daily_day ON dex_query_v1.t_smart_wallet_daily (day);
+
+COMMENT ON TABLE dex_query_v1.t_smart_wallet_daily IS '钱包每日快照（UTC 自然日）';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.day IS 'UTC 自然日';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.realized_pnl IS '当日已实现盈亏USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.unrealized_pnl IS '快照时刻未实现盈亏USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.balance IS '快照时刻原生币余额';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.balance_usd IS '快照时刻原生币余额USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.buy_num IS '当日买入次数';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.sell_num IS '当日卖出次数';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.open_positions IS '快照时刻持仓token数';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_daily.snapshot_at IS '快照时刻（毫秒），0 表示只有回填的交易聚合，未实现盈亏/余额/持仓数未填充';
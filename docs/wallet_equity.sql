@@ -0,0 +1,21 @@
+SET search_path = dex_query_v1, public, dex_query, extensions, pg_catalog;
+
+CREATE TABLE dex_query_v1.t_smart_wallet_equity (
+  id bigserial PRIMARY KEY,
+  chain_id BIGINT NOT NULL,
+  wallet_address VARCHAR(100) NOT NULL,
+  ts BIGINT NOT NULL,
+  balance_usd DECIMAL(50,20) NOT NULL DEFAULT 0,
+  holding_value_usd DECIMAL(50,20) NOT NULL DEFAULT 0,
+  equity_usd DECIMAL(50,20) NOT NULL DEFAULT 0,
+  created_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX uk_smart_wallet_equity ON dex_query_v1.t_smart_wallet_equity (chain_id, wallet_address, ts);
+CREATE INDEX idx_smart_wallet_equity_ts ON dex_query_v1.t_smart_wallet_equity (ts);
+
+COMMENT ON TABLE dex_query_v1.t_smart_wallet_equity IS '钱包资产净值快照（活跃钱包每小时，其他钱包每天）';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_equity.ts IS '快照整点/零点（UTC）毫秒时间戳';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_equity.balance_usd IS '原生币余额USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_equity.holding_value_usd IS '未清仓持仓价值USD';
+COMMENT ON COLUMN dex_query_v1.t_smart_wallet_equity.equity_usd IS '资产净值USD';
@@ -0,0 +1,31 @@
+SET search_path = dex_query_v1, public, dex_query, extensions, pg_catalog;
+
+CREATE TABLE dex_query_v1.t_smart_webhook_subscription (
+  id bigserial PRIMARY KEY,
+  name VARCHAR(128) NOT NULL,
+  url VARCHAR(1024) NOT NULL,
+  secret VARCHAR(256) NOT NULL,
+  chain_ids BIGINT[],
+  wallets VARCHAR(512)[],
+  tokens VARCHAR(512)[],
+  tx_types VARCHAR(20)[],
+  min_value DECIMAL(50,20) NOT NULL DEFAULT 0,
+  enabled BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at BIGINT NOT NULL,
+  updated_at BIGINT NOT NULL
+);
+
+CREATE TABLE dex_query_v1.t_smart_webhook_delivery (
+  id bigserial PRIMARY KEY,
+  subscription_id BIGINT NOT NULL,
+  event_id VARCHAR(64) NOT NULL,
+  payload TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  status VARCHAR(20) NOT NULL,
+  created_at BIGINT NOT NULL,
+  updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX idx_smart_webhook_delivery_subscription ON dex_query_v1.t_smart_webhook_delivery (subscription_id);
+CREATE INDEX idx_smart_webhook_delivery_status ON dex_query_v1.t_smart_webhook_delivery (status, created_at);
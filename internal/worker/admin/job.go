@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"web3-smart/internal/worker/job"
+
+	"go.uber.org/zap"
+)
+
+// registerJobRoutes 定时作业管理接口
+//
+//	GET    /admin/jobs                    作业列表及运行状态
+//	GET    /admin/jobs/{name}             单个作业状态
+//	POST   /admin/jobs/{name}/trigger     立即执行一次（不受暂停影响）
+//	POST   /admin/jobs/{name}/pause       暂停定时执行
+//	POST   /admin/jobs/{name}/resume      恢复定时执行
+//	PUT    /admin/jobs/{name}/interval    修改执行间隔 {"interval":"30m"}，重启后恢复默认
+//	GET    /admin/jobs/{name}/history     执行记录，按时间倒序，支持 limit / offset
+//	GET    /admin/jobs/{name}/checkpoint  断点（分页扫描类作业），无断点返回 404
+//	DELETE /admin/jobs/{name}/checkpoint  删除断点，强制下次从头完整扫描；执行中的作业在下一页从头开始
+func (s *Server) registerJobRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin/jobs", s.listJobs)
+	mux.HandleFunc("GET /admin/jobs/{name}", s.getJob)
+	mux.HandleFunc("POST /admin/jobs/{name}/trigger", s.triggerJob)
+	mux.HandleFunc("POST /admin/jobs/{name}/pause", s.pauseJob)
+	mux.HandleFunc("POST /admin/jobs/{name}/resume", s.resumeJob)
+	mux.HandleFunc("PUT /admin/jobs/{name}/interval", s.setJobInterval)
+	mux.HandleFunc("GET /admin/jobs/{name}/history", s.listJobHistory)
+	mux.HandleFunc("GET /admin/jobs/{name}/checkpoint", s.getJobCheckpoint)
+	mux.HandleFunc("DELETE /admin/jobs/{name}/checkpoint", s.clearJobCheckpoint)
+}
+
+// intervalRequest 修改间隔请求，interval 为 Go duration 格式，如 "90s"、"2h"
+type intervalRequest struct {
+	Interval string `json:"interval"`
+}
+
+func (s *Server) listJobs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.scheduler.Jobs())
+}
+
+func (s *Server) getJob(w http.ResponseWriter, r *http.Request) {
+	state, err := s.scheduler.Job(r.PathValue("name"))
+	if err != nil {
+		writeJobError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *Server) triggerJob(w http.ResponseWriter, r *http.Request) {
+	s.jobAction(w, r, "trigger", s.scheduler.Trigger)
+}
+
+func (s *Server) pauseJob(w http.ResponseWriter, r *http.Request) {
+	s.jobAction(w, r, "pause", s.scheduler.Pause)
+}
+
+func (s *Server) resumeJob(w http.ResponseWriter, r *http.Request) {
+	s.jobAction(w, r, "resume", s.scheduler.Resume)
+}
+
+func (s *Server) setJobInterval(w http.ResponseWriter, r *http.Request) {
+	var req intervalRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	interval, err := time.ParseDuration(req.Interval)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid interval")
+		return
+	}
+
+	name := r.PathValue("name")
+	if err := s.scheduler.SetInterval(name, interval); err != nil {
+		writeJobError(w, err)
+		return
+	}
+	s.tl.Info("admin set job interval", zap.String("job", name), zap.Duration("interval", interval))
+
+	state, _ := s.scheduler.Job(name)
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (s *Server) listJobHistory(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, err := s.scheduler.Job(name); err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	limit, offset := parsePage(r)
+	histories, err := s.repo.GetDAOManager().JobHistoryDAO.ListJobHistory(r.Context(), name, limit, offset)
+	if err != nil {
+		s.tl.Error("list job history failed", zap.String("job", name), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "list job history failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, histories)
+}
+
+func (s *Server) getJobCheckpoint(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, err := s.scheduler.Job(name); err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	cp, err := job.LoadCheckpoint(r.Context(), s.repo.GetMainRDB(), name)
+	if err != nil {
+		s.tl.Error("load job checkpoint failed", zap.String("job", name), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "load job checkpoint failed")
+		return
+	}
+	if cp == nil {
+		writeError(w, http.StatusNotFound, "checkpoint not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, cp)
+}
+
+func (s *Server) clearJobCheckpoint(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if _, err := s.scheduler.Job(name); err != nil {
+		writeJobError(w, err)
+		return
+	}
+
+	if err := job.ClearCheckpoint(r.Context(), s.repo.GetMainRDB(), name); err != nil {
+		s.tl.Error("clear job checkpoint failed", zap.String("job", name), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, "clear job checkpoint failed")
+		return
+	}
+	s.tl.Info("admin cleared job checkpoint", zap.String("job", name))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// jobAction 执行作业操作并返回最新状态
+func (s *Server) jobAction(w http.ResponseWriter, r *http.Request, action string, fn func(name string) error) {
+	name := r.PathValue("name")
+	if err := fn(name); err != nil {
+		writeJobError(w, err)
+		return
+	}
+	s.tl.Info("admin job action", zap.String("job", name), zap.String("action", action))
+
+	state, _ := s.scheduler.Job(name)
+	writeJSON(w, http.StatusAccepted, state)
+}
+
+func writeJobError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, job.ErrJobNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, job.ErrOnceJob), errors.Is(err, job.ErrCronJob), errors.Is(err, job.ErrInvalidInterval):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, job.ErrSchedulerNotRunning):
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/job"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+// Server 管理接口 HTTP 服务
+//
+// 所有接口都需要 Authorization: Bearer <admin.token>，未配置 token 时不启动。
+type Server struct {
+	cfg         config.AdminConfig
+	tl          *zap.Logger
+	repo        repository.Repository
+	server      *http.Server
+	delivery    *service.WebhookDeliveryService
+	scheduler   *job.Scheduler
+	walletAdmin *service.WalletAdminService
+}
+
+// errorResponse 统一错误返回
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewServer(cfg config.Config, logger *zap.Logger, repo repository.Repository, delivery *service.WebhookDeliveryService, scheduler *job.Scheduler) *Server {
+	s := &Server{
+		cfg:         cfg.Admin,
+		tl:          logger,
+		repo:        repo,
+		delivery:    delivery,
+		scheduler:   scheduler,
+		walletAdmin: service.NewWalletAdminService(cfg, logger, repo),
+	}
+	if !cfg.Admin.Enable || cfg.Admin.Addr == "" {
+		return s
+	}
+	if cfg.Admin.Token == "" {
+		logger.Warn("admin server enabled without token, skip")
+		return s
+	}
+
+	mux := http.NewServeMux()
+	s.registerWebhookRoutes(mux)
+	s.registerJobRoutes(mux)
+	s.registerWalletRoutes(mux)
+
+	s.server = &http.Server{
+		Addr:    cfg.Admin.Addr,
+		Handler: s.auth(mux),
+	}
+	return s
+}
+
+// Run 启动管理接口服务
+func (s *Server) Run() {
+	if s.server == nil {
+		return // disabled
+	}
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.tl.Error("admin server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Stop 优雅关闭 HTTP 服务
+func (s *Server) Stop(ctx context.Context) error {
+	if s.server == nil {
+		return nil // disabled
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// auth 校验 Bearer token
+func (s *Server) auth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := sonic.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data = []byte(`{"error":"marshal response failed"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"web3-smart/internal/worker/dao"
+	"web3-smart/internal/worker/service"
+
+	"go.uber.org/zap"
+)
+
+// registerWalletRoutes 钱包人工维护接口，操作人取自 X-Operator 请求头
+//
+//	POST /admin/wallets                                   新增跟踪钱包
+//	GET  /admin/wallets/{chain_id}/{address}              钱包详情
+//	POST /admin/wallets/{chain_id}/{address}/tags         增删标签 {"add":["kol"],"remove":["smart_wallet"]}
+//	PUT  /admin/wallets/{chain_id}/{address}/profile      修改 twitter_name / twitter_username / wallet_type
+//	GET  /admin/wallets/{chain_id}/{address}/audits       人工变更审计记录
+func (s *Server) registerWalletRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /admin/wallets", s.trackWallet)
+	mux.HandleFunc("GET /admin/wallets/{chain_id}/{address}", s.getWallet)
+	mux.HandleFunc("POST /admin/wallets/{chain_id}/{address}/tags", s.updateWalletTags)
+	mux.HandleFunc("PUT /admin/wallets/{chain_id}/{address}/profile", s.updateWalletProfile)
+	mux.HandleFunc("GET /admin/wallets/{chain_id}/{address}/audits", s.listWalletAudits)
+}
+
+func (s *Server) trackWallet(w http.ResponseWriter, r *http.Request) {
+	var req service.TrackWalletRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	wallet, err := s.walletAdmin.Track(r.Context(), operator(r), req)
+	if err != nil {
+		s.writeWalletError(w, "track wallet failed", err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, wallet)
+}
+
+func (s *Server) getWallet(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	wallet, err := s.walletAdmin.Get(r.Context(), chainID, address)
+	if err != nil {
+		s.writeWalletError(w, "get wallet failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) updateWalletTags(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	var req service.WalletTagsRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	wallet, err := s.walletAdmin.UpdateTags(r.Context(), operator(r), chainID, address, req)
+	if err != nil {
+		s.writeWalletError(w, "update wallet tags failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) updateWalletProfile(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	var req service.WalletProfileRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	wallet, err := s.walletAdmin.UpdateProfile(r.Context(), operator(r), chainID, address, req)
+	if err != nil {
+		s.writeWalletError(w, "update wallet profile failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) listWalletAudits(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := parsePage(r)
+	audits, err := s.walletAdmin.ListAudits(r.Context(), chainID, address, limit, offset)
+	if err != nil {
+		s.writeWalletError(w, "list wallet audits failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, audits)
+}
+
+func (s *Server) writeWalletError(w http.ResponseWriter, msg string, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWalletInput):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrWalletNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, dao.ErrWalletExists):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		s.tl.Warn(msg, zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+// walletPath 解析路径中的 chain_id 与钱包地址
+func walletPath(w http.ResponseWriter, r *http.Request) (uint64, string, bool) {
+	chainID, err := strconv.ParseUint(r.PathValue("chain_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid chain_id")
+		return 0, "", false
+	}
+	address := strings.TrimSpace(r.PathValue("address"))
+	if address == "" {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return 0, "", false
+	}
+	return chainID, address, true
+}
+
+// operator 审计用操作人，管理接口共用一个 token，由调用方通过 X-Operator 标识
+func operator(r *http.Request) string {
+	if op := strings.TrimSpace(r.Header.Get("X-Operator")); op != "" {
+		if len(op) > 128 {
+			op = op[:128]
+		}
+		return op
+	}
+	return "admin"
+}
@@ -0,0 +1,237 @@
+package admin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/service"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+const maxRequestBody = 1 << 20
+
+// registerWebhookRoutes webhook 订阅管理接口
+//
+//	GET    /admin/webhooks                              订阅列表
+//	POST   /admin/webhooks                              创建订阅（secret 为空时自动生成，仅在创建时返回）
+//	GET    /admin/webhooks/{id}                         订阅详情
+//	PUT    /admin/webhooks/{id}                         更新订阅
+//	DELETE /admin/webhooks/{id}                         删除订阅
+//	GET    /admin/webhooks/deliveries?status=parked     投递失败记录
+//	POST   /admin/webhooks/deliveries/{id}/redeliver    手动重投
+func (s *Server) registerWebhookRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin/webhooks", s.listWebhooks)
+	mux.HandleFunc("POST /admin/webhooks", s.createWebhook)
+	mux.HandleFunc("GET /admin/webhooks/{id}", s.getWebhook)
+	mux.HandleFunc("PUT /admin/webhooks/{id}", s.updateWebhook)
+	mux.HandleFunc("DELETE /admin/webhooks/{id}", s.deleteWebhook)
+	mux.HandleFunc("GET /admin/webhooks/deliveries", s.listDeliveries)
+	mux.HandleFunc("POST /admin/webhooks/deliveries/{id}/redeliver", s.redeliver)
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	subs, err := s.repo.GetDAOManager().WebhookDAO.ListSubscriptions(r.Context())
+	if err != nil {
+		s.tl.Warn("list webhook subscriptions failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	for _, sub := range subs {
+		sub.Secret = ""
+	}
+	writeJSON(w, http.StatusOK, subs)
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var sub model.WebhookSubscription
+	if err := decodeBody(r, &sub); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if msg := validateWebhook(&sub); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if sub.Secret == "" {
+		sub.Secret = newWebhookSecret()
+	}
+	// 新建订阅默认启用，停用通过 PUT 更新
+	sub.ID = 0
+	sub.Enabled = true
+
+	if err := s.repo.GetDAOManager().WebhookDAO.CreateSubscription(r.Context(), &sub); err != nil {
+		s.tl.Warn("create webhook subscription failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.loadWebhook(w, r)
+	if !ok {
+		return
+	}
+	sub.Secret = ""
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	existing, ok := s.loadWebhook(w, r)
+	if !ok {
+		return
+	}
+
+	var sub model.WebhookSubscription
+	if err := decodeBody(r, &sub); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if msg := validateWebhook(&sub); msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	sub.ID = existing.ID
+	sub.CreatedAt = existing.CreatedAt
+	if sub.Secret == "" {
+		sub.Secret = existing.Secret
+	}
+
+	if err := s.repo.GetDAOManager().WebhookDAO.UpdateSubscription(r.Context(), &sub); err != nil {
+		s.tl.Warn("update webhook subscription failed", zap.Int64("id", sub.ID), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	sub.Secret = ""
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) deleteWebhook(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.loadWebhook(w, r)
+	if !ok {
+		return
+	}
+	if err := s.repo.GetDAOManager().WebhookDAO.DeleteSubscription(r.Context(), sub.ID); err != nil {
+		s.tl.Warn("delete webhook subscription failed", zap.Int64("id", sub.ID), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit, offset := parsePage(r)
+	deliveries, err := s.repo.GetDAOManager().WebhookDAO.ListDeliveries(r.Context(), r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		s.tl.Warn("list webhook deliveries failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+func (s *Server) redeliver(w http.ResponseWriter, r *http.Request) {
+	if s.delivery == nil {
+		writeError(w, http.StatusServiceUnavailable, "webhook delivery disabled")
+		return
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	delivery, err := s.delivery.Redeliver(r.Context(), id)
+	if errors.Is(err, service.ErrDeliveryNotFound) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if delivery == nil {
+		s.tl.Warn("redeliver webhook failed", zap.Int64("id", id), zap.Error(err))
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadGateway, delivery)
+		return
+	}
+	writeJSON(w, http.StatusOK, delivery)
+}
+
+// loadWebhook 读取路径中的订阅，不存在时直接写入错误响应
+func (s *Server) loadWebhook(w http.ResponseWriter, r *http.Request) (*model.WebhookSubscription, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id")
+		return nil, false
+	}
+	sub, err := s.repo.GetDAOManager().WebhookDAO.GetSubscription(r.Context(), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if sub == nil {
+		writeError(w, http.StatusNotFound, "subscription not found")
+		return nil, false
+	}
+	return sub, true
+}
+
+// validateWebhook 校验订阅参数，返回错误信息
+func validateWebhook(sub *model.WebhookSubscription) string {
+	if sub.Name == "" {
+		return "name is required"
+	}
+	u, err := url.Parse(sub.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "url must be a valid https endpoint"
+	}
+	for _, t := range sub.TxTypes {
+		switch t {
+		case model.TX_TYPE_BUILD, model.TX_TYPE_BUY, model.TX_TYPE_SELL, model.TX_TYPE_CLEAN:
+		default:
+			return "invalid tx_type: " + t
+		}
+	}
+	if sub.MinValue.IsNegative() {
+		return "min_value must not be negative"
+	}
+	return ""
+}
+
+func newWebhookSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func decodeBody(r *http.Request, v interface{}) error {
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBody))
+	if err != nil {
+		return err
+	}
+	return sonic.Unmarshal(data, v)
+}
+
+// parsePage 解析 limit/offset，limit 默认 50，最大 500
+func parsePage(r *http.Request) (int, int) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
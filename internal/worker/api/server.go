@@ -0,0 +1,126 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+// Server 只读查询接口 HTTP 服务
+//
+// 所有接口返回 JSON：成功时为数据本身，失败时为 {"error": "..."} 及对应 HTTP 状态码。
+// 查询基于 DAO，单钱包查询走 DAO 的本地/Redis 缓存。
+type Server struct {
+	cfg    config.APIConfig
+	tl     *zap.Logger
+	repo   repository.Repository
+	server *http.Server
+}
+
+// errorResponse 统一错误返回
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+const (
+	defaultPageSize    = 50
+	defaultMaxPageSize = 200
+)
+
+func NewServer(cfg config.Config, logger *zap.Logger, repo repository.Repository) *Server {
+	s := &Server{
+		cfg:  cfg.API,
+		tl:   logger,
+		repo: repo,
+	}
+	if s.cfg.MaxPageSize <= 0 {
+		s.cfg.MaxPageSize = defaultMaxPageSize
+	}
+	readTimeout := time.Duration(s.cfg.ReadTimeout) * time.Second
+	if readTimeout <= 0 {
+		readTimeout = 10 * time.Second
+	}
+	writeTimeout := time.Duration(s.cfg.WriteTimeout) * time.Second
+	if writeTimeout <= 0 {
+		writeTimeout = 15 * time.Second
+	}
+
+	mux := http.NewServeMux()
+	s.registerWalletRoutes(mux)
+
+	s.server = &http.Server{
+		Addr:         s.cfg.Addr,
+		Handler:      s.recover(mux),
+		ReadTimeout:  readTimeout,
+		WriteTimeout: writeTimeout,
+	}
+	return s
+}
+
+// Run 启动查询接口服务
+func (s *Server) Run() {
+	go func() {
+		s.tl.Info("api server listening", zap.String("addr", s.cfg.Addr))
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.tl.Error("api server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Stop 优雅关闭 HTTP 服务
+func (s *Server) Stop(ctx context.Context) error {
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// recover 捕获 handler panic，统一返回 500
+func (s *Server) recover(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				s.tl.Error("api handler panic", zap.String("path", r.URL.Path), zap.Any("panic", err))
+				writeError(w, http.StatusInternalServerError, "internal error")
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parsePage 解析 limit/offset，limit 默认 50，不超过 max_page_size
+func (s *Server) parsePage(r *http.Request) (int, int) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > s.cfg.MaxPageSize {
+		limit = s.cfg.MaxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := sonic.Marshal(v)
+	if err != nil {
+		status = http.StatusInternalServerError
+		data = []byte(`{"error":"marshal response failed"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
@@ -0,0 +1,285 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"web3-smart/internal/worker/dao"
+	"web3-smart/internal/worker/model"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// registerWalletRoutes 钱包相关只读接口
+//
+//	GET /api/v1/wallets/top?chain_id=&period=1d|7d|30d&limit=&offset=          按 PnL 排序的钱包
+//	GET /api/v1/wallets/stats                                                  钱包整体统计
+//	GET /api/v1/wallets/{chain_id}/{address}                                   钱包摘要
+//	GET /api/v1/wallets/{chain_id}/{address}/holdings?status=&token=&min_value_usd=&sort=&limit=&offset=
+//	GET /api/v1/wallets/{chain_id}/{address}/transactions?token=&type=&cursor=&limit=
+//	GET /api/v1/wallets/{chain_id}/{address}/daily?from=YYYY-MM-DD&to=YYYY-MM-DD
+//	GET /api/v1/wallets/{chain_id}/{address}/equity?from=<ms>&to=<ms>
+func (s *Server) registerWalletRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/wallets/top", s.topPerformers)
+	mux.HandleFunc("GET /api/v1/wallets/stats", s.walletStats)
+	mux.HandleFunc("GET /api/v1/wallets/{chain_id}/{address}", s.getWallet)
+	mux.HandleFunc("GET /api/v1/wallets/{chain_id}/{address}/holdings", s.listHoldings)
+	mux.HandleFunc("GET /api/v1/wallets/{chain_id}/{address}/transactions", s.listTransactions)
+	mux.HandleFunc("GET /api/v1/wallets/{chain_id}/{address}/daily", s.listDaily)
+	mux.HandleFunc("GET /api/v1/wallets/{chain_id}/{address}/equity", s.equityCurve)
+}
+
+// holdingsResponse 持仓分页返回
+type holdingsResponse struct {
+	Items []*model.WalletHolding `json:"items"`
+	Total int64                  `json:"total"`
+}
+
+// transactionsResponse 交易记录游标分页返回，next_cursor 为空表示没有更多数据
+type transactionsResponse struct {
+	Items      []*model.WalletTransaction `json:"items"`
+	NextCursor string                     `json:"next_cursor"`
+}
+
+// equityResponse 资产净值曲线返回
+type equityResponse struct {
+	Points      model.EquityCurve `json:"points"`
+	MaxDrawdown decimal.Decimal   `json:"max_drawdown"`
+}
+
+func (s *Server) topPerformers(w http.ResponseWriter, r *http.Request) {
+	var chainID uint64
+	if v := r.URL.Query().Get("chain_id"); v != "" {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid chain_id")
+			return
+		}
+		chainID = id
+	}
+	period := r.URL.Query().Get("period")
+	switch period {
+	case "":
+		period = "30d"
+	case "1d", "7d", "30d":
+	default:
+		writeError(w, http.StatusBadRequest, "invalid period")
+		return
+	}
+
+	limit, offset := s.parsePage(r)
+	wallets, err := s.repo.GetDAOManager().WalletDAO.GetTopPerformers(r.Context(), chainID, period, limit, offset)
+	if err != nil {
+		s.internalError(w, "get top performers failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, wallets)
+}
+
+func (s *Server) walletStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.repo.GetDAOManager().WalletDAO.GetWalletStats(r.Context(), "")
+	if err != nil {
+		s.internalError(w, "get wallet stats failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func (s *Server) getWallet(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	wallet, err := s.repo.GetDAOManager().WalletDAO.GetByWalletAddress(r.Context(), chainID, address)
+	if err != nil {
+		s.internalError(w, "get wallet failed", err)
+		return
+	}
+	if wallet == nil {
+		writeError(w, http.StatusNotFound, "wallet not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, wallet)
+}
+
+func (s *Server) listHoldings(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	filter := dao.HoldingFilter{
+		ChainID:       chainID,
+		WalletAddress: address,
+		TokenAddress:  q.Get("token"),
+		Status:        q.Get("status"),
+		SortBy:        q.Get("sort"),
+	}
+	switch filter.Status {
+	case "", dao.HOLDING_STATUS_ALL, dao.HOLDING_STATUS_ACTIVE, dao.HOLDING_STATUS_CLOSED:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+	switch filter.SortBy {
+	case "", "value_usd", "unrealized_profits", "pnl", "last_transaction_time":
+	default:
+		writeError(w, http.StatusBadRequest, "invalid sort")
+		return
+	}
+	if v := q.Get("min_value_usd"); v != "" {
+		minValue, err := strconv.ParseFloat(v, 64)
+		if err != nil || minValue < 0 {
+			writeError(w, http.StatusBadRequest, "invalid min_value_usd")
+			return
+		}
+		filter.MinValueUSD = minValue
+	}
+	filter.Limit, filter.Offset = s.parsePage(r)
+
+	holdings, total, err := s.repo.GetDAOManager().HoldingDAO.ListByWallet(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list holdings failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, holdingsResponse{Items: holdings, Total: total})
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	filter := dao.TransactionFilter{
+		ChainID:         chainID,
+		WalletAddress:   address,
+		TokenAddress:    q.Get("token"),
+		TransactionType: q.Get("type"),
+	}
+	switch filter.TransactionType {
+	case "", model.TX_TYPE_BUILD, model.TX_TYPE_BUY, model.TX_TYPE_SELL, model.TX_TYPE_CLEAN:
+	default:
+		writeError(w, http.StatusBadRequest, "invalid type")
+		return
+	}
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := dao.DecodeTransactionCursor(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		filter.Before = cursor
+	}
+	filter.Limit, _ = s.parsePage(r)
+
+	txs, err := s.repo.GetDAOManager().TransactionDAO.ListByWallet(r.Context(), filter)
+	if err != nil {
+		s.internalError(w, "list transactions failed", err)
+		return
+	}
+
+	resp := transactionsResponse{Items: txs}
+	if len(txs) == filter.Limit {
+		last := txs[len(txs)-1]
+		resp.NextCursor = (&dao.TransactionCursor{TransactionTime: last.TransactionTime, ID: last.ID}).Encode()
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) listDaily(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	to := time.Now().UTC()
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to")
+			return
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -30)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+		from = t
+	}
+	if to.Sub(from) > 366*24*time.Hour {
+		writeError(w, http.StatusBadRequest, "range too large")
+		return
+	}
+
+	rows, err := s.repo.GetDAOManager().WalletDailyDAO.GetWalletDaily(r.Context(), chainID, address, from, to)
+	if err != nil {
+		s.internalError(w, "list wallet daily failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rows)
+}
+
+func (s *Server) equityCurve(w http.ResponseWriter, r *http.Request) {
+	chainID, address, ok := walletPath(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+
+	to := time.Now().UnixMilli()
+	if v := q.Get("to"); v != "" {
+		t, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to")
+			return
+		}
+		to = t
+	}
+	from := to - (30 * 24 * time.Hour).Milliseconds()
+	if v := q.Get("from"); v != "" {
+		t, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+		from = t
+	}
+
+	curve, err := s.repo.GetDAOManager().WalletEquityDAO.GetEquityCurve(r.Context(), chainID, address, from, to)
+	if err != nil {
+		s.internalError(w, "get equity curve failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, equityResponse{Points: curve, MaxDrawdown: curve.MaxDrawdown()})
+}
+
+// internalError 记录日志并返回 500，不向调用方暴露内部错误
+func (s *Server) internalError(w http.ResponseWriter, msg string, err error) {
+	s.tl.Warn(msg, zap.Error(err))
+	writeError(w, http.StatusInternalServerError, "internal error")
+}
+
+// walletPath 解析路径中的 chain_id 与钱包地址
+func walletPath(w http.ResponseWriter, r *http.Request) (uint64, string, bool) {
+	chainID, err := strconv.ParseUint(r.PathValue("chain_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid chain_id")
+		return 0, "", false
+	}
+	address := strings.TrimSpace(r.PathValue("address"))
+	if address == "" {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return 0, "", false
+	}
+	return chainID, address, true
+}
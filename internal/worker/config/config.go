@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"sync"
 	"web3-smart/pkg/logger"
 
 	"github.com/fsnotify/fsnotify"
@@ -11,19 +12,28 @@ import (
 
 // Config 定义整个配置的结构
 type Config struct {
-	Log                LogConfig           `mapstructure:"log"`
-	Kafka              KafkaConfig         `mapstructure:"kafka"`
-	Redis              RedisConfig         `mapstructure:"redis"`
-	Postgres           PostgresConfig      `mapstructure:"postgres"`
-	SelectDB           SelectDBConfig      `mapstructure:"selectdb"`
-	Elasticsearch      ElasticsearchConfig `mapstructure:"elasticsearch"`
-	Lark               LarkConfig          `mapstructure:"lark"`
-	Worker             WorkerConfig        `mapstructure:"worker"`
-	Monitor            MonitorConfig       `mapstructure:"monitor"`
-	Moralis            MoralisConfig       `mapstructure:"moralis"`
-	BydRpcUrl          string              `mapstructure:"byd_rpc_url"`
-	BscClientRawUrl    string              `mapstructure:"bsc_client_rawurl"`
-	SolanaClientRawUrl string              `mapstructure:"solana_client_rawurl"`
+	Log                LogConfig              `mapstructure:"log"`
+	Kafka              KafkaConfig            `mapstructure:"kafka"`
+	Redis              RedisConfig            `mapstructure:"redis"`
+	Postgres           PostgresConfig         `mapstructure:"postgres"`
+	SelectDB           SelectDBConfig         `mapstructure:"selectdb"`
+	Elasticsearch      ElasticsearchConfig    `mapstructure:"elasticsearch"`
+	Lark               LarkConfig             `mapstructure:"lark"`
+	Alert              AlertConfig            `mapstructure:"alert"`
+	Webhook            WebhookConfig          `mapstructure:"webhook"`
+	Admin              AdminConfig            `mapstructure:"admin"`
+	API                APIConfig              `mapstructure:"api"`
+	Stream             StreamConfig           `mapstructure:"stream"`
+	GRPC               GRPCConfig             `mapstructure:"grpc"`
+	TopCards           TopCardsConfig         `mapstructure:"top_cards"`
+	TransactionPairs   TransactionPairsConfig `mapstructure:"transaction_pairs"`
+	Worker             WorkerConfig           `mapstructure:"worker"`
+	AsyncWriter        AsyncWriterConfig      `mapstructure:"async_writer"`
+	Monitor            MonitorConfig          `mapstructure:"monitor"`
+	Moralis            MoralisConfig          `mapstructure:"moralis"`
+	BydRpcUrl          string                 `mapstructure:"byd_rpc_url"`
+	BscClientRawUrl    string                 `mapstructure:"bsc_client_rawurl"`
+	SolanaClientRawUrl string                 `mapstructure:"solana_client_rawurl"`
 }
 
 // KafkaConfig Kafka 配置
@@ -73,18 +83,133 @@ type LarkConfig struct {
 	Webhook string `mapstructure:"webhook"`
 }
 
+// AlertConfig 聪明钱告警配置
+type AlertConfig struct {
+	Enable           bool    `mapstructure:"enable"`
+	ClusterWallets   int     `mapstructure:"cluster_wallets"`     // 窗口内买入同一 token 的不同聪明钱数量阈值
+	ClusterWindow    int     `mapstructure:"cluster_window"`      // 集群买入统计窗口（秒）
+	Cooldown         int     `mapstructure:"cooldown"`            // 同一 token 同类告警冷却时间（秒）
+	WhaleMinValueUSD float64 `mapstructure:"whale_min_value_usd"` // 头部钱包开仓金额阈值
+	WhaleMinWinRate  float64 `mapstructure:"whale_min_win_rate"`  // 头部钱包 30d 胜率下限（百分比，60 表示 60%）
+	WhaleMinPnl30d   float64 `mapstructure:"whale_min_pnl_30d"`   // 头部钱包 30d 盈亏下限
+	ClusterTemplate  string  `mapstructure:"cluster_template"`    // 集群买入消息模板（text/template），为空使用默认
+	WhaleTemplate    string  `mapstructure:"whale_template"`      // 头部钱包开仓消息模板（text/template），为空使用默认
+}
+
+// WebhookConfig 外部 webhook 推送配置
+type WebhookConfig struct {
+	Enable      bool   `mapstructure:"enable"`
+	GroupID     string `mapstructure:"group_id"`     // 独立消费组，消费 topic_smart_trade
+	Workers     int    `mapstructure:"workers"`      // 投递协程数
+	QueueSize   int    `mapstructure:"queue_size"`   // 投递队列长度
+	MaxAttempts int    `mapstructure:"max_attempts"` // 最大投递次数，超过后 parked
+	BackoffBase int    `mapstructure:"backoff_base"` // 首次重试间隔（毫秒），之后指数增长
+	BackoffMax  int    `mapstructure:"backoff_max"`  // 最大重试间隔（毫秒）
+	Timeout     int    `mapstructure:"timeout"`      // 单次请求超时（秒）
+}
+
+// AdminConfig 管理接口配置
+type AdminConfig struct {
+	Enable bool   `mapstructure:"enable"`
+	Addr   string `mapstructure:"addr"`
+	Token  string `mapstructure:"token"` // Authorization: Bearer <token>
+}
+
+// APIConfig 只读查询接口配置（cmd/api）
+type APIConfig struct {
+	Addr         string `mapstructure:"addr"`
+	MaxPageSize  int    `mapstructure:"max_page_size"` // 分页接口单页最大条数
+	ReadTimeout  int    `mapstructure:"read_timeout"`  // 秒
+	WriteTimeout int    `mapstructure:"write_timeout"` // 秒
+}
+
+// StreamConfig 聪明钱成交实时推送网关配置（cmd/stream）
+type StreamConfig struct {
+	Addr           string   `mapstructure:"addr"`
+	GroupID        string   `mapstructure:"group_id"`        // 消费组前缀，实际使用 <group_id>_<hostname>，保证每个实例收到全量消息
+	SendBuffer     int      `mapstructure:"send_buffer"`     // 单连接待发送队列长度，写满即断开慢连接
+	Heartbeat      int      `mapstructure:"heartbeat"`       // 心跳间隔（秒）
+	MaxConnections int      `mapstructure:"max_connections"` // 单实例最大连接数
+	AllowedOrigins []string `mapstructure:"allowed_origins"` // WebSocket 允许的 Origin，为空不校验
+}
+
+// GRPCConfig SmartMoneyService gRPC 服务配置（随 cmd/stream 启动，查询接口复用 api.max_page_size）
+type GRPCConfig struct {
+	Addr string `mapstructure:"addr"` // 为空时不启动
+}
+
+// TopCardsConfig 监控页小卡片配置
+type TopCardsConfig struct {
+	Periods []TopCardsPeriodConfig `mapstructure:"periods"`
+	Chains  []TopCardsChainConfig  `mapstructure:"chains"`
+}
+
+// TopCardsPeriodConfig 小卡片统计周期
+type TopCardsPeriodConfig struct {
+	Name            string `mapstructure:"name"`             // "1h" / "6h" / "24h"，作为 redis key 的一部分
+	Duration        int    `mapstructure:"duration"`         // 周期长度（秒）
+	LeaderboardSize int    `mapstructure:"leaderboard_size"` // 榜单保留数量
+	DetailCount     int    `mapstructure:"detail_count"`     // 生成 detail 的榜单前 N 名
+	LeaderboardTTL  int    `mapstructure:"leaderboard_ttl"`  // 榜单 key 过期时间（秒）
+}
+
+// TopCardsChainConfig 小卡片覆盖的链
+type TopCardsChainConfig struct {
+	ChainID   uint64   `mapstructure:"chain_id"`
+	Blacklist []string `mapstructure:"blacklist"` // 不展示的 token（原生币、稳定币等），大小写无关
+}
+
+// TransactionPairsConfig 最新交易对榜单配置
+type TransactionPairsConfig struct {
+	Chains []uint64 `mapstructure:"chains"` // 行情刷新与早期买家覆盖的链，为空时默认 Solana、BSC
+}
+
 // LogConfig Log 日志配置
 type LogConfig struct {
 	Level string `mapstructure:"level"`
 }
 
 type WorkerConfig struct {
-	WorkerNum int `mapstructure:"worker_num"`
+	WorkerNum  int `mapstructure:"worker_num"`
+	JobLockTTL int `mapstructure:"job_lock_ttl"` // singleton 作业租约时长（秒），实例崩溃后最多经过该时长由其他实例接管
+}
+
+// AsyncWriterConfig 异步批量写入失败处理配置
+//
+// 写入失败的批次进入重试队列按指数退避重试，重试耗尽后追加写入本地文件，
+// 下游恢复后自动重放。
+type AsyncWriterConfig struct {
+	MaxRetries     int    `mapstructure:"max_retries"`      // 失败批次最多重试次数
+	RetryBackoff   int    `mapstructure:"retry_backoff_ms"` // 首次重试退避（毫秒），之后每次翻倍
+	MaxBackoff     int    `mapstructure:"max_backoff_ms"`   // 退避上限（毫秒）
+	RetryQueueSize int    `mapstructure:"retry_queue_size"` // 每个 writer 等待重试的批次上限，超过直接落盘
+	SpillDir       string `mapstructure:"spill_dir"`        // 落盘目录，为空时重试耗尽直接丢弃
+	ReplayInterval int    `mapstructure:"replay_interval"`  // 落盘数据重放检查间隔（秒）
+
+	Defaults AsyncWriterQueueConfig            `mapstructure:"defaults"` // 所有 writer 的默认队列参数
+	Writers  map[string]AsyncWriterQueueConfig `mapstructure:"writers"`  // 按 writer id 覆盖，未设置的字段取 defaults
+}
+
+// AsyncWriterQueueConfig 单个异步 writer 的队列与攒批参数
+type AsyncWriterQueueConfig struct {
+	QueueSize     int    `mapstructure:"queue_size"`        // 每个 worker 的队列长度
+	BatchSize     int    `mapstructure:"batch_size"`        // 攒批条数
+	FlushInterval int    `mapstructure:"flush_interval_ms"` // 攒批最长等待（毫秒）
+	Workers       int    `mapstructure:"workers"`           // 写入协程数，按 hashKey 分桶
+	Policy        string `mapstructure:"policy"`            // 队列满时的策略：drop_newest | drop_oldest | block | coalesce
+	BlockTimeout  int    `mapstructure:"block_timeout_ms"`  // block 策略最长等待（毫秒），超时丢弃
 }
 
 type MonitorConfig struct {
 	Enable         bool   `mapstructure:"enable"`
 	PrometheusAddr string `mapstructure:"prometheus_addr"`
+
+	// 健康检查（/healthz、/readyz）
+	HealthTimeout       int     `mapstructure:"health_timeout"`        // 单项检查超时（秒）
+	HealthCacheTTL      int     `mapstructure:"health_cache_ttl"`      // 检查结果缓存时间（秒）
+	MaxConsumerLag      int64   `mapstructure:"max_consumer_lag"`      // kafka 消费积压上限，0 不检查
+	MaxWriterSaturation float64 `mapstructure:"max_writer_saturation"` // 异步写入队列使用率上限（0-1），0 不检查
+	TradeIdleTimeout    int     `mapstructure:"trade_idle_timeout"`    // trade 消费者无新消息的最长时间（秒），0 不检查
 }
 
 type MoralisConfig struct {
@@ -122,6 +247,8 @@ func InitConfig() Config {
 
 	viper.BindEnv("lark.webhook", "MOONX_LARK_WEBHOOK")
 
+	viper.BindEnv("admin.token", "MOONX_ADMIN_TOKEN")
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		panic(fmt.Errorf("fatal error config file: %s", err))
@@ -134,11 +261,30 @@ func InitConfig() Config {
 	return config
 }
 
+var (
+	changeHooksMu sync.Mutex
+	changeHooks   []func(Config)
+)
+
+// OnChange 注册配置热加载回调，配置文件变更后以新配置调用
+func OnChange(fn func(Config)) {
+	changeHooksMu.Lock()
+	defer changeHooksMu.Unlock()
+	changeHooks = append(changeHooks, fn)
+}
+
 func WatchConfig(config *Config) {
 	viper.WatchConfig()
 	viper.OnConfigChange(func(e fsnotify.Event) {
 		newConfig := InitConfig()
 		*config = newConfig
 		logger.SetLogLevel(config.Log.Level)
+
+		changeHooksMu.Lock()
+		hooks := append([]func(Config){}, changeHooks...)
+		changeHooksMu.Unlock()
+		for _, fn := range hooks {
+			fn(newConfig)
+		}
 	})
 }
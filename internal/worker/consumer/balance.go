@@ -10,6 +10,7 @@ import (
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/monitor"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
 
 	"github.com/bytedance/sonic"
 	"github.com/segmentio/kafka-go"
@@ -25,7 +26,7 @@ type BalanceConsumer struct {
 	repo           repository.Repository
 }
 
-func NewBalanceConsumer(conf config.Config, logger *zap.Logger, repo repository.Repository) *BalanceConsumer {
+func NewBalanceConsumer(conf config.Config, logger *zap.Logger, repo repository.Repository, balanceService *service.BalanceUpdate) *BalanceConsumer {
 	workerSize := conf.Worker.WorkerNum
 	buffers := make([]chan model.BlockBalance, workerSize)
 	for i := range workerSize {
@@ -36,7 +37,7 @@ func NewBalanceConsumer(conf config.Config, logger *zap.Logger, repo repository.
 		id:             "balance_consumer",
 		workerSize:     conf.Worker.WorkerNum,
 		Consumer:       NewConsumer(conf.Kafka, logger, conf.Kafka.TopicBalance),
-		balanceHandler: handler.NewBalanceHandler(conf, logger, repo),
+		balanceHandler: handler.NewBalanceHandler(conf, logger, repo, balanceService),
 		buffers:        buffers,
 		repo:           repo,
 	}
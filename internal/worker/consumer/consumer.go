@@ -79,6 +79,11 @@ func (c *Consumer) run(ctx context.Context, handler MessageHandler) {
 	}
 }
 
+// Lag 消费积压，取 kafka-go 最近一次拉取时分区最新 offset 与读取 offset 之差
+func (c *Consumer) Lag() int64 {
+	return c.kafkaReader.Stats().Lag
+}
+
 // Stop 停止消费者
 func (c *Consumer) Stop() error {
 	return c.kafkaReader.Close()
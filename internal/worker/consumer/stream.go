@@ -0,0 +1,64 @@
+package consumer
+
+import (
+	"context"
+	"os"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/monitor"
+	"web3-smart/internal/worker/stream"
+
+	"github.com/bytedance/sonic"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// StreamConsumer 消费聪明钱交易（SmartTxEvent），分发给推送网关的 WebSocket / SSE 连接
+type StreamConsumer struct {
+	*Consumer
+	id  string
+	hub *stream.Hub
+}
+
+func NewStreamConsumer(conf config.Config, logger *zap.Logger, hub *stream.Hub) *StreamConsumer {
+	// 每个网关实例都要收到全量消息，消费组按实例区分
+	kafkaConf := conf.Kafka
+	groupID := conf.Stream.GroupID
+	if groupID == "" {
+		groupID = "web3_smart_stream"
+	}
+	hostname, _ := os.Hostname()
+	kafkaConf.GroupID = groupID + "_" + hostname
+
+	return &StreamConsumer{
+		id:       "stream_consumer",
+		Consumer: NewConsumer(kafkaConf, logger, conf.Kafka.TopicSmartTrade),
+		hub:      hub,
+	}
+}
+
+func (sc *StreamConsumer) Run(ctx context.Context) {
+	sc.Consumer.Start(ctx, sc)
+}
+
+func (sc *StreamConsumer) HandleMessage(msg kafka.Message) {
+	monitor.KafkaMessagesReceived.WithLabelValues("smart_trade_stream").Inc()
+
+	var event model.SmartTxEvent
+	if err := sonic.Unmarshal(msg.Value, &event); err != nil {
+		sc.logger.Warn("❌ JSON Parse Error", zap.String("consumerID", sc.id), zap.Error(err), zap.String("raw", string(msg.Value)))
+		return
+	}
+	if event.Type != model.SMART_TX_EVENT_TYPE {
+		return
+	}
+	sc.hub.Publish(&event, msg.Value)
+}
+
+func (sc *StreamConsumer) ID() string {
+	return sc.id
+}
+
+func (sc *StreamConsumer) Stop() error {
+	return sc.Consumer.Stop()
+}
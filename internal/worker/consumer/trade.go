@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"hash/crc32"
 	"strconv"
+	"sync/atomic"
 	"time"
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/handler"
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/monitor"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
@@ -24,10 +26,11 @@ type TradeConsumer struct {
 	buffers      []chan model.TradeEvent // 消息队列
 	tradeHandler *handler.TradeHandler   // trade处理器
 	repo         repository.Repository
+	lastMessage  atomic.Int64 // 最近一次收到消息的时间（毫秒），用于 readiness 检查消费是否停滞
 }
 
 // NewTradeConsumer 创建 TradeConsumer 实例
-func NewTradeConsumer(conf config.Config, logger *zap.Logger, repo repository.Repository) *TradeConsumer {
+func NewTradeConsumer(conf config.Config, logger *zap.Logger, repo repository.Repository, topCards *service.TopCardsService, tradeStats *service.TokenTradeStatsService) *TradeConsumer {
 	// 初始化id
 	newConsumer := NewConsumer(conf.Kafka, logger, conf.Kafka.TopicTrade)
 
@@ -38,14 +41,16 @@ func NewTradeConsumer(conf config.Config, logger *zap.Logger, repo repository.Re
 		buffers[i] = make(chan model.TradeEvent, 2000)
 	}
 
-	return &TradeConsumer{
+	tc := &TradeConsumer{
 		id:           "trade_consumer",
 		workerSize:   workerSize,
 		Consumer:     newConsumer,
 		buffers:      buffers,
-		tradeHandler: handler.NewTradeHandler(conf, logger, repo),
+		tradeHandler: handler.NewTradeHandler(conf, logger, repo, topCards, tradeStats),
 		repo:         repo,
 	}
+	tc.lastMessage.Store(time.Now().UnixMilli()) // 启动阶段视为刚收到消息
+	return tc
 }
 
 // Run 启动trade消费者
@@ -85,6 +90,7 @@ func (tc *TradeConsumer) Run(ctx context.Context) {
 // HandleMessage 实现 MessageHandler 接口
 func (tc *TradeConsumer) HandleMessage(msg kafka.Message) {
 	monitor.KafkaMessagesReceived.WithLabelValues("trade").Inc()
+	tc.lastMessage.Store(time.Now().UnixMilli())
 
 	var trade model.TradeEvent
 	if err := json.Unmarshal(msg.Value, &trade); err != nil {
@@ -111,6 +117,11 @@ func (tc *TradeConsumer) HandleMessage(msg kafka.Message) {
 	tc.dispatch(trade)
 }
 
+// LastMessageAt 最近一次收到消息的时间
+func (tc *TradeConsumer) LastMessageAt() time.Time {
+	return time.UnixMilli(tc.lastMessage.Load())
+}
+
 func (tc *TradeConsumer) ID() string {
 	return tc.id
 }
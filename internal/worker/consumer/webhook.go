@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"context"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/monitor"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"github.com/bytedance/sonic"
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// WebhookConsumer 消费聪明钱交易（SmartTxEvent），推送到外部 webhook 订阅
+type WebhookConsumer struct {
+	*Consumer
+	id       string
+	delivery *service.WebhookDeliveryService
+	repo     repository.Repository
+}
+
+func NewWebhookConsumer(conf config.Config, logger *zap.Logger, repo repository.Repository) *WebhookConsumer {
+	// 使用独立消费组，保证每个 worker 实例组内只投递一次，且不影响其他消费者的 offset
+	kafkaConf := conf.Kafka
+	if conf.Webhook.GroupID != "" {
+		kafkaConf.GroupID = conf.Webhook.GroupID
+	}
+
+	return &WebhookConsumer{
+		id:       "webhook_consumer",
+		Consumer: NewConsumer(kafkaConf, logger, conf.Kafka.TopicSmartTrade),
+		delivery: service.NewWebhookDeliveryService(conf, logger, repo),
+		repo:     repo,
+	}
+}
+
+func (wc *WebhookConsumer) Run(ctx context.Context) {
+	wc.delivery.Start()
+
+	time.Sleep(time.Second * 5) // 等待前面的组件准备完成
+	wc.Consumer.Start(ctx, wc)
+}
+
+func (wc *WebhookConsumer) HandleMessage(msg kafka.Message) {
+	monitor.KafkaMessagesReceived.WithLabelValues("smart_trade").Inc()
+
+	var event model.SmartTxEvent
+	if err := sonic.Unmarshal(msg.Value, &event); err != nil {
+		wc.logger.Warn("❌ JSON Parse Error", zap.String("consumerID", wc.id), zap.Error(err), zap.String("raw", string(msg.Value)))
+		return
+	}
+	if event.Type != model.SMART_TX_EVENT_TYPE {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	wc.delivery.Dispatch(ctx, &event, msg.Value)
+}
+
+func (wc *WebhookConsumer) ID() string {
+	return wc.id
+}
+
+// Delivery 返回投递服务，供管理接口手动重投
+func (wc *WebhookConsumer) Delivery() *service.WebhookDeliveryService {
+	return wc.delivery
+}
+
+func (wc *WebhookConsumer) Stop() error {
+	if err := wc.Consumer.Stop(); err != nil {
+		return err
+	}
+	wc.delivery.Close()
+	return nil
+}
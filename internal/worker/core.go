@@ -2,74 +2,198 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"web3-smart/internal/worker/admin"
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/consumer"
 	"web3-smart/internal/worker/job"
 	"web3-smart/internal/worker/monitor"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+	"web3-smart/internal/worker/writer"
 
 	"go.uber.org/zap"
 )
 
 type Core struct {
-	cfg       config.Config
-	tl        *zap.Logger
-	repo      repository.Repository
-	scheduler *job.Scheduler
-	consumers []consumer.KafkaConsumer
-	metrics   *monitor.MetricsServer // 新增
+	cfg        config.Config
+	tl         *zap.Logger
+	repo       repository.Repository
+	scheduler  *job.Scheduler
+	consumers  []consumer.KafkaConsumer
+	metrics    *monitor.MetricsServer // 新增
+	admin      *admin.Server
+	topCards   *service.TopCardsService        // trade 处理器与价格刷新任务共用
+	tradeStats *service.TokenTradeStatsService // trade 处理器与最新交易对行情刷新任务共用
+	balance    *service.BalanceUpdate          // 余额消费者与 token_balance 任务共用
 }
 
 func New(cfg config.Config, logger *zap.Logger) *Core {
-	// 初始化作业调度器
-	scheduler := job.NewScheduler(logger)
+	// 异步写入失败的重试与落盘配置，需在创建各 writer 之前设置
+	writer.Configure(cfg.AsyncWriter)
 
 	// 初始化repo
 	repo := repository.New(cfg, logger)
 
+	// 小卡片服务注册了配置热加载回调并启动周期清理协程，全局只创建一个，由 trade 处理器与价格刷新任务共用
+	topCards := service.NewTopCardsService(cfg, logger, repo)
+	// token 成交统计带后台写入协程，同样全局只创建一个：trade 处理器写入，行情刷新任务读取
+	tradeStats := service.NewTokenTradeStatsService(cfg, logger, repo)
+	// 余额写入服务持有固定 id 的 writer，重复创建会争用同一落盘文件，全局只创建一个
+	balance := service.NewBalanceUpdate(cfg, logger, repo)
+
+	// 初始化作业调度器，singleton 作业通过 Redis 租约保证多副本下只有一个实例执行
+	locker := job.NewLeaseLocker(repo.GetMainRDB(), time.Duration(cfg.Worker.JobLockTTL)*time.Second)
+	scheduler := job.NewScheduler(logger, locker, repo.GetDAOManager().JobHistoryDAO)
+
 	// 加载历史数据
 	cacheLoad := job.NewCacheLoad(cfg, repo, logger)
-	scheduler.RegisterOnceJob("cache_load", cacheLoad.Run)
+	scheduler.RegisterOnceJob("cache_load", job.JobPerInstance, cacheLoad.Run)
 
 	// 注册定时清理任务 - 每小时执行一次
 	transactionCleanup := job.NewTransactionCleanup(cfg, repo, logger)
-	scheduler.RegisterJob("transaction_cleanup", 1*time.Hour, transactionCleanup.Run)
+	scheduler.RegisterJob("transaction_cleanup", 1*time.Hour, job.JobSingleton, transactionCleanup.Run)
 
-	tokenBalance := job.NewTokenBalance(cfg, repo, logger)
-	scheduler.RegisterJob("token_balance", 10*time.Minute, tokenBalance.Run)
+	tokenBalance := job.NewTokenBalance(cfg, repo, logger, balance)
+	scheduler.RegisterJob("token_balance", 10*time.Minute, job.JobSingleton, tokenBalance.Run)
 
-	// 定時：聰明錢資料聚合更新（每 2 小時）
+	// 定時：聰明錢資料全量掃描（每 6 小時），兜底處理無交易錢包因時間窗口滑出產生的指標變化
 	analyzer := job.NewSmartMoneyAnalyzer(repo, logger)
 	analyzer.Cfg = cfg
-	scheduler.RegisterJob("smart_money_analyze", 2*time.Hour, analyzer.Run)
+	// 全量掃描耗時長：單次最多執行 100 分鐘，超時中斷後下一次從斷點繼續；多實例錯峰啟動
+	scheduler.RegisterJob("smart_money_analyze", 6*time.Hour, job.JobSingleton, analyzer.Run,
+		job.WithTimeout(100*time.Minute), job.WithJitter(time.Minute))
+
+	// 定時：聰明錢資料增量更新，只重算有新交易的錢包（每 5 分鐘）
+	// 與全量掃描共用租約，全量執行期間跳過，dirty set 留到下一輪處理
+	scheduler.RegisterJob("smart_money_analyze_incremental", 5*time.Minute, job.JobSingleton, analyzer.RunIncremental,
+		job.WithLockName("smart_money_analyze"))
 
 	// 定時：聰明錢錢包分類器（每 6 小時）
 	classifier := job.NewSmartWalletClassifier(repo, logger)
-	scheduler.RegisterJob("smart_wallet_classifier", 6*time.Hour, classifier.Run)
+	scheduler.RegisterJob("smart_wallet_classifier", 6*time.Hour, job.JobSingleton, classifier.Run)
 
 	// 定時：處理缺失的token info（每 20 秒）
 	handleMissingTokenInfo := job.NewHandleMissingTokenInfo(cfg, repo, logger)
-	scheduler.RegisterJob("handle_missing_tokeninfo", 20*time.Second, handleMissingTokenInfo.Run)
+	scheduler.RegisterJob("handle_missing_tokeninfo", 20*time.Second, job.JobSingleton, handleMissingTokenInfo.Run)
+
+	// 定時：token 維度聰明錢資訊聚合（每 10 分鐘）
+	tokenSmartMoney := job.NewTokenSmartMoney(cfg, repo, logger)
+	scheduler.RegisterJob("token_smart_money", 10*time.Minute, job.JobSingleton, tokenSmartMoney.Run)
+
+	// 定時：聰明錢資金流時間序列壓縮到 SelectDB（每 5 分鐘）
+	smartFlowCompaction := job.NewSmartFlowCompaction(cfg, repo, logger)
+	scheduler.RegisterJob("smart_flow_compaction", 5*time.Minute, job.JobSingleton, smartFlowCompaction.Run)
+
+	// 定時：小卡片價格與週期漲跌幅刷新（每 30 秒）
+	topCardsPriceRefresh := job.NewTopCardsPriceRefresh(cfg, repo, logger, topCards)
+	scheduler.RegisterJob("top_cards_price_refresh", 30*time.Second, job.JobSingleton, topCardsPriceRefresh.Run)
+
+	// 定時：最新交易對 Top50 價格、市值、24h 成交額與漲跌幅（每 30 秒）
+	transactionPairsMarketRefresh := job.NewTransactionPairsMarketRefresh(cfg, repo, logger, tradeStats)
+	scheduler.RegisterJob("transaction_pairs_market_refresh", 30*time.Second, job.JobSingleton, transactionPairsMarketRefresh.Run)
+
+	// 定時：錢包排行榜物化到 Redis（每 10 分鐘）
+	walletLeaderboard := job.NewWalletLeaderboard(cfg, repo, logger)
+	scheduler.RegisterJob("wallet_leaderboard", 10*time.Minute, job.JobSingleton, walletLeaderboard.Run)
+
+	// 定時：錢包每日快照（每 10 分鐘檢查，跨 UTC 日後生成前一日快照）
+	walletDailySnapshot := job.NewWalletDailySnapshot(cfg, repo, logger)
+	scheduler.RegisterJob("wallet_daily_snapshot", 10*time.Minute, job.JobSingleton, walletDailySnapshot.Run)
+
+	// 定時：錢包資產淨值曲線與最大回撤（每 10 分鐘檢查，活躍錢包每小時、其他錢包每天一筆）
+	walletEquitySnapshot := job.NewWalletEquitySnapshot(cfg, repo, logger)
+	scheduler.RegisterJob("wallet_equity_snapshot", 10*time.Minute, job.JobSingleton, walletEquitySnapshot.Run)
+
+	// 定時：最新交易對榜單 token 的早期買家（每 5 分鐘）
+	earlyBuyersRefresh := job.NewEarlyBuyersRefresh(cfg, repo, logger)
+	scheduler.RegisterJob("early_buyers_refresh", 5*time.Minute, job.JobSingleton, earlyBuyersRefresh.Run)
+
+	// 定時：清理過期的作業執行記錄（每天 UTC 03:20）
+	jobHistoryCleanup := job.NewJobHistoryCleanup(cfg, repo, logger)
+	scheduler.RegisterJob("job_history_cleanup", 0, job.JobSingleton, jobHistoryCleanup.Run,
+		job.MustCron("20 3 * * *"), job.WithRetry(2, time.Minute))
 
 	// 初始化消费者
+	tradeConsumer := consumer.NewTradeConsumer(cfg, logger, repo, topCards, tradeStats)
 	consumers := []consumer.KafkaConsumer{
-		consumer.NewTradeConsumer(cfg, logger, repo),
-		consumer.NewBalanceConsumer(cfg, logger, repo),
+		tradeConsumer,
+		consumer.NewBalanceConsumer(cfg, logger, repo, balance),
+	}
+
+	// 外部 webhook 推送（独立消费组消费聪明钱交易）
+	var webhookDelivery *service.WebhookDeliveryService
+	if cfg.Webhook.Enable {
+		webhookConsumer := consumer.NewWebhookConsumer(cfg, logger, repo)
+		webhookDelivery = webhookConsumer.Delivery()
+		consumers = append(consumers, webhookConsumer)
 	}
 
 	core := &Core{
-		cfg:       cfg,
-		repo:      repo,
-		tl:        logger,
-		scheduler: scheduler,
-		consumers: consumers,
-		metrics:   monitor.NewMetricsServer(cfg.Monitor),
+		cfg:        cfg,
+		repo:       repo,
+		tl:         logger,
+		scheduler:  scheduler,
+		consumers:  consumers,
+		metrics:    monitor.NewMetricsServer(cfg.Monitor, newHealthChecker(cfg, repo, consumers, tradeConsumer)),
+		admin:      admin.NewServer(cfg, logger, repo, webhookDelivery, scheduler),
+		topCards:   topCards,
+		tradeStats: tradeStats,
+		balance:    balance,
 	}
 	return core
 }
 
+// newHealthChecker 组装 /healthz 与 /readyz 的检查项
+func newHealthChecker(cfg config.Config, repo repository.Repository, consumers []consumer.KafkaConsumer, trade *consumer.TradeConsumer) *monitor.HealthChecker {
+	checker := monitor.NewHealthChecker(cfg.Monitor)
+	for name, fn := range repo.HealthChecks() {
+		checker.AddLiveness(name, fn)
+	}
+
+	// 消费积压
+	if maxLag := cfg.Monitor.MaxConsumerLag; maxLag > 0 {
+		for _, cons := range consumers {
+			lc, ok := cons.(interface{ Lag() int64 })
+			if !ok {
+				continue
+			}
+			checker.AddReadiness("kafka_lag:"+cons.ID(), func(ctx context.Context) error {
+				if lag := lc.Lag(); lag > maxLag {
+					return fmt.Errorf("lag %d exceeds %d", lag, maxLag)
+				}
+				return nil
+			})
+		}
+	}
+
+	// 异步写入队列饱和度
+	if maxSaturation := cfg.Monitor.MaxWriterSaturation; maxSaturation > 0 {
+		checker.AddReadiness("writer_queue", func(ctx context.Context) error {
+			for name, saturation := range writer.QueueSaturation() {
+				if saturation >= maxSaturation {
+					return fmt.Errorf("writer %s queue %.0f%% full", name, saturation*100)
+				}
+			}
+			return nil
+		})
+	}
+
+	// trade 消费停滞
+	if cfg.Monitor.TradeIdleTimeout > 0 {
+		idle := time.Duration(cfg.Monitor.TradeIdleTimeout) * time.Second
+		checker.AddReadiness("trade_consumer_idle", func(ctx context.Context) error {
+			if since := time.Since(trade.LastMessageAt()); since > idle {
+				return fmt.Errorf("no trade message for %s", since.Truncate(time.Second))
+			}
+			return nil
+		})
+	}
+	return checker
+}
+
 func (c *Core) Start(ctx context.Context) {
 	c.tl.Info("Starting worker core...")
 	// 启动监控服务
@@ -77,6 +201,11 @@ func (c *Core) Start(ctx context.Context) {
 		c.metrics.Run()
 	}
 
+	// 启动管理接口
+	if c.admin != nil {
+		c.admin.Run()
+	}
+
 	// 启动消费者
 	for _, cons := range c.consumers {
 		go cons.Run(ctx)
@@ -106,6 +235,16 @@ func (c *Core) Stop(ctx context.Context) {
 		c.scheduler.Stop(ctx)
 	}
 
+	// 消费者与调度器停止后再关闭共用的服务
+	c.topCards.Close()
+	c.tradeStats.Close()
+	_ = c.balance.Stop()
+
+	// 停止管理接口
+	if c.admin != nil {
+		_ = c.admin.Stop(ctx)
+	}
+
 	// 停止 Prometheus 监控服务
 	if c.metrics != nil {
 		_ = c.metrics.Stop(ctx)
@@ -10,18 +10,28 @@ import (
 
 // DAOManager 管理所有DAO实例
 type DAOManager struct {
-	HoldingDAO HoldingDAO
-	TokenDAO   TokenDAO
-	PairsDAO   PairsDAO
-	WalletDAO  WalletDAO
+	HoldingDAO      HoldingDAO
+	TokenDAO        TokenDAO
+	PairsDAO        PairsDAO
+	WalletDAO       WalletDAO
+	WebhookDAO      WebhookDAO
+	WalletDailyDAO  WalletDailyDAO
+	WalletEquityDAO WalletEquityDAO
+	TransactionDAO  TransactionDAO
+	JobHistoryDAO   JobHistoryDAO
 }
 
 // NewDAOManager 创建DAO管理器实例
 func NewDAOManager(cfg *config.Config, db *gorm.DB, es *elasticsearch.Client, rds *redis.Client) *DAOManager {
 	return &DAOManager{
-		HoldingDAO: NewHoldingDAO(cfg, db, rds),
-		TokenDAO:   NewTokenDAO(cfg, db, es, rds),
-		PairsDAO:   NewPairsDAO(cfg, db, rds),
-		WalletDAO:  NewWalletDAO(cfg, db, rds),
+		HoldingDAO:      NewHoldingDAO(cfg, db, rds),
+		TokenDAO:        NewTokenDAO(cfg, db, es, rds),
+		PairsDAO:        NewPairsDAO(cfg, db, rds),
+		WalletDAO:       NewWalletDAO(cfg, db, rds),
+		WebhookDAO:      NewWebhookDAO(cfg, db),
+		WalletDailyDAO:  NewWalletDailyDAO(cfg, db),
+		WalletEquityDAO: NewWalletEquityDAO(cfg, db),
+		TransactionDAO:  NewTransactionDAO(cfg, db),
+		JobHistoryDAO:   NewJobHistoryDAO(cfg, db),
 	}
 }
@@ -28,6 +28,30 @@ type HoldingDAO interface {
 	// GetActiveHoldings 获取未清仓的持仓信息
 	GetActiveHoldings(ctx context.Context, walletAddress string) ([]*model.WalletHolding, error)
 
+	// CountHolders 统计某 token 当前未清仓的持仓钱包数
+	CountHolders(ctx context.Context, chainID uint64, tokenAddress string) (int64, error)
+
+	// ListByWallet 按条件分页查询钱包持仓，返回当前页与总数
+	ListByWallet(ctx context.Context, filter HoldingFilter) ([]*model.WalletHolding, int64, error)
+
 	// UpdateHoldingCache 更新持仓缓存
 	UpdateHoldingCache(ctx context.Context, cacheKey string, holding *model.WalletHolding) error
 }
+
+const (
+	HOLDING_STATUS_ALL    = "all"    // 全部
+	HOLDING_STATUS_ACTIVE = "active" // 未清仓
+	HOLDING_STATUS_CLOSED = "closed" // 已清仓
+)
+
+// HoldingFilter 持仓查询条件，零值表示不过滤
+type HoldingFilter struct {
+	ChainID       uint64
+	WalletAddress string
+	TokenAddress  string
+	Status        string  // all / active / closed，默认 all
+	MinValueUSD   float64 // 最小持仓价值USD
+	SortBy        string  // value_usd / unrealized_profits / pnl / last_transaction_time，默认 last_transaction_time
+	Limit         int
+	Offset        int
+}
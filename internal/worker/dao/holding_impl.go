@@ -116,6 +116,45 @@ func (h *holdingDAO) GetByWallet(ctx context.Context, chainId uint64, walletAddr
 	return holdings, nil
 }
 
+// ListByWallet 按条件分页查询钱包持仓，返回当前页与总数
+func (h *holdingDAO) ListByWallet(ctx context.Context, filter HoldingFilter) ([]*model.WalletHolding, int64, error) {
+	query := h.db.WithContext(ctx).Model(&model.WalletHolding{}).
+		Where("chain_id = ? AND wallet_address = ?", filter.ChainID, filter.WalletAddress)
+	if filter.TokenAddress != "" {
+		query = query.Where("token_address = ?", filter.TokenAddress)
+	}
+	switch filter.Status {
+	case HOLDING_STATUS_ACTIVE:
+		query = query.Where("amount > 0")
+	case HOLDING_STATUS_CLOSED:
+		query = query.Where("amount <= 0")
+	}
+	if filter.MinValueUSD > 0 {
+		query = query.Where("value_usd >= ?", filter.MinValueUSD)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := "last_transaction_time DESC"
+	switch filter.SortBy {
+	case "value_usd", "unrealized_profits", "pnl":
+		orderBy = filter.SortBy + " DESC"
+	}
+
+	var holdings []*model.WalletHolding
+	err := query.Order(orderBy).Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&holdings).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return holdings, total, nil
+}
+
 // Create 创建新的持仓记录
 func (h *holdingDAO) Create(ctx context.Context, holding *model.WalletHolding) error {
 	err := h.db.WithContext(ctx).Create(holding).Error
@@ -156,6 +195,16 @@ func (h *holdingDAO) GetByChainAndWallet(ctx context.Context, chainID uint64, wa
 	return holdings, nil
 }
 
+// CountHolders 统计某 token 当前未清仓的持仓钱包数
+func (h *holdingDAO) CountHolders(ctx context.Context, chainID uint64, tokenAddress string) (int64, error) {
+	var count int64
+	err := h.db.WithContext(ctx).
+		Model(&model.WalletHolding{}).
+		Where("chain_id = ? AND token_address = ? AND amount > 0", chainID, tokenAddress).
+		Count(&count).Error
+	return count, err
+}
+
 // GetActiveHoldings 获取未清仓的持仓信息
 func (h *holdingDAO) GetActiveHoldings(ctx context.Context, walletAddress string) ([]*model.WalletHolding, error) {
 	var holdings []*model.WalletHolding
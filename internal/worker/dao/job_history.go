@@ -0,0 +1,18 @@
+package dao
+
+import (
+	"context"
+	"web3-smart/internal/worker/model"
+)
+
+// JobHistoryDAO 定义定时作业执行记录数据访问接口
+type JobHistoryDAO interface {
+	// CreateJobHistory 写入一条执行记录
+	CreateJobHistory(ctx context.Context, history *model.JobHistory) error
+
+	// ListJobHistory 按作业分页获取执行记录，按 id 倒序；jobName 为空表示全部作业
+	ListJobHistory(ctx context.Context, jobName string, limit, offset int) ([]*model.JobHistory, error)
+
+	// DeleteBefore 删除 started_at 早于 before（毫秒）的记录
+	DeleteBefore(ctx context.Context, before int64) (int64, error)
+}
@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"context"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"gorm.io/gorm"
+)
+
+// jobHistoryDAO 实现JobHistoryDAO接口
+type jobHistoryDAO struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// NewJobHistoryDAO 创建JobHistoryDAO实例
+func NewJobHistoryDAO(cfg *config.Config, db *gorm.DB) JobHistoryDAO {
+	return &jobHistoryDAO{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// CreateJobHistory 写入一条执行记录
+func (j *jobHistoryDAO) CreateJobHistory(ctx context.Context, history *model.JobHistory) error {
+	return j.db.WithContext(ctx).Create(history).Error
+}
+
+// ListJobHistory 按作业分页获取执行记录，按 id 倒序；jobName 为空表示全部作业
+func (j *jobHistoryDAO) ListJobHistory(ctx context.Context, jobName string, limit, offset int) ([]*model.JobHistory, error) {
+	var histories []*model.JobHistory
+	query := j.db.WithContext(ctx)
+	if jobName != "" {
+		query = query.Where("job_name = ?", jobName)
+	}
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&histories).Error
+	return histories, err
+}
+
+// DeleteBefore 删除 started_at 早于 before（毫秒）的记录
+func (j *jobHistoryDAO) DeleteBefore(ctx context.Context, before int64) (int64, error) {
+	result := j.db.WithContext(ctx).Where("started_at < ?", before).Delete(&model.JobHistory{})
+	return result.RowsAffected, result.Error
+}
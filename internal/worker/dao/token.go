@@ -27,4 +27,10 @@ type TokenDAO interface {
 
 	// UpdateTokenInfoCache 更新token info缓存
 	UpdateTokenInfoCache(ctx context.Context, cacheKey string, tokenInfo *model.SmTokenRet)
+
+	// GetSmartMoneyInfo 获取token的聪明钱聚合信息（smart_money_info 字段），token 不存在或尚未聚合时返回 nil
+	GetSmartMoneyInfo(ctx context.Context, chainID uint64, tokenAddress string) (*model.TokenSmartMoneyInfo, error)
+
+	// UpdateSmartMoneyInfo 更新token的聪明钱聚合信息（smart_money_info 字段）
+	UpdateSmartMoneyInfo(ctx context.Context, chainID uint64, tokenAddress string, info *model.TokenSmartMoneyInfo) error
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -237,6 +238,42 @@ func (t *tokenDAO) GetBySymbol(ctx context.Context, chainID uint64, symbol strin
 	return tokens, nil
 }
 
+// GetSmartMoneyInfo 获取token的聪明钱聚合信息（smart_money_info 字段），token 不存在或尚未聚合时返回 nil
+func (t *tokenDAO) GetSmartMoneyInfo(ctx context.Context, chainID uint64, tokenAddress string) (*model.TokenSmartMoneyInfo, error) {
+	var raw []datatypes.JSON
+	err := t.db.WithContext(ctx).
+		Model(&model.Token{}).
+		Where("chain_id = ? AND address = ? AND smart_money_info IS NOT NULL", chainID, tokenAddress).
+		Limit(1).
+		Pluck("smart_money_info", &raw).Error
+	if err != nil || len(raw) == 0 {
+		return nil, err
+	}
+
+	var info model.TokenSmartMoneyInfo
+	if err := sonic.Unmarshal(raw[0], &info); err != nil {
+		return nil, fmt.Errorf("unmarshal smart_money_info failed: %w", err)
+	}
+	return &info, nil
+}
+
+// UpdateSmartMoneyInfo 更新token的聪明钱聚合信息（smart_money_info 字段）
+func (t *tokenDAO) UpdateSmartMoneyInfo(ctx context.Context, chainID uint64, tokenAddress string, info *model.TokenSmartMoneyInfo) error {
+	if info == nil {
+		return nil
+	}
+
+	data, err := sonic.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("marshal smart_money_info failed: %w", err)
+	}
+
+	return t.db.WithContext(ctx).
+		Model(&model.Token{}).
+		Where("chain_id = ? AND address = ?", chainID, tokenAddress).
+		Update("smart_money_info", datatypes.JSON(data)).Error
+}
+
 // getTokenFromES 从ES查询token信息
 func (t *tokenDAO) getTokenFromES(ctx context.Context, chainID uint64, tokenAddress string) (*model.SmTokenRet, error) {
 	// 获取network名称
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"web3-smart/internal/worker/model"
+)
+
+// TransactionDAO 定义交易记录数据访问接口
+type TransactionDAO interface {
+	// ListByWallet 按 (transaction_time, id) 倒序游标分页查询钱包交易记录
+	ListByWallet(ctx context.Context, filter TransactionFilter) ([]*model.WalletTransaction, error)
+}
+
+// TransactionCursor 游标位置，返回早于该位置的记录
+type TransactionCursor struct {
+	TransactionTime int64
+	ID              int64
+}
+
+// Encode 编码为对外的分页游标：base64url("<transaction_time>:<id>")
+func (c *TransactionCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.TransactionTime, c.ID)))
+}
+
+// DecodeTransactionCursor 解析 Encode 生成的分页游标
+func DecodeTransactionCursor(v string) (*TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	ts, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+	c := &TransactionCursor{}
+	if c.TransactionTime, err = strconv.ParseInt(ts, 10, 64); err != nil {
+		return nil, err
+	}
+	if c.ID, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// TransactionFilter 交易记录查询条件，零值表示不过滤
+type TransactionFilter struct {
+	ChainID         uint64
+	WalletAddress   string
+	TokenAddress    string
+	TransactionType string // build / buy / sell / clean
+	Before          *TransactionCursor
+	Limit           int
+}
@@ -0,0 +1,44 @@
+package dao
+
+import (
+	"context"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"gorm.io/gorm"
+)
+
+// transactionDAO 实现TransactionDAO接口
+type transactionDAO struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// NewTransactionDAO 创建TransactionDAO实例
+func NewTransactionDAO(cfg *config.Config, db *gorm.DB) TransactionDAO {
+	return &transactionDAO{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// ListByWallet 按 (transaction_time, id) 倒序游标分页查询钱包交易记录
+func (t *transactionDAO) ListByWallet(ctx context.Context, filter TransactionFilter) ([]*model.WalletTransaction, error) {
+	query := t.db.WithContext(ctx).
+		Where("chain_id = ? AND wallet_address = ?", filter.ChainID, filter.WalletAddress)
+	if filter.TokenAddress != "" {
+		query = query.Where("token_address = ?", filter.TokenAddress)
+	}
+	if filter.TransactionType != "" {
+		query = query.Where("transaction_type = ?", filter.TransactionType)
+	}
+	if filter.Before != nil {
+		query = query.Where("(transaction_time, id) < (?, ?)", filter.Before.TransactionTime, filter.Before.ID)
+	}
+
+	var txs []*model.WalletTransaction
+	err := query.Order("transaction_time DESC").Order("id DESC").
+		Limit(filter.Limit).
+		Find(&txs).Error
+	return txs, err
+}
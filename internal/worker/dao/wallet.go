@@ -2,9 +2,13 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"web3-smart/internal/worker/model"
 )
 
+// ErrWalletExists 新增跟踪的钱包已存在（wallet_address 唯一）
+var ErrWalletExists = errors.New("wallet already exists")
+
 // WalletDAO 定义wallet数据访问接口
 type WalletDAO interface {
 	// GetByWalletAddress 通过钱包地址查询钱包摘要信息（核心需求）
@@ -25,8 +29,8 @@ type WalletDAO interface {
 	// GetActiveWallets 获取活跃钱包
 	GetActiveWallets(ctx context.Context, limit, offset int) ([]*model.WalletSummary, error)
 
-	// GetTopPerformers 获取表现最好的钱包（按PNL排序）
-	GetTopPerformers(ctx context.Context, period string, limit, offset int) ([]*model.WalletSummary, error)
+	// GetTopPerformers 获取表现最好的钱包（按PNL排序），chainID 为 0 表示不限链，结果本地缓存 1 分钟
+	GetTopPerformers(ctx context.Context, chainID uint64, period string, limit, offset int) ([]*model.WalletSummary, error)
 
 	// GetByTwitterUsername 通过Twitter用户名查询钱包
 	GetByTwitterUsername(ctx context.Context, twitterUsername string) ([]*model.WalletSummary, error)
@@ -43,9 +47,18 @@ type WalletDAO interface {
 	// BatchUpdate 批量更新钱包信息
 	BatchUpdate(ctx context.Context, wallets []*model.WalletSummary) error
 
-	// GetWalletStats 获取钱包统计信息
+	// GetWalletStats 获取钱包统计信息，结果本地缓存 1 分钟
 	GetWalletStats(ctx context.Context, walletAddress string) (*model.WalletStats, error)
 
 	// UpdateWalletCache 更新钱包缓存
 	UpdateWalletCache(ctx context.Context, cacheKey string, wallet *model.WalletSummary) error
+
+	// CreateTracked 人工新增跟踪钱包并写入审计记录，钱包已存在时返回 ErrWalletExists
+	CreateTracked(ctx context.Context, wallet *model.WalletSummary, audit *model.WalletAudit) error
+
+	// UpdateManualFields 锁定钱包行后由 mutate 修改人工字段，与审计记录同一事务提交并刷新缓存；钱包不存在时返回 nil
+	UpdateManualFields(ctx context.Context, chainID uint64, walletAddress string, mutate func(wallet *model.WalletSummary) error, audit *model.WalletAudit) (*model.WalletSummary, error)
+
+	// ListAudits 获取钱包人工变更审计记录，按时间倒序
+	ListAudits(ctx context.Context, chainID uint64, walletAddress string, limit, offset int) ([]*model.WalletAudit, error)
 }
@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"web3-smart/internal/worker/model"
+)
+
+// WalletDailyDAO 定义钱包每日快照数据访问接口
+type WalletDailyDAO interface {
+	// SnapshotDay 生成指定 UTC 自然日的完整快照（交易聚合 + 当前持仓/余额），已存在则覆盖
+	SnapshotDay(ctx context.Context, day time.Time) (int64, error)
+
+	// RebuildDay 从 t_smart_transaction 重建指定 UTC 自然日的已实现盈亏与买卖次数，不覆盖快照时刻字段
+	RebuildDay(ctx context.Context, day time.Time) (int64, error)
+
+	// GetLatestSnapshotDay 获取已生成快照的最新日期，只有回填数据的日期不算，没有数据时返回零值
+	GetLatestSnapshotDay(ctx context.Context) (time.Time, error)
+
+	// GetWalletDaily 获取钱包在 [from, to] 区间内的每日快照，按日期升序
+	GetWalletDaily(ctx context.Context, chainID uint64, walletAddress string, from, to time.Time) ([]*model.WalletDaily, error)
+}
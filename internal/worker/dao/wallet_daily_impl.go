@@ -0,0 +1,127 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"gorm.io/gorm"
+)
+
+// walletDailyTxAggSQL 当日交易聚合，参数：[start_ms, end_ms)
+const walletDailyTxAggSQL = `
+SELECT chain_id, wallet_address,
+       SUM(realized_profit) AS realized_pnl,
+       COUNT(*) FILTER (WHERE transaction_type IN ('build', 'buy')) AS buy_num,
+       COUNT(*) FILTER (WHERE transaction_type IN ('sell', 'clean')) AS sell_num
+FROM dex_query_v1.t_smart_transaction
+WHERE transaction_time >= @start AND transaction_time < @end
+GROUP BY chain_id, wallet_address`
+
+// walletDailySnapshotSQL 活跃钱包或当日有交易的钱包各生成一行
+const walletDailySnapshotSQL = `
+INSERT INTO dex_query_v1.t_smart_wallet_daily
+  (chain_id, wallet_address, day, realized_pnl, unrealized_pnl, balance, balance_usd,
+   buy_num, sell_num, open_positions, snapshot_at, created_at, updated_at)
+SELECT w.chain_id, w.wallet_address, @day,
+       COALESCE(tx.realized_pnl, 0), COALESCE(h.unrealized_pnl, 0), w.balance, w.balance_usd,
+       COALESCE(tx.buy_num, 0), COALESCE(tx.sell_num, 0), COALESCE(h.open_positions, 0),
+       @now, @now, @now
+FROM dex_query_v1.t_smart_wallet w
+LEFT JOIN (` + walletDailyTxAggSQL + `) tx
+  ON tx.chain_id = w.chain_id AND tx.wallet_address = w.wallet_address
+LEFT JOIN (
+  SELECT chain_id, wallet_address,
+         SUM(unrealized_profits) AS unrealized_pnl,
+         COUNT(*) AS open_positions
+  FROM dex_query_v1.t_smart_holding
+  WHERE amount > 0
+  GROUP BY chain_id, wallet_address
+) h ON h.chain_id = w.chain_id AND h.wallet_address = w.wallet_address
+WHERE w.is_active = TRUE OR tx.wallet_address IS NOT NULL
+ON CONFLICT (chain_id, wallet_address, day) DO UPDATE SET
+  realized_pnl = EXCLUDED.realized_pnl,
+  unrealized_pnl = EXCLUDED.unrealized_pnl,
+  balance = EXCLUDED.balance,
+  balance_usd = EXCLUDED.balance_usd,
+  buy_num = EXCLUDED.buy_num,
+  sell_num = EXCLUDED.sell_num,
+  open_positions = EXCLUDED.open_positions,
+  snapshot_at = EXCLUDED.snapshot_at,
+  updated_at = EXCLUDED.updated_at`
+
+// walletDailyRebuildSQL 仅重建交易聚合字段，快照时刻字段保留已有值（新行为 0）
+const walletDailyRebuildSQL = `
+INSERT INTO dex_query_v1.t_smart_wallet_daily
+  (chain_id, wallet_address, day, realized_pnl, buy_num, sell_num, created_at, updated_at)
+SELECT tx.chain_id, tx.wallet_address, @day, tx.realized_pnl, tx.buy_num, tx.sell_num, @now, @now
+FROM (` + walletDailyTxAggSQL + `) tx
+ON CONFLICT (chain_id, wallet_address, day) DO UPDATE SET
+  realized_pnl = EXCLUDED.realized_pnl,
+  buy_num = EXCLUDED.buy_num,
+  sell_num = EXCLUDED.sell_num,
+  updated_at = EXCLUDED.updated_at`
+
+// walletDailyDAO 实现WalletDailyDAO接口
+type walletDailyDAO struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// NewWalletDailyDAO 创建WalletDailyDAO实例
+func NewWalletDailyDAO(cfg *config.Config, db *gorm.DB) WalletDailyDAO {
+	return &walletDailyDAO{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// SnapshotDay 生成指定 UTC 自然日的完整快照（交易聚合 + 当前持仓/余额），已存在则覆盖
+func (w *walletDailyDAO) SnapshotDay(ctx context.Context, day time.Time) (int64, error) {
+	result := w.db.WithContext(ctx).Exec(walletDailySnapshotSQL, dayArgs(day))
+	return result.RowsAffected, result.Error
+}
+
+// RebuildDay 从 t_smart_transaction 重建指定 UTC 自然日的已实现盈亏与买卖次数，不覆盖快照时刻字段
+func (w *walletDailyDAO) RebuildDay(ctx context.Context, day time.Time) (int64, error) {
+	result := w.db.WithContext(ctx).Exec(walletDailyRebuildSQL, dayArgs(day))
+	return result.RowsAffected, result.Error
+}
+
+// GetLatestSnapshotDay 获取已生成快照的最新日期，只有回填数据的日期不算，没有数据时返回零值
+func (w *walletDailyDAO) GetLatestSnapshotDay(ctx context.Context) (time.Time, error) {
+	var latest sql.NullTime
+	err := w.db.WithContext(ctx).
+		Model(&model.WalletDaily{}).
+		Select("MAX(day)").
+		Where("snapshot_at > 0").
+		Scan(&latest).Error
+	if err != nil || !latest.Valid {
+		return time.Time{}, err
+	}
+	return latest.Time.UTC(), nil
+}
+
+// GetWalletDaily 获取钱包在 [from, to] 区间内的每日快照，按日期升序
+func (w *walletDailyDAO) GetWalletDaily(ctx context.Context, chainID uint64, walletAddress string, from, to time.Time) ([]*model.WalletDaily, error) {
+	var rows []*model.WalletDaily
+	err := w.db.WithContext(ctx).
+		Where("chain_id = ? AND wallet_address = ? AND day >= ? AND day <= ?",
+			chainID, walletAddress, from.UTC().Format(time.DateOnly), to.UTC().Format(time.DateOnly)).
+		Order("day ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+// dayArgs 将 UTC 自然日转换为 SQL 命名参数
+func dayArgs(day time.Time) map[string]interface{} {
+	start := day.UTC().Truncate(24 * time.Hour)
+	return map[string]interface{}{
+		"day":   start.Format(time.DateOnly),
+		"start": start.UnixMilli(),
+		"end":   start.Add(24 * time.Hour).UnixMilli(),
+		"now":   time.Now().UnixMilli(),
+	}
+}
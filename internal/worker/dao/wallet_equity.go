@@ -0,0 +1,24 @@
+package dao
+
+import (
+	"context"
+	"web3-smart/internal/worker/model"
+)
+
+// WalletEquityDAO 定义钱包资产净值快照数据访问接口
+type WalletEquityDAO interface {
+	// SnapshotActive 为 activeSince（毫秒）之后有交易的钱包记录 ts 时刻的快照，已存在则跳过
+	SnapshotActive(ctx context.Context, ts, activeSince int64) (int64, error)
+
+	// SnapshotInactive 为其余钱包记录 ts 时刻的快照，已存在则跳过
+	SnapshotInactive(ctx context.Context, ts, activeSince int64) (int64, error)
+
+	// GetEquityCurve 获取钱包在 [from, to]（毫秒）区间内的资产净值曲线，按时间升序
+	GetEquityCurve(ctx context.Context, chainID uint64, walletAddress string, from, to int64) (model.EquityCurve, error)
+
+	// UpdateMaxDrawdown 根据 since（毫秒）之后的曲线重算 t_smart_wallet.max_drawdown_30d
+	UpdateMaxDrawdown(ctx context.Context, since int64) (int64, error)
+
+	// DeleteBefore 删除 ts 早于 before（毫秒）的快照
+	DeleteBefore(ctx context.Context, before int64) (int64, error)
+}
@@ -0,0 +1,101 @@
+package dao
+
+import (
+	"context"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"gorm.io/gorm"
+)
+
+// walletEquitySnapshotSQL 资产净值快照，@active 控制选取活跃或非活跃钱包
+const walletEquitySnapshotSQL = `
+INSERT INTO dex_query_v1.t_smart_wallet_equity
+  (chain_id, wallet_address, ts, balance_usd, holding_value_usd, equity_usd, created_at)
+SELECT w.chain_id, w.wallet_address, @ts,
+       w.balance_usd, COALESCE(h.value_usd, 0), w.balance_usd + COALESCE(h.value_usd, 0),
+       @now
+FROM dex_query_v1.t_smart_wallet w
+LEFT JOIN (
+  SELECT chain_id, wallet_address, SUM(value_usd) AS value_usd
+  FROM dex_query_v1.t_smart_holding
+  WHERE amount > 0
+  GROUP BY chain_id, wallet_address
+) h ON h.chain_id = w.chain_id AND h.wallet_address = w.wallet_address
+WHERE (COALESCE(w.last_transaction_time, 0) >= @active_since) = @active
+ON CONFLICT (chain_id, wallet_address, ts) DO NOTHING`
+
+// walletEquityDrawdownSQL 按曲线的历史峰值计算最大回撤
+const walletEquityDrawdownSQL = `
+UPDATE dex_query_v1.t_smart_wallet w
+SET max_drawdown_30d = d.max_drawdown
+FROM (
+  SELECT chain_id, wallet_address,
+         MAX(CASE WHEN peak > 0 THEN (peak - equity_usd) / peak ELSE 0 END) AS max_drawdown
+  FROM (
+    SELECT chain_id, wallet_address, equity_usd,
+           MAX(equity_usd) OVER (PARTITION BY chain_id, wallet_address ORDER BY ts) AS peak
+    FROM dex_query_v1.t_smart_wallet_equity
+    WHERE ts >= @since
+  ) c
+  GROUP BY chain_id, wallet_address
+) d
+WHERE w.chain_id = d.chain_id AND w.wallet_address = d.wallet_address
+  AND w.max_drawdown_30d <> d.max_drawdown`
+
+// walletEquityDAO 实现WalletEquityDAO接口
+type walletEquityDAO struct {
+	cfg *config.Config
+	db  *gorm.DB
+}
+
+// NewWalletEquityDAO 创建WalletEquityDAO实例
+func NewWalletEquityDAO(cfg *config.Config, db *gorm.DB) WalletEquityDAO {
+	return &walletEquityDAO{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// SnapshotActive 为 activeSince（毫秒）之后有交易的钱包记录 ts 时刻的快照，已存在则跳过
+func (w *walletEquityDAO) SnapshotActive(ctx context.Context, ts, activeSince int64) (int64, error) {
+	return w.snapshot(ctx, ts, activeSince, true)
+}
+
+// SnapshotInactive 为其余钱包记录 ts 时刻的快照，已存在则跳过
+func (w *walletEquityDAO) SnapshotInactive(ctx context.Context, ts, activeSince int64) (int64, error) {
+	return w.snapshot(ctx, ts, activeSince, false)
+}
+
+func (w *walletEquityDAO) snapshot(ctx context.Context, ts, activeSince int64, active bool) (int64, error) {
+	result := w.db.WithContext(ctx).Exec(walletEquitySnapshotSQL, map[string]interface{}{
+		"ts":           ts,
+		"now":          time.Now().UnixMilli(),
+		"active_since": activeSince,
+		"active":       active,
+	})
+	return result.RowsAffected, result.Error
+}
+
+// GetEquityCurve 获取钱包在 [from, to]（毫秒）区间内的资产净值曲线，按时间升序
+func (w *walletEquityDAO) GetEquityCurve(ctx context.Context, chainID uint64, walletAddress string, from, to int64) (model.EquityCurve, error) {
+	var curve model.EquityCurve
+	err := w.db.WithContext(ctx).
+		Where("chain_id = ? AND wallet_address = ? AND ts >= ? AND ts <= ?", chainID, walletAddress, from, to).
+		Order("ts ASC").
+		Find(&curve).Error
+	return curve, err
+}
+
+// UpdateMaxDrawdown 根据 since（毫秒）之后的曲线重算 t_smart_wallet.max_drawdown_30d
+func (w *walletEquityDAO) UpdateMaxDrawdown(ctx context.Context, since int64) (int64, error) {
+	result := w.db.WithContext(ctx).Exec(walletEquityDrawdownSQL, map[string]interface{}{"since": since})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteBefore 删除 ts 早于 before（毫秒）的快照
+func (w *walletEquityDAO) DeleteBefore(ctx context.Context, before int64) (int64, error) {
+	result := w.db.WithContext(ctx).Where("ts < ?", before).Delete(&model.WalletEquity{})
+	return result.RowsAffected, result.Error
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/patrickmn/go-cache"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // walletDAO 实现WalletDAO接口
@@ -192,8 +193,15 @@ func (w *walletDAO) GetActiveWallets(ctx context.Context, limit, offset int) ([]
 	return wallets, nil
 }
 
-// GetTopPerformers 获取表现最好的钱包（按PNL排序）
-func (w *walletDAO) GetTopPerformers(ctx context.Context, period string, limit, offset int) ([]*model.WalletSummary, error) {
+// GetTopPerformers 获取表现最好的钱包（按PNL排序），chainID 为 0 表示不限链，结果本地缓存 1 分钟
+func (w *walletDAO) GetTopPerformers(ctx context.Context, chainID uint64, period string, limit, offset int) ([]*model.WalletSummary, error) {
+	cacheKey := fmt.Sprintf("wallet:top_performers:%d:%s:%d:%d", chainID, period, limit, offset)
+	if cached, found := w.localCache.Get(cacheKey); found {
+		if wallets, ok := cached.([]*model.WalletSummary); ok {
+			return wallets, nil
+		}
+	}
+
 	var wallets []*model.WalletSummary
 	var orderBy string
 
@@ -208,8 +216,11 @@ func (w *walletDAO) GetTopPerformers(ctx context.Context, period string, limit,
 		orderBy = "pnl_30d DESC"
 	}
 
-	err := w.db.WithContext(ctx).
-		Where("is_active = ?", true).
+	query := w.db.WithContext(ctx).Where("is_active = ?", true)
+	if chainID != 0 {
+		query = query.Where("chain_id = ?", chainID)
+	}
+	err := query.
 		Order(orderBy).
 		Limit(limit).
 		Offset(offset).
@@ -219,6 +230,7 @@ func (w *walletDAO) GetTopPerformers(ctx context.Context, period string, limit,
 		return nil, err
 	}
 
+	w.localCache.Set(cacheKey, wallets, time.Minute)
 	return wallets, nil
 }
 
@@ -271,6 +283,13 @@ func (w *walletDAO) BatchUpdate(ctx context.Context, wallets []*model.WalletSumm
 
 // GetWalletStats 获取钱包统计信息
 func (w *walletDAO) GetWalletStats(ctx context.Context, walletAddress string) (*model.WalletStats, error) {
+	const cacheKey = "wallet:stats"
+	if cached, found := w.localCache.Get(cacheKey); found {
+		if stats, ok := cached.(*model.WalletStats); ok {
+			return stats, nil
+		}
+	}
+
 	var stats model.WalletStats
 
 	// 获取总钱包数
@@ -315,5 +334,99 @@ func (w *walletDAO) GetWalletStats(ctx context.Context, walletAddress string) (*
 		return nil, fmt.Errorf("获取聪明钱数量失败: %w", err)
 	}
 
+	w.localCache.Set(cacheKey, &stats, time.Minute)
 	return &stats, nil
 }
+
+// CreateTracked 人工新增跟踪钱包并写入审计记录
+func (w *walletDAO) CreateTracked(ctx context.Context, wallet *model.WalletSummary, audit *model.WalletAudit) error {
+	after, err := sonic.Marshal(wallet.ManualFields())
+	if err != nil {
+		return err
+	}
+	audit.After = after
+
+	err = w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "wallet_address"}},
+			DoNothing: true,
+		}).Create(wallet)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrWalletExists
+		}
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// 覆盖可能存在的空结果缓存，交易侧立即开始跟踪
+	_ = w.UpdateWalletCache(ctx, utils.WalletSummaryKey(wallet.ChainID, wallet.WalletAddress), wallet)
+	return nil
+}
+
+// UpdateManualFields 锁定钱包行后修改人工字段，与审计记录同一事务提交
+func (w *walletDAO) UpdateManualFields(ctx context.Context, chainID uint64, walletAddress string, mutate func(wallet *model.WalletSummary) error, audit *model.WalletAudit) (*model.WalletSummary, error) {
+	var wallet model.WalletSummary
+	err := w.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("chain_id = ? AND wallet_address = ?", chainID, walletAddress).
+			First(&wallet).Error
+		if err != nil {
+			return err
+		}
+
+		before, err := sonic.Marshal(wallet.ManualFields())
+		if err != nil {
+			return err
+		}
+		if err := mutate(&wallet); err != nil {
+			return err
+		}
+		after, err := sonic.Marshal(wallet.ManualFields())
+		if err != nil {
+			return err
+		}
+		audit.Before, audit.After = before, after
+
+		wallet.UpdatedAt = time.Now().UnixMilli()
+		err = tx.Model(&model.WalletSummary{}).
+			Where("id = ?", wallet.ID).
+			Updates(map[string]interface{}{
+				"tags":             wallet.Tags,
+				"twitter_name":     wallet.TwitterName,
+				"twitter_username": wallet.TwitterUsername,
+				"wallet_type":      wallet.WalletType,
+				"updated_at":       wallet.UpdatedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	// 交易侧从缓存读取钱包后整行回写，必须用最新数据覆盖缓存，否则人工修改会被旧缓存覆盖
+	_ = w.UpdateWalletCache(ctx, utils.WalletSummaryKey(chainID, walletAddress), &wallet)
+	return &wallet, nil
+}
+
+// ListAudits 获取钱包人工变更审计记录
+func (w *walletDAO) ListAudits(ctx context.Context, chainID uint64, walletAddress string, limit, offset int) ([]*model.WalletAudit, error) {
+	var audits []*model.WalletAudit
+	err := w.db.WithContext(ctx).
+		Where("chain_id = ? AND wallet_address = ?", chainID, walletAddress).
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&audits).Error
+	return audits, err
+}
@@ -0,0 +1,39 @@
+package dao
+
+import (
+	"context"
+	"web3-smart/internal/worker/model"
+)
+
+// WebhookDAO 定义 webhook 订阅及投递记录数据访问接口
+type WebhookDAO interface {
+	// ListSubscriptions 获取全部订阅
+	ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+
+	// ListEnabledSubscriptions 获取启用中的订阅（带本地缓存，供投递热路径使用）
+	ListEnabledSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error)
+
+	// GetSubscription 通过ID获取订阅
+	GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error)
+
+	// CreateSubscription 创建订阅
+	CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+
+	// UpdateSubscription 更新订阅
+	UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error
+
+	// DeleteSubscription 删除订阅
+	DeleteSubscription(ctx context.Context, id int64) error
+
+	// CreateDelivery 记录投递失败（parked）
+	CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+
+	// GetDelivery 通过ID获取投递记录
+	GetDelivery(ctx context.Context, id int64) (*model.WebhookDelivery, error)
+
+	// ListDeliveries 按状态分页获取投递记录，status 为空表示全部
+	ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error)
+
+	// UpdateDelivery 更新投递记录
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
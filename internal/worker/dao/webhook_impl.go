@@ -0,0 +1,137 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"github.com/patrickmn/go-cache"
+	"gorm.io/gorm"
+)
+
+const webhookEnabledCacheKey = "webhook:subscriptions:enabled"
+
+// webhookDAO 实现WebhookDAO接口
+type webhookDAO struct {
+	cfg        *config.Config
+	db         *gorm.DB
+	localCache *cache.Cache
+}
+
+// NewWebhookDAO 创建WebhookDAO实例
+func NewWebhookDAO(cfg *config.Config, db *gorm.DB) WebhookDAO {
+	// 订阅变更频率很低，短缓存即可；本实例的写操作会主动失效
+	localCache := cache.New(30*time.Second, time.Minute)
+	return &webhookDAO{
+		cfg:        cfg,
+		db:         db,
+		localCache: localCache,
+	}
+}
+
+// ListSubscriptions 获取全部订阅
+func (w *webhookDAO) ListSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	var subs []*model.WebhookSubscription
+	err := w.db.WithContext(ctx).Order("id ASC").Find(&subs).Error
+	return subs, err
+}
+
+// ListEnabledSubscriptions 获取启用中的订阅（带本地缓存，供投递热路径使用）
+func (w *webhookDAO) ListEnabledSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	if cached, found := w.localCache.Get(webhookEnabledCacheKey); found {
+		if subs, ok := cached.([]*model.WebhookSubscription); ok {
+			return subs, nil
+		}
+	}
+
+	var subs []*model.WebhookSubscription
+	if err := w.db.WithContext(ctx).Where("enabled = ?", true).Find(&subs).Error; err != nil {
+		return nil, err
+	}
+	w.localCache.Set(webhookEnabledCacheKey, subs, cache.DefaultExpiration)
+	return subs, nil
+}
+
+// GetSubscription 通过ID获取订阅
+func (w *webhookDAO) GetSubscription(ctx context.Context, id int64) (*model.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&sub).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// CreateSubscription 创建订阅
+func (w *webhookDAO) CreateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	now := time.Now().UnixMilli()
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+	err := w.db.WithContext(ctx).Create(sub).Error
+	if err == nil {
+		w.localCache.Delete(webhookEnabledCacheKey)
+	}
+	return err
+}
+
+// UpdateSubscription 更新订阅
+func (w *webhookDAO) UpdateSubscription(ctx context.Context, sub *model.WebhookSubscription) error {
+	sub.UpdatedAt = time.Now().UnixMilli()
+	err := w.db.WithContext(ctx).Save(sub).Error
+	if err == nil {
+		w.localCache.Delete(webhookEnabledCacheKey)
+	}
+	return err
+}
+
+// DeleteSubscription 删除订阅
+func (w *webhookDAO) DeleteSubscription(ctx context.Context, id int64) error {
+	err := w.db.WithContext(ctx).Delete(&model.WebhookSubscription{}, id).Error
+	if err == nil {
+		w.localCache.Delete(webhookEnabledCacheKey)
+	}
+	return err
+}
+
+// CreateDelivery 记录投递失败（parked）
+func (w *webhookDAO) CreateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	now := time.Now().UnixMilli()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	return w.db.WithContext(ctx).Create(delivery).Error
+}
+
+// GetDelivery 通过ID获取投递记录
+func (w *webhookDAO) GetDelivery(ctx context.Context, id int64) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	err := w.db.WithContext(ctx).Where("id = ?", id).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries 按状态分页获取投递记录，status 为空表示全部
+func (w *webhookDAO) ListDeliveries(ctx context.Context, status string, limit, offset int) ([]*model.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	query := w.db.WithContext(ctx).Order("id DESC").Limit(limit).Offset(offset)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&deliveries).Error
+	return deliveries, err
+}
+
+// UpdateDelivery 更新投递记录
+func (w *webhookDAO) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	delivery.UpdatedAt = time.Now().UnixMilli()
+	return w.db.WithContext(ctx).Save(delivery).Error
+}
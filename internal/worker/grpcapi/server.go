@@ -0,0 +1,141 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/smartmoneypb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
+)
+
+// Server SmartMoneyService gRPC 服务
+//
+// 查询接口与 HTTP 只读接口（internal/worker/api）一致：基于 DAO，默认值、分页上限与游标格式相同，
+// decimal 字段转为字符串。StreamSmartTrades 委托给推送网关（stream.Server），与 WebSocket / SSE 共用同一个 Hub。
+type Server struct {
+	smartmoneypb.UnimplementedSmartMoneyServiceServer
+
+	cfg         config.GRPCConfig
+	tl          *zap.Logger
+	repo        repository.Repository
+	trades      TradeStreamer
+	maxPageSize int
+	server      *grpc.Server
+}
+
+// TradeStreamer 聪明钱实时成交推送，由 stream.Server 实现
+type TradeStreamer interface {
+	StreamSmartTrades(*smartmoneypb.StreamSmartTradesRequest, grpc.ServerStreamingServer[smartmoneypb.SmartTrade]) error
+}
+
+const (
+	defaultPageSize    = 50
+	defaultMaxPageSize = 200
+)
+
+func NewServer(cfg config.Config, logger *zap.Logger, repo repository.Repository, trades TradeStreamer) *Server {
+	s := &Server{
+		cfg:         cfg.GRPC,
+		tl:          logger,
+		repo:        repo,
+		trades:      trades,
+		maxPageSize: cfg.API.MaxPageSize,
+	}
+	if s.maxPageSize <= 0 {
+		s.maxPageSize = defaultMaxPageSize
+	}
+
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.recoverUnary),
+		grpc.ChainStreamInterceptor(s.recoverStream),
+		grpc.KeepaliveParams(keepalive.ServerParameters{Time: 30 * time.Second, Timeout: 10 * time.Second}),
+	)
+	smartmoneypb.RegisterSmartMoneyServiceServer(s.server, s)
+	return s
+}
+
+// Run 启动 gRPC 服务，未配置 addr 时不启动
+func (s *Server) Run() {
+	if s.cfg.Addr == "" {
+		return
+	}
+	lis, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		s.tl.Error("grpc server listen failed", zap.String("addr", s.cfg.Addr), zap.Error(err))
+		return
+	}
+	go func() {
+		s.tl.Info("grpc server listening", zap.String("addr", s.cfg.Addr))
+		if err := s.server.Serve(lis); err != nil {
+			s.tl.Error("grpc server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Stop 优雅关闭，超时后强制断开（推送流需先由 Hub.Close 结束）
+func (s *Server) Stop(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	select {
+	case <-done:
+	case <-shutdownCtx.Done():
+		s.server.Stop()
+	}
+	return nil
+}
+
+// StreamSmartTrades 聪明钱实时成交
+func (s *Server) StreamSmartTrades(req *smartmoneypb.StreamSmartTradesRequest, ss grpc.ServerStreamingServer[smartmoneypb.SmartTrade]) error {
+	if s.trades == nil {
+		return status.Error(codes.Unimplemented, "trade stream not available")
+	}
+	return s.trades.StreamSmartTrades(req, ss)
+}
+
+// recoverUnary 捕获 handler panic，统一返回 INTERNAL
+func (s *Server) recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.tl.Error("grpc handler panic", zap.String("method", info.FullMethod), zap.Any("panic", r))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func (s *Server) recoverStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.tl.Error("grpc handler panic", zap.String("method", info.FullMethod), zap.Any("panic", r))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, ss)
+}
+
+// pageLimit limit 默认 50，不超过 max_page_size
+func (s *Server) pageLimit(limit int32) int {
+	if limit <= 0 {
+		return defaultPageSize
+	}
+	return min(int(limit), s.maxPageSize)
+}
+
+// internalError 记录日志并返回 INTERNAL，不向调用方暴露内部错误
+func (s *Server) internalError(msg string, err error) error {
+	s.tl.Warn(msg, zap.Error(err))
+	return status.Error(codes.Internal, "internal error")
+}
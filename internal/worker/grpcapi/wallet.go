@@ -0,0 +1,234 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"web3-smart/internal/worker/dao"
+	"web3-smart/internal/worker/model"
+	"web3-smart/pkg/smartmoneypb"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) GetWallet(ctx context.Context, req *smartmoneypb.GetWalletRequest) (*smartmoneypb.Wallet, error) {
+	address := strings.TrimSpace(req.GetWalletAddress())
+	if address == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid wallet_address")
+	}
+	wallet, err := s.repo.GetDAOManager().WalletDAO.GetByWalletAddress(ctx, req.GetChainId(), address)
+	if err != nil {
+		return nil, s.internalError("get wallet failed", err)
+	}
+	if wallet == nil {
+		return nil, status.Error(codes.NotFound, "wallet not found")
+	}
+	return walletToPB(wallet), nil
+}
+
+func (s *Server) ListHoldings(ctx context.Context, req *smartmoneypb.ListHoldingsRequest) (*smartmoneypb.ListHoldingsResponse, error) {
+	address := strings.TrimSpace(req.GetWalletAddress())
+	if address == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid wallet_address")
+	}
+
+	filter := dao.HoldingFilter{
+		ChainID:       req.GetChainId(),
+		WalletAddress: address,
+		TokenAddress:  req.GetTokenAddress(),
+		Status:        req.GetStatus(),
+		SortBy:        req.GetSortBy(),
+		MinValueUSD:   req.GetMinValueUsd(),
+		Limit:         s.pageLimit(req.GetLimit()),
+		Offset:        max(int(req.GetOffset()), 0),
+	}
+	switch filter.Status {
+	case "", dao.HOLDING_STATUS_ALL, dao.HOLDING_STATUS_ACTIVE, dao.HOLDING_STATUS_CLOSED:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid status")
+	}
+	switch filter.SortBy {
+	case "", "value_usd", "unrealized_profits", "pnl", "last_transaction_time":
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid sort_by")
+	}
+	if filter.MinValueUSD < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid min_value_usd")
+	}
+
+	holdings, total, err := s.repo.GetDAOManager().HoldingDAO.ListByWallet(ctx, filter)
+	if err != nil {
+		return nil, s.internalError("list holdings failed", err)
+	}
+	resp := &smartmoneypb.ListHoldingsResponse{
+		Items: make([]*smartmoneypb.Holding, 0, len(holdings)),
+		Total: total,
+	}
+	for _, h := range holdings {
+		resp.Items = append(resp.Items, holdingToPB(h))
+	}
+	return resp, nil
+}
+
+func (s *Server) ListTransactions(ctx context.Context, req *smartmoneypb.ListTransactionsRequest) (*smartmoneypb.ListTransactionsResponse, error) {
+	address := strings.TrimSpace(req.GetWalletAddress())
+	if address == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid wallet_address")
+	}
+
+	filter := dao.TransactionFilter{
+		ChainID:         req.GetChainId(),
+		WalletAddress:   address,
+		TokenAddress:    req.GetTokenAddress(),
+		TransactionType: req.GetTransactionType(),
+		Limit:           s.pageLimit(req.GetLimit()),
+	}
+	switch filter.TransactionType {
+	case "", model.TX_TYPE_BUILD, model.TX_TYPE_BUY, model.TX_TYPE_SELL, model.TX_TYPE_CLEAN:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction_type")
+	}
+	if v := req.GetCursor(); v != "" {
+		cursor, err := dao.DecodeTransactionCursor(v)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		filter.Before = cursor
+	}
+
+	txs, err := s.repo.GetDAOManager().TransactionDAO.ListByWallet(ctx, filter)
+	if err != nil {
+		return nil, s.internalError("list transactions failed", err)
+	}
+	resp := &smartmoneypb.ListTransactionsResponse{
+		Items: make([]*smartmoneypb.Transaction, 0, len(txs)),
+	}
+	for _, tx := range txs {
+		resp.Items = append(resp.Items, transactionToPB(tx))
+	}
+	if len(txs) == filter.Limit {
+		last := txs[len(txs)-1]
+		resp.NextCursor = (&dao.TransactionCursor{TransactionTime: last.TransactionTime, ID: last.ID}).Encode()
+	}
+	return resp, nil
+}
+
+func (s *Server) GetTokenSmartMoney(ctx context.Context, req *smartmoneypb.GetTokenSmartMoneyRequest) (*smartmoneypb.TokenSmartMoney, error) {
+	tokenAddress := strings.TrimSpace(req.GetTokenAddress())
+	if tokenAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid token_address")
+	}
+	info, err := s.repo.GetDAOManager().TokenDAO.GetSmartMoneyInfo(ctx, req.GetChainId(), tokenAddress)
+	if err != nil {
+		return nil, s.internalError("get token smart money info failed", err)
+	}
+	if info == nil {
+		return nil, status.Error(codes.NotFound, "token smart money info not found")
+	}
+	return tokenSmartMoneyToPB(req.GetChainId(), tokenAddress, info), nil
+}
+
+func periodStatsToPB(pnl, pnlPercentage, unrealizedProfit, totalCost, avgCost, winRate decimal.Decimal, buyNum, sellNum int) *smartmoneypb.WalletPeriodStats {
+	return &smartmoneypb.WalletPeriodStats{
+		Pnl:              pnl.String(),
+		PnlPercentage:    pnlPercentage.String(),
+		UnrealizedProfit: unrealizedProfit.String(),
+		TotalCost:        totalCost.String(),
+		AvgCost:          avgCost.String(),
+		WinRate:          winRate.String(),
+		BuyNum:           int32(buyNum),
+		SellNum:          int32(sellNum),
+	}
+}
+
+func walletToPB(w *model.WalletSummary) *smartmoneypb.Wallet {
+	return &smartmoneypb.Wallet{
+		ChainId:             w.ChainID,
+		WalletAddress:       w.WalletAddress,
+		Avatar:              w.Avatar,
+		Tags:                w.Tags,
+		TwitterName:         w.TwitterName,
+		TwitterUsername:     w.TwitterUsername,
+		WalletType:          int32(w.WalletType),
+		Balance:             w.Balance.String(),
+		BalanceUsd:          w.BalanceUSD.String(),
+		Stats_1D:            periodStatsToPB(w.PNL1d, w.PNLPercentage1d, w.UnrealizedProfit1d, w.TotalCost1d, w.AvgCost1d, w.WinRate1d, w.BuyNum1d, w.SellNum1d),
+		Stats_7D:            periodStatsToPB(w.PNL7d, w.PNLPercentage7d, w.UnrealizedProfit7d, w.TotalCost7d, w.AvgCost7d, w.WinRate7d, w.BuyNum7d, w.SellNum7d),
+		Stats_30D:           periodStatsToPB(w.PNL30d, w.PNLPercentage30d, w.UnrealizedProfit30d, w.TotalCost30d, w.AvgCost30d, w.WinRate30d, w.BuyNum30d, w.SellNum30d),
+		MaxDrawdown_30D:     w.MaxDrawdown30d.String(),
+		LastTransactionTime: w.LastTransactionTime,
+		IsActive:            w.IsActive,
+		UpdatedAt:           w.UpdatedAt,
+	}
+}
+
+func holdingToPB(h *model.WalletHolding) *smartmoneypb.Holding {
+	pb := &smartmoneypb.Holding{
+		ChainId:             h.ChainID,
+		WalletAddress:       h.WalletAddress,
+		TokenAddress:        h.TokenAddress,
+		TokenName:           h.TokenName,
+		TokenIcon:           h.TokenIcon,
+		Amount:              h.Amount.String(),
+		ValueUsd:            h.ValueUSD.String(),
+		UnrealizedProfits:   h.UnrealizedProfits.String(),
+		Pnl:                 h.PNL.String(),
+		PnlPercentage:       h.PNLPercentage.String(),
+		AvgPrice:            h.AvgPrice.String(),
+		CurrentTotalCost:    h.CurrentTotalCost.String(),
+		Marketcap:           h.MarketCap.String(),
+		IsDev:               h.IsDev,
+		Tags:                h.Tags,
+		LastTransactionTime: h.LastTransactionTime,
+	}
+	if h.PositionOpenedAt != nil {
+		pb.PositionOpenedAt = *h.PositionOpenedAt
+	}
+	return pb
+}
+
+func transactionToPB(tx *model.WalletTransaction) *smartmoneypb.Transaction {
+	return &smartmoneypb.Transaction{
+		Id:                       tx.ID,
+		ChainId:                  tx.ChainID,
+		WalletAddress:            tx.WalletAddress,
+		TokenAddress:             tx.TokenAddress,
+		TokenName:                tx.TokenName,
+		TokenIcon:                tx.TokenIcon,
+		TransactionType:          tx.TransactionType,
+		TransactionTime:          tx.TransactionTime,
+		Signature:                tx.Signature,
+		LogIndex:                 int32(tx.LogIndex),
+		Price:                    tx.Price.String(),
+		Amount:                   tx.Amount.String(),
+		Value:                    tx.Value.String(),
+		Marketcap:                tx.MarketCap.String(),
+		RealizedProfit:           tx.RealizedProfit.String(),
+		RealizedProfitPercentage: tx.RealizedProfitPercentage.String(),
+		FromTokenAddress:         tx.FromTokenAddress,
+		FromTokenSymbol:          tx.FromTokenSymbol,
+		FromTokenAmount:          tx.FromTokenAmount.String(),
+		DestTokenAddress:         tx.DestTokenAddress,
+		DestTokenSymbol:          tx.DestTokenSymbol,
+		DestTokenAmount:          tx.DestTokenAmount.String(),
+	}
+}
+
+func tokenSmartMoneyToPB(chainID uint64, tokenAddress string, info *model.TokenSmartMoneyInfo) *smartmoneypb.TokenSmartMoney {
+	return &smartmoneypb.TokenSmartMoney{
+		ChainId:              chainID,
+		TokenAddress:         tokenAddress,
+		HolderCount:          info.HolderCount,
+		HoldingValueUsd:      info.HoldingValueUSD.String(),
+		HoldingAmount:        info.HoldingAmount.String(),
+		HoldingSupplyPercent: info.HoldingSupplyPercent.String(),
+		AvgEntryMarketcap:    info.AvgEntryMarketCap.String(),
+		BuyValue_24H:         info.BuyValue24h.String(),
+		SellValue_24H:        info.SellValue24h.String(),
+		NetFlow_24H:          info.NetFlow24h.String(),
+		FirstBuyTime:         info.FirstBuyTime,
+		LastBuyTime:          info.LastBuyTime,
+		UpdatedAt:            info.UpdatedAt,
+	}
+}
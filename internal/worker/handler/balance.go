@@ -19,12 +19,12 @@ type BalanceHandler struct {
 	balanceService *service.BalanceUpdate
 }
 
-func NewBalanceHandler(cfg config.Config, logger *zap.Logger, repo repository.Repository) *BalanceHandler {
+func NewBalanceHandler(cfg config.Config, logger *zap.Logger, repo repository.Repository, balanceService *service.BalanceUpdate) *BalanceHandler {
 	return &BalanceHandler{
 		cfg:            cfg,
 		repo:           repo,
 		logger:         logger,
-		balanceService: service.NewBalanceUpdate(cfg, logger, repo),
+		balanceService: balanceService,
 	}
 }
 
@@ -43,6 +43,6 @@ func (h *BalanceHandler) HandleBalance(ctx context.Context, blockBalance model.B
 	h.balanceService.UpdateBalance(blockBalance)
 }
 
+// Stop 共用的 BalanceUpdate 由 Core 关闭
 func (h *BalanceHandler) Stop() {
-	h.balanceService.Stop()
 }
@@ -16,20 +16,24 @@ type TradeHandler struct {
 	wpaServ *service.WalletPositonAnalyze
 	wisServ *service.WalletIndicatorStatistics
 	tcsServ *service.TopCardsService
+	ttsServ *service.TokenTradeStatsService
 }
 
-func NewTradeHandler(cfg config.Config, logger *zap.Logger, repo repository.Repository) *TradeHandler {
+// NewTradeHandler 创建 trade 处理器，topCards、tradeStats 与定时任务共用，由调用方负责关闭
+func NewTradeHandler(cfg config.Config, logger *zap.Logger, repo repository.Repository, topCards *service.TopCardsService, tradeStats *service.TokenTradeStatsService) *TradeHandler {
 	return &TradeHandler{
 		tl:      logger,
 		cfg:     cfg,
 		repo:    repo,
 		wpaServ: service.NewWalletPositonAnalyze(cfg, logger, repo),
 		wisServ: service.NewWalletIndicatorStatistics(cfg, logger, repo),
-		tcsServ: service.NewTopCardsService(cfg, logger, repo),
+		tcsServ: topCards,
+		ttsServ: tradeStats,
 	}
 }
 
 func (h *TradeHandler) HandleTrade(trade model.TradeEvent) {
+	h.ttsServ.Record(trade)
 	smartMoney, prevHolding, currentHolding, txType := h.wpaServ.ProcessTrade(trade)
 	if smartMoney != nil {
 		h.wisServ.Statistics(trade, smartMoney, prevHolding, currentHolding, txType)
@@ -40,5 +44,4 @@ func (h *TradeHandler) HandleTrade(trade model.TradeEvent) {
 func (h *TradeHandler) Stop() {
 	h.wpaServ.Close()
 	h.wisServ.Close()
-	h.tcsServ.Close()
 }
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+)
+
+// 断点保留时长，超过后视为过期，下次从头开始
+const checkpointTTL = 24 * time.Hour
+
+// Checkpoint 分页扫描类作业的断点
+//
+// 作业每处理完一页保存一次，被中断（重启、超时、租约丢失）后下次启动从 LastID 继续同一轮；
+// 整轮完成后删除。删除断点即可强制下次从头完整扫描。
+type Checkpoint struct {
+	RunID     string `json:"run_id"`
+	LastID    int64  `json:"last_id"`   // keyset 游标，下一页从 id > LastID 开始
+	Processed int64  `json:"processed"` // 本轮累计处理数
+	Errors    int64  `json:"errors"`    // 本轮累计失败数
+	Pages     int64  `json:"pages"`
+	StartedAt int64  `json:"started_at"` // 本轮开始时间，毫秒时间戳
+	UpdatedAt int64  `json:"updated_at"` // 最近一次保存时间，毫秒时间戳
+}
+
+func jobCheckpointKey(name string) string {
+	return fmt.Sprintf("smart_money:job:checkpoint:%s", name)
+}
+
+// LoadCheckpoint 读取作业断点，不存在返回 (nil, nil)
+func LoadCheckpoint(ctx context.Context, rdb *redis.Client, name string) (*Checkpoint, error) {
+	data, err := rdb.Get(ctx, jobCheckpointKey(name)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cp Checkpoint
+	if err := sonic.Unmarshal(data, &cp); err != nil {
+		return nil, err
+	}
+	return &cp, nil
+}
+
+// SaveCheckpoint 保存作业断点
+func SaveCheckpoint(ctx context.Context, rdb *redis.Client, name string, cp *Checkpoint) error {
+	cp.UpdatedAt = time.Now().UnixMilli()
+	data, err := sonic.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return rdb.Set(ctx, jobCheckpointKey(name), data, checkpointTTL).Err()
+}
+
+// ClearCheckpoint 删除作业断点，下次执行从头开始；执行中的作业在下一页发现断点被删后也会从头开始
+func ClearCheckpoint(ctx context.Context, rdb *redis.Client, name string) error {
+	return rdb.Del(ctx, jobCheckpointKey(name)).Err()
+}
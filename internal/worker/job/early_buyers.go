@@ -0,0 +1,45 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"go.uber.org/zap"
+)
+
+// EarlyBuyersRefresh 定时刷新最新交易对榜单中 token 的早期买家缓存
+type EarlyBuyersRefresh struct {
+	cfg         config.Config
+	repo        repository.Repository
+	tl          *zap.Logger
+	earlyBuyers *service.EarlyBuyersService
+}
+
+// NewEarlyBuyersRefresh 创建早期买家刷新任务
+func NewEarlyBuyersRefresh(cfg config.Config, repo repository.Repository, logger *zap.Logger) *EarlyBuyersRefresh {
+	return &EarlyBuyersRefresh{
+		cfg:         cfg,
+		repo:        repo,
+		tl:          logger,
+		earlyBuyers: service.NewEarlyBuyersService(cfg, logger, repo),
+	}
+}
+
+// Run 执行早期买家刷新
+func (j *EarlyBuyersRefresh) Run(ctx context.Context) error {
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("early buyers refresh lease check failed", zap.Error(err))
+		return err
+	}
+	startTime := time.Now()
+	if err := j.earlyBuyers.RefreshLeaderboardTokens(ctx); err != nil {
+		j.tl.Warn("refresh early buyers failed", zap.Error(err))
+		return err
+	}
+	j.tl.Info("early buyers refreshed", zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
@@ -74,7 +74,11 @@ func (j *HandleMissingTokenInfo) Run(ctx context.Context) error {
 		return nil
 	}
 
-	// 4. 更新数据库
+	// 4. 更新数据库，写入前确认租约仍有效（未处理的成员留在 Redis 由接管者处理）
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("handle missing token info lease check failed", zap.Error(err))
+		return err
+	}
 	holdingUpdatedCount, transactionUpdatedCount := j.updateDatabase(ctx, walletTokenList, tokenInfoMap)
 
 	// 5. 更新token info缓存
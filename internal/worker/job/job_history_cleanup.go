@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+
+	"go.uber.org/zap"
+)
+
+// 作业执行记录保留时长
+const jobHistoryRetention = 14 * 24 * time.Hour
+
+// JobHistoryCleanup 清理过期的作业执行记录
+type JobHistoryCleanup struct {
+	cfg  config.Config
+	repo repository.Repository
+	tl   *zap.Logger
+}
+
+// NewJobHistoryCleanup 创建作业执行记录清理任务
+func NewJobHistoryCleanup(cfg config.Config, repo repository.Repository, logger *zap.Logger) *JobHistoryCleanup {
+	return &JobHistoryCleanup{
+		cfg:  cfg,
+		repo: repo,
+		tl:   logger,
+	}
+}
+
+// Run 删除保留期之前的记录
+func (j *JobHistoryCleanup) Run(ctx context.Context) error {
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("job history cleanup lease check failed", zap.Error(err))
+		return err
+	}
+	before := time.Now().Add(-jobHistoryRetention).UnixMilli()
+	deleted, err := j.repo.GetDAOManager().JobHistoryDAO.DeleteBefore(ctx, before)
+	if err != nil {
+		j.tl.Error("delete expired job history failed", zap.Error(err))
+		return err
+	}
+	j.tl.Info("job history cleanup completed", zap.Int64("deleted", deleted))
+	return nil
+}
@@ -0,0 +1,222 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// 默认租约时长，实例崩溃后最多经过该时长由其他实例接管
+const defaultLeaseTTL = 30 * time.Second
+
+// ErrLeaseLost 租约已过期或被其他实例持有
+var ErrLeaseLost = errors.New("job lease lost")
+
+// ErrFenced 写入被 fencing 条件拒绝：该行已被持有更新租约的实例写过
+var ErrFenced = errors.New("write rejected by fencing token")
+
+// 获取租约：锁不存在时自增 fencing token 并写入 "<owner>:<token>"，返回 token；已被持有返回 0
+var acquireScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+local token = redis.call('INCR', KEYS[2])
+redis.call('SET', KEYS[1], ARGV[1] .. ':' .. token, 'PX', ARGV[2])
+return token
+`)
+
+// 续约：仅当锁仍归自己持有时延长过期时间
+var renewScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// 释放：仅删除自己持有的锁
+var releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+func jobLockKey(name string) string {
+	return fmt.Sprintf("smart_money:job:lock:%s", name)
+}
+
+func jobFenceKey(name string) string {
+	return fmt.Sprintf("smart_money:job:fence:%s", name)
+}
+
+// Locker 作业租约锁，调度器通过它获取 singleton 作业的租约；LeaseLocker 为 Redis 实现
+type Locker interface {
+	// Acquire 尝试获取租约，已被其他实例持有时返回 (nil, nil)
+	Acquire(ctx context.Context, name string) (*Lease, error)
+	// TTL 租约时长，调度器按 TTL/3 续约
+	TTL() time.Duration
+}
+
+// leaseBackend 租约的续约、校验与释放，由签发租约的 Locker 实现
+type leaseBackend interface {
+	renew(ctx context.Context, l *Lease) error
+	check(ctx context.Context, l *Lease) error
+	release(ctx context.Context, l *Lease) error
+}
+
+// LeaseLocker 基于 Redis 的作业租约锁
+//
+// 锁带过期时间，持有期间由调度器定期续约；进程崩溃后不再续约，租约到期自动释放。
+// 每次获取成功分配单调递增的 fencing token（同一锁名共用一个序列）。作业在关键写入前用 CheckLease 确认仍是持有者；
+// 由于 GC 停顿或网络分区，确认之后租约仍可能过期，因此写 PG 时再用 fencedUpdates 带上 token：
+// 行上记录最近一次写入的 token，只有不小于它的写入才生效，过期持有者不会覆盖接管者已写入的结果。
+// ES 不支持条件写，只在对应 PG 写入成功后写入。
+type LeaseLocker struct {
+	rdb   *redis.Client
+	owner string // 实例标识：hostname-pid-启动时间，避免同主机重启后误认旧锁
+	ttl   time.Duration
+}
+
+// NewLeaseLocker 创建租约锁，ttl <= 0 时使用默认值
+func NewLeaseLocker(rdb *redis.Client, ttl time.Duration) *LeaseLocker {
+	if ttl <= 0 {
+		ttl = defaultLeaseTTL
+	}
+	host, _ := os.Hostname()
+	return &LeaseLocker{
+		rdb:   rdb,
+		ttl:   ttl,
+		owner: fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano()),
+	}
+}
+
+// TTL 租约时长
+func (l *LeaseLocker) TTL() time.Duration {
+	return l.ttl
+}
+
+// Acquire 尝试获取租约，name 为锁名（默认即作业名），已被其他实例持有时返回 (nil, nil)
+func (l *LeaseLocker) Acquire(ctx context.Context, name string) (*Lease, error) {
+	token, err := acquireScript.Run(ctx, l.rdb,
+		[]string{jobLockKey(name), jobFenceKey(name)},
+		l.owner, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return nil, err
+	}
+	if token == 0 {
+		return nil, nil
+	}
+	return &Lease{
+		locker: l,
+		name:   name,
+		token:  token,
+		value:  l.owner + ":" + strconv.FormatInt(token, 10),
+	}, nil
+}
+
+// Lease 已获取的作业租约
+type Lease struct {
+	locker leaseBackend
+	name   string
+	token  int64
+	value  string
+}
+
+// Token fencing token，同一锁名每次获取单调递增
+func (l *Lease) Token() int64 {
+	return l.token
+}
+
+// Renew 续约，租约已丢失返回 ErrLeaseLost
+func (l *Lease) Renew(ctx context.Context) error {
+	return l.locker.renew(ctx, l)
+}
+
+// Check 确认租约仍由自己持有，作业在关键写入前调用
+func (l *Lease) Check(ctx context.Context) error {
+	return l.locker.check(ctx, l)
+}
+
+// Release 释放租约，只删除自己持有的锁
+func (l *Lease) Release(ctx context.Context) error {
+	return l.locker.release(ctx, l)
+}
+
+func (l *LeaseLocker) renew(ctx context.Context, lease *Lease) error {
+	ok, err := renewScript.Run(ctx, l.rdb, []string{jobLockKey(lease.name)},
+		lease.value, l.ttl.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if ok == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *LeaseLocker) check(ctx context.Context, lease *Lease) error {
+	val, err := l.rdb.Get(ctx, jobLockKey(lease.name)).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrLeaseLost
+	}
+	if err != nil {
+		return err
+	}
+	if val != lease.value {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *LeaseLocker) release(ctx context.Context, lease *Lease) error {
+	return releaseScript.Run(ctx, l.rdb, []string{jobLockKey(lease.name)}, lease.value).Err()
+}
+
+type leaseCtxKey struct{}
+
+// LeaseFromContext 获取当前作业持有的租约，per-instance 作业或未启用锁时返回 nil
+func LeaseFromContext(ctx context.Context) *Lease {
+	lease, _ := ctx.Value(leaseCtxKey{}).(*Lease)
+	return lease
+}
+
+// LeaseToken 当前作业持有租约的 fencing token，未持有租约时返回 0
+func LeaseToken(ctx context.Context) int64 {
+	if lease := LeaseFromContext(ctx); lease != nil {
+		return lease.Token()
+	}
+	return 0
+}
+
+// fencedUpdates 带 fencing 条件更新：仅当行上的 column 不大于当前 token 时写入，并把 token 记入 column。
+// 持有租约且没有行被更新时返回 ErrFenced（行不存在也视为被拒绝）；未持有租约时为普通更新。
+func fencedUpdates(ctx context.Context, q *gorm.DB, column string, updates map[string]interface{}) error {
+	token := LeaseToken(ctx)
+	if token == 0 {
+		return q.Updates(updates).Error
+	}
+	updates[column] = token
+	res := q.Where(column+" <= ?", token).Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrFenced
+	}
+	return nil
+}
+
+// CheckLease 作业在关键写入前确认租约仍有效；未持有租约（per-instance 或未启用锁）时直接通过
+func CheckLease(ctx context.Context) error {
+	lease := LeaseFromContext(ctx)
+	if lease == nil {
+		return nil
+	}
+	return lease.Check(ctx)
+}
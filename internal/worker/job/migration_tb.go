@@ -119,16 +119,16 @@ func (m *MigrationTable) initWriters() {
 		m.tl, &multiWriter[model.WalletSummary]{
 			writers: []writer.BatchWriter[model.WalletSummary]{m.walletDBWriter, m.walletESWriter},
 			// writers: []writer.BatchWriter[model.WalletSummary]{m.walletDBWriter},
-		}, BatchSize, time.Millisecond*300, "wallet_migration", 2)
+		}, "wallet_migration")
 
 	m.holdingAsyncWriter = writer.NewAsyncBatchWriter[model.WalletHolding](
 		m.tl, &multiWriter[model.WalletHolding]{
 			// writers: []writer.BatchWriter[model.WalletHolding]{m.holdingDBWriter, m.holdingESWriter},
 			writers: []writer.BatchWriter[model.WalletHolding]{m.holdingDBWriter},
-		}, BatchSize, time.Millisecond*300, "holding_migration", 2)
+		}, "holding_migration")
 
 	m.transactionAsyncWriter = writer.NewAsyncBatchWriter[model.WalletTransaction](
-		m.tl, m.transactionDBWriter, BatchSize, time.Millisecond*300, "transaction_migration", 2)
+		m.tl, m.transactionDBWriter, "transaction_migration")
 }
 
 // multiWriter 用于同时写入DB和ES
@@ -0,0 +1,77 @@
+package job
+
+import (
+	"fmt"
+	"time"
+)
+
+// OverlapPolicy 上一轮未结束时新一轮触发的处理方式
+type OverlapPolicy int
+
+const (
+	OverlapSkip  OverlapPolicy = iota // 跳过本轮（默认）
+	OverlapQueue                      // 排队到上一轮结束后立即执行，多次触发只保留一次
+)
+
+func (p OverlapPolicy) String() string {
+	if p == OverlapQueue {
+		return "queue"
+	}
+	return "skip"
+}
+
+// 重试退避上限
+const maxRetryBackoff = 5 * time.Minute
+
+// JobOption 作业注册选项
+type JobOption func(*ScheduledJob)
+
+// MustCron 按 cron 表达式（分 时 日 月 周，UTC）调度，注册时传入的 interval 被忽略。
+// 只用于代码中写死的表达式，解析失败 panic；来自配置等外部输入的表达式先用 ValidateCron 校验。
+func MustCron(expr string) JobOption {
+	sched, err := parseCron(expr)
+	if err != nil {
+		panic(fmt.Sprintf("job: %v", err))
+	}
+	return func(j *ScheduledJob) {
+		j.cron = sched
+	}
+}
+
+// WithLockName singleton 作业使用指定的租约锁名（默认为作业名）。
+// 多个作业使用同一锁名时跨实例互斥，其中一个持有租约期间其余作业本轮跳过。
+func WithLockName(name string) JobOption {
+	return func(j *ScheduledJob) {
+		j.lockName = name
+	}
+}
+
+// WithJitter 每次定时触发前额外随机延迟 [0, jitter)，错开多个作业或多个实例同时启动
+func WithJitter(jitter time.Duration) JobOption {
+	return func(j *ScheduledJob) {
+		j.jitter = jitter
+	}
+}
+
+// WithTimeout 单轮最大执行时长（含重试），超时取消作业 ctx。
+// 不设置时周期作业默认取调度间隔的一半，一次性作业不限时。
+func WithTimeout(timeout time.Duration) JobOption {
+	return func(j *ScheduledJob) {
+		j.timeout = timeout
+	}
+}
+
+// WithOverlap 设置重叠策略，手动触发始终排队，不受该策略影响
+func WithOverlap(policy OverlapPolicy) JobOption {
+	return func(j *ScheduledJob) {
+		j.overlap = policy
+	}
+}
+
+// WithRetry 出错后最多重试 maxRetries 次，退避从 backoff 开始每次翻倍，上限 5 分钟
+func WithRetry(maxRetries int, backoff time.Duration) JobOption {
+	return func(j *ScheduledJob) {
+		j.maxRetries = maxRetries
+		j.retryBackoff = backoff
+	}
+}
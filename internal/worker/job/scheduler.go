@@ -2,8 +2,15 @@ package job
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sort"
 	"sync"
 	"time"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/monitor"
 
 	"go.uber.org/zap"
 )
@@ -11,63 +18,187 @@ import (
 // JobFunc 定义作业执行函数
 type JobFunc func(ctx context.Context) error
 
+// 最小调度间隔，防止运行时把间隔改得过小
+const minJobInterval = time.Second
+
+// JobMode 作业在多副本部署下的执行方式
+type JobMode int
+
+const (
+	JobPerInstance JobMode = iota // 每个实例各自执行（如加载本地缓存）
+	JobSingleton                  // 所有实例中同一时刻只有一个执行，需先获取 Redis 租约
+)
+
+func (m JobMode) String() string {
+	if m == JobSingleton {
+		return "singleton"
+	}
+	return "per_instance"
+}
+
+var (
+	ErrJobNotFound         = errors.New("job not found")
+	ErrOnceJob             = errors.New("once job does not support this operation")
+	ErrCronJob             = errors.New("cron job does not support interval change")
+	ErrInvalidInterval     = errors.New("interval must be at least 1s")
+	ErrSchedulerNotRunning = errors.New("scheduler not running")
+)
+
+// HistoryRecorder 作业执行记录持久化
+type HistoryRecorder interface {
+	CreateJobHistory(ctx context.Context, history *model.JobHistory) error
+}
+
 // Scheduler 作业调度器
 type Scheduler struct {
-	jobs    map[string]*ScheduledJob
-	running bool
-	mu      sync.Mutex
-	logger  *zap.Logger
+	jobs     map[string]*ScheduledJob
+	running  bool
+	mu       sync.Mutex
+	logger   *zap.Logger
+	locker   Locker          // 为 nil 时 singleton 作业也直接执行（单实例部署）
+	history  HistoryRecorder // 为 nil 时不记录执行历史
+	clock    clock
+	instance string
+}
+
+// clock 调度器取时间与等待重试退避所用的时钟，测试中替换
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
 }
 
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
 // ScheduledJob 表示一个调度的作业
 type ScheduledJob struct {
 	name     string
 	interval time.Duration
 	fn       JobFunc
 	stopCh   chan struct{}
-	done     sync.WaitGroup
+	done     sync.WaitGroup // 调度循环及执行中的作业
 	cancel   context.CancelFunc
 	once     bool
+	mode     JobMode
+
+	// 注册选项，注册后不再变更
+	cron         *cronSchedule
+	jitter       time.Duration
+	timeout      time.Duration
+	overlap      OverlapPolicy
+	maxRetries   int
+	retryBackoff time.Duration
+	lockName     string // singleton 租约锁名，空表示使用作业名
+
+	triggerCh  chan struct{} // 手动触发，缓冲 1，重复触发合并
+	intervalCh chan struct{} // 间隔变更通知，缓冲 1
+
+	// 运行状态，由 mu 保护
+	mu        sync.Mutex
+	busy      bool   // 已派发执行（含等待租约、重试退避）
+	pending   string // 排队等待执行的触发来源，空表示无
+	running   bool
+	paused    bool
+	lastStart time.Time
+	lastEnd   time.Time
+	lastErr   string
+	nextRun   time.Time
+	runCount  int64
+	failCount int64
+	skipCount int64 // 租约被其他实例持有而跳过的次数
+	lastToken int64 // 最近一次获取租约的 fencing token
+}
+
+// JobState 作业运行状态快照
+type JobState struct {
+	Name         string     `json:"name"`
+	Interval     string     `json:"interval,omitempty"`
+	Cron         string     `json:"cron,omitempty"`
+	Once         bool       `json:"once"`
+	Mode         string     `json:"mode"`
+	Overlap      string     `json:"overlap,omitempty"`
+	Timeout      string     `json:"timeout,omitempty"`
+	MaxRetries   int        `json:"max_retries,omitempty"`
+	LockName     string     `json:"lock_name,omitempty"` // 与其他作业共用的租约锁名
+	Running      bool       `json:"running"`
+	Pending      bool       `json:"pending"`
+	Paused       bool       `json:"paused"`
+	LastStart    *time.Time `json:"last_start,omitempty"`
+	LastEnd      *time.Time `json:"last_end,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	NextRun      *time.Time `json:"next_run,omitempty"`
+	RunCount     int64      `json:"run_count"`
+	FailCount    int64      `json:"fail_count"`
+	SkipCount    int64      `json:"skip_count"`
+	LeaseToken   int64      `json:"lease_token,omitempty"`
 }
 
-// NewScheduler 创建调度器
-func NewScheduler(logger *zap.Logger) *Scheduler {
+// NewScheduler 创建调度器，locker 为 nil 时不做跨实例互斥，history 为 nil 时不记录执行历史
+func NewScheduler(logger *zap.Logger, locker Locker, history HistoryRecorder) *Scheduler {
+	host, _ := os.Hostname()
 	return &Scheduler{
-		jobs:   make(map[string]*ScheduledJob),
-		logger: logger,
+		jobs:     make(map[string]*ScheduledJob),
+		logger:   logger,
+		locker:   locker,
+		history:  history,
+		clock:    realClock{},
+		instance: fmt.Sprintf("%s-%d", host, os.Getpid()),
 	}
 }
 
 // RegisterJob 注册作业
-func (s *Scheduler) RegisterJob(name string, interval time.Duration, fn JobFunc) {
+func (s *Scheduler) RegisterJob(name string, interval time.Duration, mode JobMode, fn JobFunc, opts ...JobOption) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.jobs[name] = &ScheduledJob{
+	job := &ScheduledJob{
 		name:     name,
 		interval: interval,
 		fn:       fn,
 		stopCh:   make(chan struct{}),
 		once:     false,
+		mode:     mode,
+
+		triggerCh:  make(chan struct{}, 1),
+		intervalCh: make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(job)
 	}
+	s.jobs[name] = job
 
-	s.logger.Info("Registered job", zap.String("job", name), zap.Duration("interval", interval))
+	if job.cron != nil {
+		s.logger.Info("Registered job", zap.String("job", name), zap.Stringer("cron", job.cron), zap.Stringer("mode", mode))
+	} else {
+		s.logger.Info("Registered job", zap.String("job", name), zap.Duration("interval", interval), zap.Stringer("mode", mode))
+	}
 }
 
-// RegisterOnceJob RegisterJob 注册只运行一次的作业
-func (s *Scheduler) RegisterOnceJob(name string, fn JobFunc) {
+// RegisterOnceJob RegisterJob 注册只运行一次的作业，仅 WithTimeout / WithRetry 选项生效
+func (s *Scheduler) RegisterOnceJob(name string, mode JobMode, fn JobFunc, opts ...JobOption) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	s.jobs[name] = &ScheduledJob{
+	job := &ScheduledJob{
 		name:     name,
 		interval: 0,
 		fn:       fn,
 		stopCh:   make(chan struct{}),
 		once:     true,
+		mode:     mode,
+
+		triggerCh:  make(chan struct{}, 1),
+		intervalCh: make(chan struct{}, 1),
 	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	s.jobs[name] = job
 
-	s.logger.Info("Registered once job", zap.String("job", name))
+	s.logger.Info("Registered once job", zap.String("job", name), zap.Stringer("mode", mode))
 }
 
 // Start 启动调度器
@@ -108,9 +239,7 @@ func (s *Scheduler) Stop(ctx context.Context) {
 
 	// 关闭所有作业的停止通道
 	for _, job := range s.jobs {
-		if job.cancel != nil {
-			job.cancel() // 调用 cancel 来提前终止任务
-		}
+		job.cancelRun() // 调用 cancel 来提前终止任务
 		close(job.stopCh)
 	}
 	s.mu.Unlock()
@@ -158,23 +287,45 @@ func (s *Scheduler) Stop(ctx context.Context) {
 // runOnceJob 运行单次任务
 func (s *Scheduler) runOnceJob(ctx context.Context, job *ScheduledJob) {
 	s.logger.Info("Running one-time job", zap.String("job", job.name))
-	s.executeJob(ctx, job)
+	s.executeJob(ctx, job, model.JOB_TRIGGER_STARTUP)
 }
 
-// runJob 运行单个作业
+// runJob 运行单个作业的调度循环
+//
+// 调度循环只负责按时派发，作业在独立 goroutine 中执行，执行耗时不影响下一次触发时间；
+// 上一轮未结束时按重叠策略跳过或排队。
 func (s *Scheduler) runJob(ctx context.Context, job *ScheduledJob) {
 	s.logger.Info("Running job", zap.String("job", job.name), zap.Bool("once", job.once))
 
-	ticker := time.NewTicker(job.interval)
-	defer ticker.Stop()
-
-	// 立即运行一次
-	s.executeJob(ctx, job)
+	// 间隔作业启动后立即执行一次（叠加 jitter），cron 作业等待下一个时间点
+	var delay time.Duration
+	if job.cron != nil {
+		delay = job.nextDelay(s.clock.Now())
+	} else {
+		delay = job.jitterDelay()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	job.setNextRun(s.clock.Now().Add(delay))
 
 	for {
 		select {
-		case <-ticker.C:
-			s.executeJob(ctx, job)
+		case <-timer.C:
+			if !job.isPaused() {
+				s.dispatch(ctx, job, model.JOB_TRIGGER_SCHEDULE)
+			}
+			delay = job.nextDelay(s.clock.Now())
+			timer.Reset(delay)
+			job.setNextRun(s.clock.Now().Add(delay))
+		case <-job.triggerCh:
+			// 手动触发不受暂停影响，也不重置定时周期
+			s.logger.Info("Job triggered manually", zap.String("job", job.name))
+			s.dispatch(ctx, job, model.JOB_TRIGGER_MANUAL)
+		case <-job.intervalCh:
+			delay = job.nextDelay(s.clock.Now())
+			timer.Reset(delay)
+			job.setNextRun(s.clock.Now().Add(delay))
+			s.logger.Info("Job interval changed", zap.String("job", job.name), zap.Duration("interval", job.getInterval()))
 		case <-job.stopCh:
 			s.logger.Info("Stopping job", zap.String("job", job.name))
 			return
@@ -185,27 +336,526 @@ func (s *Scheduler) runJob(ctx context.Context, job *ScheduledJob) {
 	}
 }
 
+// dispatch 派发一次执行；作业仍在执行时，手动触发及 OverlapQueue 作业排队，其余跳过
+func (s *Scheduler) dispatch(ctx context.Context, job *ScheduledJob, trigger string) {
+	queue := trigger == model.JOB_TRIGGER_MANUAL || job.overlap == OverlapQueue
+	started, queued := job.begin(trigger, queue)
+	if !started {
+		if queued {
+			s.logger.Debug("Job still running, queued", zap.String("job", job.name), zap.String("trigger", trigger))
+		} else {
+			s.logger.Warn("Job still running, skip", zap.String("job", job.name))
+			monitor.JobSkippedTotal.WithLabelValues(job.name, "overlap").Inc()
+		}
+		return
+	}
+
+	job.done.Add(1)
+	go func() {
+		defer job.done.Done()
+		for next := trigger; next != ""; next = job.finish() {
+			if job.stopping(ctx) {
+				job.clearBusy()
+				return
+			}
+			s.executeJob(ctx, job, next)
+		}
+	}()
+}
+
 // executeJob 执行作业并处理错误
-func (s *Scheduler) executeJob(ctx context.Context, job *ScheduledJob) {
-	jobCtx, cancel := context.WithCancel(ctx)
-	if !job.once {
-		// 周期任务增加超时时间
-		jobCtx, cancel = context.WithTimeout(ctx, job.interval/2)
+func (s *Scheduler) executeJob(ctx context.Context, job *ScheduledJob, trigger string) {
+	var (
+		jobCtx context.Context
+		cancel context.CancelFunc
+	)
+	if timeout := job.runTimeout(); timeout > 0 {
+		jobCtx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		jobCtx, cancel = context.WithCancel(ctx)
 	}
-	job.cancel = cancel
+	job.setCancel(cancel)
 	defer cancel()
 
-	s.logger.Debug("Starting job execution", zap.String("job", job.name))
-	startTime := time.Now()
+	var token int64
+	if job.mode == JobSingleton && s.locker != nil {
+		lease, err := s.locker.Acquire(jobCtx, job.leaseName())
+		if err != nil {
+			s.logger.Error("Acquire job lease failed", zap.String("job", job.name), zap.Error(err))
+			now := s.clock.Now()
+			job.markStart(now)
+			job.markEnd(now, fmt.Errorf("acquire lease: %w", err))
+			monitor.JobRunsTotal.WithLabelValues(job.name, model.JOB_RUN_STATUS_FAILED).Inc()
+			return
+		}
+		if lease == nil {
+			s.logger.Debug("Job lease held by another run, skip", zap.String("job", job.name), zap.String("lock", job.leaseName()))
+			job.markSkipped()
+			monitor.JobSkippedTotal.WithLabelValues(job.name, "lease").Inc()
+			return
+		}
+		token = lease.Token()
+		job.setToken(token)
+		jobCtx = context.WithValue(jobCtx, leaseCtxKey{}, lease)
 
-	if err := job.fn(jobCtx); err != nil {
+		stopRenew := make(chan struct{})
+		renewDone := make(chan struct{})
+		go func() {
+			defer close(renewDone)
+			s.keepLease(jobCtx, job.name, lease, cancel, stopRenew)
+		}()
+		defer func() {
+			close(stopRenew)
+			<-renewDone
+			// 作业 ctx 可能已取消，释放使用独立的短超时
+			releaseCtx, releaseCancel := context.WithTimeout(context.Background(), 3*time.Second)
+			defer releaseCancel()
+			if err := lease.Release(releaseCtx); err != nil {
+				s.logger.Warn("Release job lease failed", zap.String("job", job.name), zap.Error(err))
+			}
+		}()
+	}
+
+	s.logger.Debug("Starting job execution", zap.String("job", job.name), zap.String("trigger", trigger))
+	startTime := s.clock.Now()
+	job.markStart(startTime)
+	monitor.JobRunning.WithLabelValues(job.name).Set(1)
+
+	attempts, err := s.runWithRetry(jobCtx, job)
+
+	endTime := s.clock.Now()
+	job.markEnd(endTime, err)
+	monitor.JobRunning.WithLabelValues(job.name).Set(0)
+	monitor.JobRunDuration.WithLabelValues(job.name).Observe(endTime.Sub(startTime).Seconds())
+
+	status := model.JOB_RUN_STATUS_SUCCESS
+	switch {
+	case err == nil:
+		monitor.JobLastSuccess.WithLabelValues(job.name).Set(float64(endTime.Unix()))
+	case errors.Is(jobCtx.Err(), context.DeadlineExceeded):
+		status = model.JOB_RUN_STATUS_TIMEOUT
+	default:
+		status = model.JOB_RUN_STATUS_FAILED
+	}
+	monitor.JobRunsTotal.WithLabelValues(job.name, status).Inc()
+
+	if err != nil {
 		s.logger.Error("Job execution failed",
 			zap.String("job", job.name),
+			zap.String("status", status),
+			zap.Int("attempts", attempts),
 			zap.Error(err),
-			zap.Duration("duration", time.Since(startTime)))
+			zap.Duration("duration", endTime.Sub(startTime)))
 	} else {
 		s.logger.Debug("Job execution completed",
 			zap.String("job", job.name),
-			zap.Duration("duration", time.Since(startTime)))
+			zap.Int("attempts", attempts),
+			zap.Duration("duration", endTime.Sub(startTime)))
+	}
+
+	history := &model.JobHistory{
+		JobName:    job.name,
+		Instance:   s.instance,
+		Trigger:    trigger,
+		Status:     status,
+		Attempts:   attempts,
+		LeaseToken: token,
+		StartedAt:  startTime.UnixMilli(),
+		FinishedAt: endTime.UnixMilli(),
+		DurationMs: endTime.Sub(startTime).Milliseconds(),
+	}
+	if err != nil {
+		history.Error = err.Error()
+	}
+	s.recordHistory(history)
+}
+
+// runWithRetry 执行作业，出错时按退避重试；超时、取消或租约丢失不再重试
+func (s *Scheduler) runWithRetry(ctx context.Context, job *ScheduledJob) (int, error) {
+	backoff := job.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := job.fn(ctx)
+		if err == nil || attempt > job.maxRetries || ctx.Err() != nil || errors.Is(err, ErrLeaseLost) {
+			return attempt, err
+		}
+
+		s.logger.Warn("Job attempt failed, retrying",
+			zap.String("job", job.name),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		monitor.JobRetriesTotal.WithLabelValues(job.name).Inc()
+
+		select {
+		case <-s.clock.After(backoff):
+		case <-ctx.Done():
+			return attempt, err
+		}
+		backoff = min(backoff*2, maxRetryBackoff)
+	}
+}
+
+// recordHistory 写入执行记录，失败只记日志
+func (s *Scheduler) recordHistory(history *model.JobHistory) {
+	if s.history == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := s.history.CreateJobHistory(ctx, history); err != nil {
+		s.logger.Warn("Record job history failed", zap.String("job", history.JobName), zap.Error(err))
+	}
+}
+
+// keepLease 按 TTL/3 续约，续约确认租约丢失时取消作业，避免与新持有者并发写入
+func (s *Scheduler) keepLease(ctx context.Context, name string, lease *Lease, cancel context.CancelFunc, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.locker.TTL() / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := lease.Renew(ctx)
+			if errors.Is(err, ErrLeaseLost) {
+				s.logger.Error("Job lease lost, cancelling job", zap.String("job", name), zap.Int64("token", lease.Token()))
+				cancel()
+				return
+			}
+			if err != nil {
+				// Redis 短暂不可用时继续重试，租约到期前恢复即可
+				s.logger.Warn("Renew job lease failed", zap.String("job", name), zap.Error(err))
+			}
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Jobs 返回所有作业的状态快照，按作业名排序
+func (s *Scheduler) Jobs() []JobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	states := make([]JobState, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		states = append(states, job.state())
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+// Job 返回单个作业的状态快照
+func (s *Scheduler) Job(name string) (JobState, error) {
+	job, err := s.getJob(name)
+	if err != nil {
+		return JobState{}, err
+	}
+	return job.state(), nil
+}
+
+// Trigger 立即执行一次作业；作业正在执行时排队到本次结束后执行，重复触发只保留一次
+func (s *Scheduler) Trigger(name string) error {
+	job, err := s.getPeriodicJob(name)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	running := s.running
+	s.mu.Unlock()
+	if !running {
+		return ErrSchedulerNotRunning
+	}
+
+	select {
+	case job.triggerCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Pause 暂停作业的定时执行，正在执行的本轮不受影响
+func (s *Scheduler) Pause(name string) error {
+	job, err := s.getPeriodicJob(name)
+	if err != nil {
+		return err
+	}
+	job.setPaused(true)
+	s.logger.Info("Job paused", zap.String("job", name))
+	return nil
+}
+
+// Resume 恢复作业的定时执行
+func (s *Scheduler) Resume(name string) error {
+	job, err := s.getPeriodicJob(name)
+	if err != nil {
+		return err
+	}
+	job.setPaused(false)
+	s.logger.Info("Job resumed", zap.String("job", name))
+	return nil
+}
+
+// SetInterval 运行时修改作业间隔，从修改时刻起按新间隔重新计时；重启后恢复为注册时的间隔
+func (s *Scheduler) SetInterval(name string, interval time.Duration) error {
+	if interval < minJobInterval {
+		return ErrInvalidInterval
+	}
+	job, err := s.getPeriodicJob(name)
+	if err != nil {
+		return err
+	}
+	if job.cron != nil {
+		return ErrCronJob
+	}
+
+	job.mu.Lock()
+	job.interval = interval
+	job.mu.Unlock()
+
+	select {
+	case job.intervalCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *Scheduler) getJob(name string) (*ScheduledJob, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *Scheduler) getPeriodicJob(name string) (*ScheduledJob, error) {
+	job, err := s.getJob(name)
+	if err != nil {
+		return nil, err
+	}
+	if job.once {
+		return nil, ErrOnceJob
+	}
+	return job, nil
+}
+
+func (j *ScheduledJob) getInterval() time.Duration {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.interval
+}
+
+// jitterDelay 随机延迟 [0, jitter)
+func (j *ScheduledJob) jitterDelay() time.Duration {
+	if j.jitter <= 0 {
+		return 0
+	}
+	return rand.N(j.jitter)
+}
+
+// nextDelay 距下一次定时触发的时长
+func (j *ScheduledJob) nextDelay(now time.Time) time.Duration {
+	delay := j.getInterval()
+	if j.cron != nil {
+		next := j.cron.Next(now)
+		if next.IsZero() {
+			// 表达式 5 年内无匹配（如 2 月 30 日），每天重新计算一次
+			next = now.Add(24 * time.Hour)
+		}
+		delay = next.Sub(now)
+	}
+	return delay + j.jitterDelay()
+}
+
+// runTimeout 单轮最大执行时长，0 表示不限
+func (j *ScheduledJob) runTimeout() time.Duration {
+	if j.timeout > 0 {
+		return j.timeout
+	}
+	if j.once {
+		return 0
+	}
+	if j.cron != nil {
+		// 取相邻两次触发间隔的一半
+		next := j.cron.Next(time.Now())
+		if next.IsZero() {
+			return 0
+		}
+		return j.cron.Next(next).Sub(next) / 2
+	}
+	return j.getInterval() / 2
+}
+
+func (j *ScheduledJob) isPaused() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.paused
+}
+
+func (j *ScheduledJob) setPaused(paused bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.paused = paused
+}
+
+func (j *ScheduledJob) setNextRun(t time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.nextRun = t
+}
+
+func (j *ScheduledJob) setCancel(cancel context.CancelFunc) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.cancel = cancel
+}
+
+func (j *ScheduledJob) cancelRun() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+// begin 标记作业开始执行；已在执行时 queue 为 true 则记录排队
+func (j *ScheduledJob) begin(trigger string, queue bool) (started, queued bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.busy {
+		j.busy = true
+		return true, false
+	}
+	if queue {
+		j.pending = trigger
+		return false, true
+	}
+	return false, false
+}
+
+// finish 本轮结束，有排队的触发时返回其来源并保持 busy，否则清除 busy
+func (j *ScheduledJob) finish() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if next := j.pending; next != "" {
+		j.pending = ""
+		return next
+	}
+	j.busy = false
+	return ""
+}
+
+func (j *ScheduledJob) clearBusy() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.busy = false
+	j.pending = ""
+}
+
+func (j *ScheduledJob) stopping(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	select {
+	case <-j.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (j *ScheduledJob) markStart(t time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = true
+	j.lastStart = t
+}
+
+func (j *ScheduledJob) markEnd(t time.Time, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.running = false
+	j.lastEnd = t
+	j.runCount++
+	if err != nil {
+		j.failCount++
+		j.lastErr = err.Error()
+	} else {
+		j.lastErr = ""
+	}
+}
+
+func (j *ScheduledJob) markSkipped() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.skipCount++
+}
+
+// leaseName singleton 租约锁名
+func (j *ScheduledJob) leaseName() string {
+	if j.lockName != "" {
+		return j.lockName
+	}
+	return j.name
+}
+
+func (j *ScheduledJob) setToken(token int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.lastToken = token
+}
+
+func (j *ScheduledJob) state() JobState {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	st := JobState{
+		Name:       j.name,
+		Once:       j.once,
+		Mode:       j.mode.String(),
+		MaxRetries: j.maxRetries,
+		Running:    j.running,
+		Pending:    j.pending != "",
+		Paused:     j.paused,
+		LastError:  j.lastErr,
+		RunCount:   j.runCount,
+		FailCount:  j.failCount,
+		SkipCount:  j.skipCount,
+		LeaseToken: j.lastToken,
+	}
+	if !j.once {
+		if j.cron != nil {
+			st.Cron = j.cron.String()
+		} else {
+			st.Interval = j.interval.String()
+		}
+		st.Overlap = j.overlap.String()
+	}
+	if j.lockName != "" {
+		st.LockName = j.lockName
+	}
+	if j.timeout > 0 {
+		st.Timeout = j.timeout.String()
+	}
+	if !j.lastStart.IsZero() {
+		t := j.lastStart
+		st.LastStart = &t
+	}
+	if !j.lastEnd.IsZero() {
+		t := j.lastEnd
+		st.LastEnd = &t
+		if !j.running {
+			st.LastDuration = j.lastEnd.Sub(j.lastStart).String()
+		}
+	}
+	if !j.nextRun.IsZero() && !j.paused && !j.once {
+		t := j.nextRun
+		st.NextRun = &t
 	}
+	return st
 }
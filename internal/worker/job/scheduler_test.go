@@ -0,0 +1,221 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	"web3-smart/internal/worker/model"
+
+	"go.uber.org/zap"
+)
+
+// fakeClock 重试退避立即返回，并记录每次等待的时长
+type fakeClock struct {
+	mu     sync.Mutex
+	waits  []time.Duration
+	nowVal time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nowVal
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.nowVal.Add(d)
+	return ch
+}
+
+// fakeLocker 内存租约锁，lost 置位后续约与校验返回 ErrLeaseLost
+type fakeLocker struct {
+	ttl      time.Duration
+	token    atomic.Int64
+	lost     atomic.Bool
+	released atomic.Int32
+}
+
+func (l *fakeLocker) Acquire(_ context.Context, name string) (*Lease, error) {
+	return &Lease{locker: l, name: name, token: l.token.Add(1)}, nil
+}
+
+func (l *fakeLocker) TTL() time.Duration { return l.ttl }
+
+func (l *fakeLocker) renew(context.Context, *Lease) error {
+	if l.lost.Load() {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (l *fakeLocker) check(ctx context.Context, lease *Lease) error {
+	return l.renew(ctx, lease)
+}
+
+func (l *fakeLocker) release(context.Context, *Lease) error {
+	l.released.Add(1)
+	return nil
+}
+
+type memHistory struct {
+	mu   sync.Mutex
+	rows []model.JobHistory
+}
+
+func (h *memHistory) CreateJobHistory(_ context.Context, history *model.JobHistory) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rows = append(h.rows, *history)
+	return nil
+}
+
+func (h *memHistory) last(t *testing.T) model.JobHistory {
+	t.Helper()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.rows) == 0 {
+		t.Fatal("no job history recorded")
+	}
+	return h.rows[len(h.rows)-1]
+}
+
+func newTestScheduler(locker Locker) (*Scheduler, *fakeClock, *memHistory) {
+	history := &memHistory{}
+	s := NewScheduler(zap.NewNop(), locker, history)
+	clk := &fakeClock{nowVal: time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC)}
+	s.clock = clk
+	return s, clk, history
+}
+
+func waitJobIdle(t *testing.T, job *ScheduledJob) {
+	t.Helper()
+	done := make(chan struct{})
+	go func() {
+		job.done.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("job did not finish")
+	}
+}
+
+// 上一轮未结束时：OverlapSkip 丢弃本轮，OverlapQueue 在上一轮结束后补跑一次
+func TestSchedulerOverlap(t *testing.T) {
+	cases := []struct {
+		policy OverlapPolicy
+		want   int32
+	}{
+		{OverlapSkip, 1},
+		{OverlapQueue, 2},
+	}
+	for _, c := range cases {
+		t.Run(c.policy.String(), func(t *testing.T) {
+			s, _, _ := newTestScheduler(nil)
+			var runs atomic.Int32
+			started := make(chan struct{}, 2)
+			release := make(chan struct{})
+			s.RegisterJob("overlap", time.Minute, JobSingleton, func(ctx context.Context) error {
+				runs.Add(1)
+				started <- struct{}{}
+				<-release
+				return nil
+			}, WithOverlap(c.policy))
+			job := s.jobs["overlap"]
+
+			ctx := context.Background()
+			s.dispatch(ctx, job, model.JOB_TRIGGER_SCHEDULE)
+			<-started
+			// 连续两次触发，排队时只保留一次
+			s.dispatch(ctx, job, model.JOB_TRIGGER_SCHEDULE)
+			s.dispatch(ctx, job, model.JOB_TRIGGER_SCHEDULE)
+			close(release)
+			waitJobIdle(t, job)
+
+			if got := runs.Load(); got != c.want {
+				t.Fatalf("runs = %d, want %d", got, c.want)
+			}
+		})
+	}
+}
+
+// 重试到 maxRetries 次后停止，退避逐次翻倍
+func TestSchedulerRetryStopsAtMaxAttempts(t *testing.T) {
+	s, clk, history := newTestScheduler(nil)
+	var calls atomic.Int32
+	errBoom := errors.New("boom")
+	s.RegisterJob("retry", time.Minute, JobSingleton, func(ctx context.Context) error {
+		calls.Add(1)
+		return errBoom
+	}, WithRetry(2, time.Second))
+
+	s.executeJob(context.Background(), s.jobs["retry"], model.JOB_TRIGGER_MANUAL)
+
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("calls = %d, want 3", got)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; len(clk.waits) != len(want) || clk.waits[0] != want[0] || clk.waits[1] != want[1] {
+		t.Fatalf("backoff waits = %v, want %v", clk.waits, want)
+	}
+	h := history.last(t)
+	if h.Status != model.JOB_RUN_STATUS_FAILED || h.Attempts != 3 || h.Error != errBoom.Error() {
+		t.Fatalf("history = %+v, want failed after 3 attempts", h)
+	}
+}
+
+// 超时取消作业 ctx，超时的轮次不再重试
+func TestSchedulerTimeoutCancelsJob(t *testing.T) {
+	s, _, history := newTestScheduler(nil)
+	var calls atomic.Int32
+	s.RegisterJob("timeout", time.Minute, JobSingleton, func(ctx context.Context) error {
+		calls.Add(1)
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithTimeout(20*time.Millisecond), WithRetry(3, time.Second))
+
+	s.executeJob(context.Background(), s.jobs["timeout"], model.JOB_TRIGGER_MANUAL)
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("calls = %d, want 1", got)
+	}
+	if h := history.last(t); h.Status != model.JOB_RUN_STATUS_TIMEOUT {
+		t.Fatalf("status = %q, want %q", h.Status, model.JOB_RUN_STATUS_TIMEOUT)
+	}
+}
+
+// 续约发现租约丢失时取消作业，作业内 CheckLease 返回 ErrLeaseLost
+func TestSchedulerLeaseLostCancelsJob(t *testing.T) {
+	locker := &fakeLocker{ttl: 30 * time.Millisecond}
+	s, _, history := newTestScheduler(locker)
+	var checkErr error
+	s.RegisterJob("lease", time.Minute, JobSingleton, func(ctx context.Context) error {
+		if err := CheckLease(ctx); err != nil {
+			t.Errorf("CheckLease before loss: %v", err)
+		}
+		locker.lost.Store(true)
+		<-ctx.Done()
+		checkErr = CheckLease(ctx)
+		return ctx.Err()
+	}, WithTimeout(2*time.Second))
+
+	s.executeJob(context.Background(), s.jobs["lease"], model.JOB_TRIGGER_MANUAL)
+
+	if !errors.Is(checkErr, ErrLeaseLost) {
+		t.Fatalf("CheckLease after loss = %v, want ErrLeaseLost", checkErr)
+	}
+	h := history.last(t)
+	if h.Status != model.JOB_RUN_STATUS_FAILED || h.LeaseToken != 1 {
+		t.Fatalf("history = %+v, want failed with lease token 1", h)
+	}
+	if got := locker.released.Load(); got != 1 {
+		t.Fatalf("released = %d, want 1", got)
+	}
+}
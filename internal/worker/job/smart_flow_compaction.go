@@ -0,0 +1,139 @@
+package job
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// SmartFlowCompaction 定时将 Redis 中已结束的 5m/1h 聪明钱资金流时间桶压缩写入 SelectDB
+//
+// 每个分辨率维护一个进度 key（已压缩到的桶起始时间，毫秒）：
+//
+//	key: smart_money:monitor:smart_flow:compacted:<resolution>
+//
+// 每次处理进度之后、当前桶之前（已结束）的桶，写入成功后推进进度。
+// 迟到的交易仍会计入已压缩过的桶，因此每次额外回读进度之前 smartFlowCompactionLookback 内的桶重新写入；
+// 晚于该窗口到达的交易只保留在 Redis 中。
+// SelectDB 表为 UNIQUE KEY 模型，重复写入同一个桶会覆盖，保证重跑幂等。
+type SmartFlowCompaction struct {
+	cfg        config.Config
+	repo       repository.Repository
+	tl         *zap.Logger
+	flowSeries *service.SmartFlowSeriesService
+}
+
+const (
+	smartFlowCompactionBatchSize = 1000
+	smartFlowCompactionLookback  = 30 * time.Minute // 回读已压缩桶的窗口，至少一个桶
+)
+
+// NewSmartFlowCompaction 创建资金流压缩任务
+func NewSmartFlowCompaction(cfg config.Config, repo repository.Repository, logger *zap.Logger) *SmartFlowCompaction {
+	return &SmartFlowCompaction{
+		cfg:        cfg,
+		repo:       repo,
+		tl:         logger,
+		flowSeries: service.NewSmartFlowSeriesService(cfg, logger, repo),
+	}
+}
+
+// Run 执行压缩任务
+func (j *SmartFlowCompaction) Run(ctx context.Context) error {
+	for _, r := range service.SmartFlowResolutions {
+		if !r.Compact {
+			continue
+		}
+		if err := j.compactResolution(ctx, r); err != nil {
+			j.tl.Warn("compact smart flow series failed", zap.String("resolution", r.Name), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// compactResolution 压缩单个分辨率下已结束的时间桶
+func (j *SmartFlowCompaction) compactResolution(ctx context.Context, r service.SmartFlowResolution) error {
+	startTime := time.Now()
+	rdb := j.repo.GetMetricsRDB()
+	progressKey := "smart_money:monitor:smart_flow:compacted:" + r.Name
+
+	now := startTime.UnixMilli()
+	stepMs := r.Step.Milliseconds()
+	currentBucket := now - now%stepMs
+
+	// 读取进度并回退回读窗口，缺失时从 Redis 保留时长的起点开始
+	from := now - r.Retention.Milliseconds()
+	if v, err := rdb.Get(ctx, progressKey).Result(); err == nil {
+		if p, err := strconv.ParseInt(v, 10, 64); err == nil {
+			from = max(from, p-max(smartFlowCompactionLookback.Milliseconds(), stepMs))
+		}
+	} else if err != redis.Nil {
+		return err
+	}
+	from -= from % stepMs
+	if from >= currentBucket {
+		return nil
+	}
+
+	tokens, err := j.flowSeries.ActiveTokens(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	rows := make([]model.SmartFlowBucket, 0, smartFlowCompactionBatchSize)
+	written := 0
+	for _, t := range tokens {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		series, err := j.flowSeries.GetSeries(ctx, t.ChainID, t.TokenAddress, r.Name, from, currentBucket-1)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, series...)
+		if len(rows) >= smartFlowCompactionBatchSize {
+			if err := j.write(ctx, rows); err != nil {
+				return err
+			}
+			written += len(rows)
+			rows = rows[:0]
+		}
+	}
+	if len(rows) > 0 {
+		if err := j.write(ctx, rows); err != nil {
+			return err
+		}
+		written += len(rows)
+	}
+
+	// 写入全部成功后推进进度；推进前确认租约仍有效，避免被接管后用旧的 currentBucket 回退接管者的进度
+	if err := CheckLease(ctx); err != nil {
+		return err
+	}
+	if err := rdb.Set(ctx, progressKey, currentBucket, r.Retention).Err(); err != nil {
+		return err
+	}
+
+	j.tl.Info("smart flow series compacted",
+		zap.String("resolution", r.Name),
+		zap.Int("tokens", len(tokens)),
+		zap.Int("buckets", written),
+		zap.Int64("from", from),
+		zap.Int64("to", currentBucket),
+		zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
+
+// write 批量写入 SelectDB
+func (j *SmartFlowCompaction) write(ctx context.Context, rows []model.SmartFlowBucket) error {
+	return j.repo.GetSelectDB().WithContext(ctx).CreateInBatches(rows, smartFlowCompactionBatchSize).Error
+}
@@ -13,13 +13,17 @@ import (
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
 	"web3-smart/internal/worker/writer"
 	walletwriter "web3-smart/internal/worker/writer/wallet"
 	getOnchainInfo "web3-smart/pkg/utils/get_onchain_info"
+	walletUtils "web3-smart/pkg/utils/wallet_utils"
 
 	"github.com/gagliardetto/solana-go"
 	rpcsol "github.com/gagliardetto/solana-go/rpc"
+	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/quotecoin"
@@ -27,6 +31,12 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	smartMoneyAnalyzeJob  = "smart_money_analyze"
+	analyzePageSize       = 800
+	analyzeWorkersPerPage = 16
+)
+
 type SmartMoneyAnalyzer struct {
 	repo   repository.Repository
 	logger *zap.Logger
@@ -46,31 +56,35 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 		return fmt.Errorf("db is nil")
 	}
 
-	var esAsync *writer.AsyncBatchWriter[model.WalletSummary]
-	if esClient := j.repo.GetElasticsearchClient(); esClient != nil && j.Cfg.Elasticsearch.WalletsIndexName != "" {
-		esWriter := walletwriter.NewESWalletWriter(esClient, j.logger, j.Cfg.Elasticsearch.WalletsIndexName)
-		// 提升 ES 批次、併發與 flush 頻率
-		esAsync = writer.NewAsyncBatchWriter[model.WalletSummary](j.logger, esWriter, 2000, 150*time.Millisecond, "wallet_es_writer", 8)
-		esAsync.Start(ctx)
+	esAsync := j.newESWriter(ctx, "wallet_es_analyze_writer")
+	if esAsync != nil {
 		defer esAsync.Close()
 	}
 
-	const pageSize = 800
-	const workersPerPage = 16
-	var processed int64 = 0
-	var errors int64 = 0
-	var lastID int64 = 0
-	start := time.Now()
-	j.logger.Info("smart_money_analyze start", zap.Time("start_time", start))
+	rdb := j.repo.GetMainRDB()
+	cp := j.loadCheckpoint(ctx, rdb)
+	var processed = cp.Processed
+	var errors = cp.Errors
+	var lastID = cp.LastID
+	start := time.UnixMilli(cp.StartedAt)
+	j.logger.Info("smart_money_analyze start",
+		zap.String("run_id", cp.RunID),
+		zap.Time("start_time", start),
+		zap.Int64("resume_from_id", lastID))
 
 	for {
 		if ctx.Err() != nil {
 			j.logger.Warn("smart_money_analyze cancelled", zap.Error(ctx.Err()))
 			return ctx.Err()
 		}
+		// 每頁寫入前確認租約仍有效，避免租約過期後與接管的實例併發寫 t_smart_wallet
+		if err := CheckLease(ctx); err != nil {
+			j.logger.Warn("smart_money_analyze lease check failed", zap.Error(err))
+			return err
+		}
 		var wallets []model.WalletSummary
 		// Keyset 分頁：避免大 Offset 退化
-		tx := db.WithContext(ctx).Where("id > ?", lastID).Limit(pageSize).Order("id ASC").Find(&wallets)
+		tx := db.WithContext(ctx).Where("id > ?", lastID).Limit(analyzePageSize).Order("id ASC").Find(&wallets)
 		if tx.Error != nil {
 			return tx.Error
 		}
@@ -82,40 +96,20 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 		j.logger.Info("smart_money_analyze page fetched", zap.Int("count", len(wallets)), zap.Int64("since_processed", atomic.LoadInt64(&processed)))
 
 		// 併發處理當前頁的錢包
-		jobs := make(chan *model.WalletSummary, len(wallets))
-		var wg sync.WaitGroup
-		worker := func() {
-			defer wg.Done()
-			for w := range jobs {
-				if esDoc, err := j.updateOneWallet(ctx, db, w); err != nil {
-					atomic.AddInt64(&errors, 1)
-					j.logger.Error("update wallet failed", zap.String("wallet", w.WalletAddress), zap.Error(err))
-				} else if esAsync != nil && esDoc != nil {
-					esAsync.Submit(*esDoc, w.WalletAddress)
-				}
-				n := atomic.AddInt64(&processed, 1)
-				if n%1000 == 0 {
-					elapsed := time.Since(start)
-					j.logger.Info("smart_money_analyze progress",
-						zap.Int64("processed", n),
-						zap.Int64("errors", atomic.LoadInt64(&errors)),
-						zap.Duration("elapsed", elapsed),
-						zap.Float64("throughput_wallets_per_sec", float64(n)/elapsed.Seconds()))
-				}
-				if ctx.Err() != nil {
-					return
-				}
+		j.processWallets(ctx, db, wallets, esAsync, func(w *model.WalletSummary, err error) {
+			if err != nil {
+				atomic.AddInt64(&errors, 1)
 			}
-		}
-		for i := 0; i < workersPerPage; i++ {
-			wg.Add(1)
-			go worker()
-		}
-		for i := range wallets {
-			jobs <- &wallets[i]
-		}
-		close(jobs)
-		wg.Wait()
+			n := atomic.AddInt64(&processed, 1)
+			if n%1000 == 0 {
+				elapsed := time.Since(start)
+				j.logger.Info("smart_money_analyze progress",
+					zap.Int64("processed", n),
+					zap.Int64("errors", atomic.LoadInt64(&errors)),
+					zap.Duration("elapsed", elapsed),
+					zap.Float64("throughput_wallets_per_sec", float64(n)/elapsed.Seconds()))
+			}
+		})
 		pageElapsed := time.Since(pageStart)
 		j.logger.Debug("smart_money_analyze page processed",
 			zap.Int("count", len(wallets)),
@@ -124,6 +118,29 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 
 		// 設定下一頁的起點
 		lastID = wallets[len(wallets)-1].ID
+
+		// 每頁保存斷點；斷點被刪除表示要求重新完整掃描，從頭開始新一輪
+		if cp.Pages > 0 {
+			if current, err := LoadCheckpoint(ctx, rdb, smartMoneyAnalyzeJob); err == nil && current == nil {
+				j.logger.Info("smart_money_analyze checkpoint cleared, restart full pass", zap.String("run_id", cp.RunID))
+				cp = newAnalyzeCheckpoint()
+				processed, lastID = 0, 0
+				atomic.StoreInt64(&errors, 0)
+				start = time.UnixMilli(cp.StartedAt)
+				continue
+			}
+		}
+		cp.LastID = lastID
+		cp.Processed = atomic.LoadInt64(&processed)
+		cp.Errors = atomic.LoadInt64(&errors)
+		cp.Pages++
+		if err := SaveCheckpoint(ctx, rdb, smartMoneyAnalyzeJob, cp); err != nil {
+			j.logger.Warn("smart_money_analyze save checkpoint failed", zap.Int64("last_id", lastID), zap.Error(err))
+		}
+	}
+	// 整輪完成，清除斷點，下次從頭開始
+	if err := ClearCheckpoint(ctx, rdb, smartMoneyAnalyzeJob); err != nil {
+		j.logger.Warn("smart_money_analyze clear checkpoint failed", zap.Error(err))
 	}
 	elapsed := time.Since(start)
 	var throughput float64
@@ -134,9 +151,9 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 	if processed > 0 {
 		avgPerWalletMs = float64(elapsed.Milliseconds()) / float64(processed)
 	}
-	// 觀察與 2 小時排程的關係
-	twoHours := 2 * time.Hour
-	percentOfTwoHours := (float64(elapsed) / float64(twoHours)) * 100
+	// 觀察與 6 小時排程的關係
+	sixHours := 6 * time.Hour
+	percentOfSixHours := (float64(elapsed) / float64(sixHours)) * 100
 	errorCount := atomic.LoadInt64(&errors)
 	successCount := processed - errorCount
 	var successRate float64
@@ -145,6 +162,8 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 	}
 	j.logger.Info(
 		"smart_money_analyze done",
+		zap.String("run_id", cp.RunID),
+		zap.Int64("pages", cp.Pages),
 		zap.Time("start_time", start),
 		zap.Time("end_time", time.Now()),
 		zap.Int64("processed", processed),
@@ -154,11 +173,185 @@ func (j *SmartMoneyAnalyzer) Run(ctx context.Context) error {
 		zap.Duration("elapsed", elapsed),
 		zap.Float64("throughput_wallets_per_sec", throughput),
 		zap.Float64("avg_ms_per_wallet", avgPerWalletMs),
-		zap.Float64("elapsed_vs_2h_percent", percentOfTwoHours),
+		zap.Float64("elapsed_vs_6h_percent", percentOfSixHours),
 	)
 	return nil
 }
 
+// RunIncremental 增量模式：只重算有新交易的錢包（dirty set），計算量隨交易活躍度而非錢包總數增長。
+// 每次最多處理啟動時集合中的數量，避免交易持續寫入導致任務無法結束；
+// 無交易錢包因時間窗口滑出產生的指標變化仍由全量掃描（Run）處理。
+// 與全量掃描共用同一租約（WithLockName），兩者不會同時寫 t_smart_wallet。
+func (j *SmartMoneyAnalyzer) RunIncremental(ctx context.Context) error {
+	db := j.repo.GetDB()
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	}
+
+	dirtySet := service.NewWalletDirtySetService(j.Cfg, j.logger, j.repo)
+	remaining, err := dirtySet.Size(ctx)
+	if err != nil {
+		return err
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	esAsync := j.newESWriter(ctx, "wallet_es_analyze_incremental_writer")
+	if esAsync != nil {
+		defer esAsync.Close()
+	}
+
+	start := time.Now()
+	var processed, failed, missing int64
+	for remaining > 0 {
+		if ctx.Err() != nil {
+			j.logger.Warn("smart_money_analyze_incremental cancelled", zap.Error(ctx.Err()))
+			return ctx.Err()
+		}
+		if err := CheckLease(ctx); err != nil {
+			j.logger.Warn("smart_money_analyze_incremental lease check failed", zap.Error(err))
+			return err
+		}
+
+		dirty, err := dirtySet.Pop(ctx, min(remaining, analyzePageSize))
+		if err != nil {
+			return err
+		}
+		if len(dirty) == 0 {
+			break
+		}
+		remaining -= int64(len(dirty))
+
+		wallets, err := j.loadDirtyWallets(ctx, db, dirty)
+		if err != nil {
+			j.restoreDirty(dirtySet, dirty)
+			return err
+		}
+		// 錢包資料尚未落庫（或已被清理）的直接丟棄，下一筆交易會重新標記
+		missing += int64(len(dirty) - len(wallets))
+
+		var mu sync.Mutex
+		done := make(map[string]struct{}, len(wallets))
+		j.processWallets(ctx, db, wallets, esAsync, func(w *model.WalletSummary, err error) {
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			done[service.DirtyWallet{ChainID: w.ChainID, WalletAddress: w.WalletAddress}.Key()] = struct{}{}
+			mu.Unlock()
+		})
+
+		// 失敗或因取消未處理的放回集合，下次重試
+		var retry []service.DirtyWallet
+		for _, w := range wallets {
+			d := service.DirtyWallet{ChainID: w.ChainID, WalletAddress: w.WalletAddress}
+			if _, ok := done[d.Key()]; !ok {
+				retry = append(retry, d)
+			}
+		}
+		j.restoreDirty(dirtySet, retry)
+		processed += int64(len(wallets))
+		failed += int64(len(retry))
+	}
+
+	j.logger.Info("smart_money_analyze_incremental done",
+		zap.Int64("processed", processed),
+		zap.Int64("failed", failed),
+		zap.Int64("missing", missing),
+		zap.Duration("elapsed", time.Since(start)))
+	return nil
+}
+
+// loadDirtyWallets 按 (chain_id, wallet_address) 批量讀取錢包
+func (j *SmartMoneyAnalyzer) loadDirtyWallets(ctx context.Context, db *gorm.DB, dirty []service.DirtyWallet) ([]model.WalletSummary, error) {
+	pairs := make([][]interface{}, 0, len(dirty))
+	for _, d := range dirty {
+		pairs = append(pairs, []interface{}{d.ChainID, d.WalletAddress})
+	}
+	var wallets []model.WalletSummary
+	err := db.WithContext(ctx).Where("(chain_id, wallet_address) IN ?", pairs).Find(&wallets).Error
+	return wallets, err
+}
+
+// restoreDirty 放回集合，作業 ctx 可能已取消，使用獨立的短超時
+func (j *SmartMoneyAnalyzer) restoreDirty(dirtySet *service.WalletDirtySetService, wallets []service.DirtyWallet) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := dirtySet.Restore(ctx, wallets); err != nil {
+		j.logger.Warn("smart_money_analyze_incremental restore dirty wallets failed", zap.Int("count", len(wallets)), zap.Error(err))
+	}
+}
+
+// newESWriter 建立錢包 ES 非同步寫入器，未配置 ES 時返回 nil。
+// 全量與增量各用一個 id，與交易流程的 wallet_es_writer 互不相同（指標與落盤檔名按 id 區分）
+func (j *SmartMoneyAnalyzer) newESWriter(ctx context.Context, id string) *writer.AsyncBatchWriter[model.WalletSummary] {
+	esClient := j.repo.GetElasticsearchClient()
+	if esClient == nil || j.Cfg.Elasticsearch.WalletsIndexName == "" {
+		return nil
+	}
+	esWriter := walletwriter.NewESWalletWriter(esClient, j.logger, j.Cfg.Elasticsearch.WalletsIndexName)
+	// 批次、併發與 flush 頻率見 async_writer.writers 配置
+	esAsync := writer.NewAsyncBatchWriter(j.logger, esWriter, id, writer.WithKeyFunc(walletUtils.WalletKey))
+	esAsync.Start(ctx)
+	return esAsync
+}
+
+// processWallets 併發重算一批錢包，每個錢包處理完後回調 done；ctx 取消後未處理的錢包不回調
+func (j *SmartMoneyAnalyzer) processWallets(ctx context.Context, db *gorm.DB, wallets []model.WalletSummary, esAsync *writer.AsyncBatchWriter[model.WalletSummary], done func(w *model.WalletSummary, err error)) {
+	jobs := make(chan *model.WalletSummary, len(wallets))
+	var wg sync.WaitGroup
+	worker := func() {
+		defer wg.Done()
+		for w := range jobs {
+			if ctx.Err() != nil {
+				return
+			}
+			esDoc, err := j.updateOneWallet(ctx, db, w)
+			if err != nil {
+				j.logger.Error("update wallet failed", zap.String("wallet", w.WalletAddress), zap.Error(err))
+			} else if esAsync != nil && esDoc != nil {
+				esAsync.Submit(*esDoc, w.WalletAddress)
+			}
+			done(w, err)
+		}
+	}
+	for i := 0; i < analyzeWorkersPerPage; i++ {
+		wg.Add(1)
+		go worker()
+	}
+	for i := range wallets {
+		jobs <- &wallets[i]
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// loadCheckpoint 讀取上一輪未完成的斷點，不存在或讀取失敗時開始新一輪
+func (j *SmartMoneyAnalyzer) loadCheckpoint(ctx context.Context, rdb *redis.Client) *Checkpoint {
+	cp, err := LoadCheckpoint(ctx, rdb, smartMoneyAnalyzeJob)
+	if err != nil {
+		j.logger.Warn("smart_money_analyze load checkpoint failed, start new run", zap.Error(err))
+		return newAnalyzeCheckpoint()
+	}
+	if cp == nil {
+		return newAnalyzeCheckpoint()
+	}
+	j.logger.Info("smart_money_analyze resume interrupted run",
+		zap.String("run_id", cp.RunID),
+		zap.Int64("last_id", cp.LastID),
+		zap.Int64("processed", cp.Processed),
+		zap.Int64("pages", cp.Pages))
+	return cp
+}
+
+func newAnalyzeCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		RunID:     uuid.NewString(),
+		StartedAt: time.Now().UnixMilli(),
+	}
+}
+
 func (j *SmartMoneyAnalyzer) getTokenPriceUSD(ctx context.Context, tokenAddress string) (float64, error) {
 	es := j.repo.GetElasticsearchClient()
 	if es == nil {
@@ -969,9 +1162,9 @@ func (j *SmartMoneyAnalyzer) updateOneWallet(ctx context.Context, db *gorm.DB, w
 	// 最後更新時間無論如何刷新，代表本次任務已跑過；如果你希望「僅變化才更新」，可以再細化為 dirty 檢查
 	updates["updated_at"] = time.Now().UnixMilli()
 
-	if err := db.WithContext(ctx).Model(&model.WalletSummary{}).
-		Where("wallet_address = ?", w.WalletAddress).
-		Updates(updates).Error; err != nil {
+	// 帶 fencing token 寫入：租約過期後被接管時，本實例的舊結果不會覆蓋接管者已寫入的錢包
+	q := db.WithContext(ctx).Model(&model.WalletSummary{}).Where("wallet_address = ?", w.WalletAddress)
+	if err := fencedUpdates(ctx, q, "analyze_fence", updates); err != nil {
 		return nil, err
 	}
 
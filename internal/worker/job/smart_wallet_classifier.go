@@ -38,6 +38,11 @@ func (j *SmartWalletClassifier) Run(ctx context.Context) error {
 			j.logger.Warn("smart_wallet_classifier cancelled", zap.Error(ctx.Err()))
 			return ctx.Err()
 		}
+		// 每頁寫入前確認租約仍有效，避免租約過期後與接管的實例併發寫 t_smart_wallet
+		if err := CheckLease(ctx); err != nil {
+			j.logger.Warn("smart_wallet_classifier lease check failed", zap.Error(err))
+			return err
+		}
 
 		var wallets []model.WalletSummary
 		tx := db.WithContext(ctx).
@@ -15,15 +15,15 @@ type TokenBalance struct {
 	cfg                 config.Config
 	repo                repository.Repository
 	tl                  *zap.Logger
-	tokenBalanceService service.BalanceUpdate
+	tokenBalanceService *service.BalanceUpdate
 }
 
-func NewTokenBalance(cfg config.Config, repo repository.Repository, logger *zap.Logger) *TokenBalance {
+func NewTokenBalance(cfg config.Config, repo repository.Repository, logger *zap.Logger, tokenBalanceService *service.BalanceUpdate) *TokenBalance {
 	return &TokenBalance{
 		repo:                repo,
 		tl:                  logger,
 		cfg:                 cfg,
-		tokenBalanceService: *service.NewBalanceUpdate(cfg, logger, repo),
+		tokenBalanceService: tokenBalanceService,
 	}
 }
 
@@ -36,6 +36,11 @@ func (t *TokenBalance) Run(ctx context.Context) error {
 		}
 
 		for _, token := range tokens {
+			// 每个 token 间隔 10s，逐个确认租约仍有效
+			if err := CheckLease(ctx); err != nil {
+				t.tl.Warn("token balance lease check failed", zap.Error(err))
+				return err
+			}
 			tokenFake := token
 			t.tl.Debug("load balance", zap.String("network", tokenFake.Network), zap.String("address", tokenFake.Address))
 			err := t.tokenBalanceService.LoadBalanceFromExternal(ctx, tokenFake)
@@ -0,0 +1,316 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/elasticsearch"
+
+	"github.com/shopspring/decimal"
+	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+	"go.uber.org/zap"
+)
+
+// TokenSmartMoney 定时聚合 token 维度的聪明钱数据，写入 web3_tokens.smart_money_info 及 ES web3_tokens 索引
+//
+// 数据来源：
+//   - t_smart_holding：当前持仓的聪明钱数量、持仓价值/数量、平均买入市值
+//   - t_smart_transaction：24h 买卖金额（净流入）、首次/最近买入时间
+//
+// 只处理「当前有聪明钱持仓」或「24h 内有聪明钱交易」的 token，
+// 这样聪明钱全部清仓的 token 也会在下一轮被刷新为 0。
+type TokenSmartMoney struct {
+	cfg  config.Config
+	repo repository.Repository
+	tl   *zap.Logger
+}
+
+// tokenSmartHoldingRow 持仓聚合结果
+type tokenSmartHoldingRow struct {
+	ChainID           uint64          `gorm:"column:chain_id"`
+	TokenAddress      string          `gorm:"column:token_address"`
+	HolderCount       int64           `gorm:"column:holder_count"`
+	HoldingValueUSD   decimal.Decimal `gorm:"column:holding_value_usd"`
+	HoldingAmount     decimal.Decimal `gorm:"column:holding_amount"`
+	AvgEntryMarketCap decimal.Decimal `gorm:"column:avg_entry_marketcap"`
+}
+
+// tokenSmartTxRow 交易聚合结果
+type tokenSmartTxRow struct {
+	TokenAddress string          `gorm:"column:token_address"`
+	BuyValue24h  decimal.Decimal `gorm:"column:buy_value_24h"`
+	SellValue24h decimal.Decimal `gorm:"column:sell_value_24h"`
+	FirstBuyTime *int64          `gorm:"column:first_buy_time"`
+	LastBuyTime  *int64          `gorm:"column:last_buy_time"`
+}
+
+const tokenSmartMoneyBatchSize = 500
+
+// NewTokenSmartMoney 创建 token 聪明钱聚合任务
+func NewTokenSmartMoney(cfg config.Config, repo repository.Repository, logger *zap.Logger) *TokenSmartMoney {
+	return &TokenSmartMoney{
+		cfg:  cfg,
+		repo: repo,
+		tl:   logger,
+	}
+}
+
+// Run 执行聚合任务
+func (j *TokenSmartMoney) Run(ctx context.Context) error {
+	startTime := time.Now()
+	since24h := startTime.Add(-24 * time.Hour).UnixMilli()
+
+	// 1. 聚合持仓数据
+	holdings, err := j.queryHoldings(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 2. 合并 24h 内有交易的 token，按链分组
+	tokensByChain := make(map[uint64][]string)
+	holdingMap := make(map[string]*tokenSmartHoldingRow, len(holdings))
+	for i := range holdings {
+		h := &holdings[i]
+		holdingMap[tokenSmartMoneyKey(h.ChainID, h.TokenAddress)] = h
+		tokensByChain[h.ChainID] = append(tokensByChain[h.ChainID], h.TokenAddress)
+	}
+
+	activeTokens, err := j.queryActiveTokens(ctx, since24h)
+	if err != nil {
+		return err
+	}
+	for _, t := range activeTokens {
+		if _, ok := holdingMap[tokenSmartMoneyKey(t.ChainID, t.TokenAddress)]; ok {
+			continue
+		}
+		tokensByChain[t.ChainID] = append(tokensByChain[t.ChainID], t.TokenAddress)
+	}
+
+	// 3. 分批计算并写入
+	updatedCount := 0
+	for chainID, tokens := range tokensByChain {
+		for start := 0; start < len(tokens); start += tokenSmartMoneyBatchSize {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// 每批写入前确认租约仍有效
+			if err := CheckLease(ctx); err != nil {
+				j.tl.Warn("token smart money lease check failed", zap.Error(err))
+				return err
+			}
+			end := start + tokenSmartMoneyBatchSize
+			if end > len(tokens) {
+				end = len(tokens)
+			}
+			n, err := j.processBatch(ctx, chainID, tokens[start:end], holdingMap, since24h)
+			if err != nil {
+				j.tl.Warn("token smart money batch failed",
+					zap.Uint64("chain_id", chainID),
+					zap.Int("batch_size", end-start),
+					zap.Error(err))
+				continue
+			}
+			updatedCount += n
+		}
+	}
+
+	j.tl.Info("token smart money aggregation completed",
+		zap.Int("holding_tokens", len(holdings)),
+		zap.Int("active_tokens_24h", len(activeTokens)),
+		zap.Int("updated_count", updatedCount),
+		zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
+
+// queryHoldings 按 token 聚合当前聪明钱持仓
+func (j *TokenSmartMoney) queryHoldings(ctx context.Context) ([]tokenSmartHoldingRow, error) {
+	sql := `
+SELECT
+    chain_id,
+    token_address,
+    COUNT(DISTINCT wallet_address) AS holder_count,
+    SUM(value_usd) AS holding_value_usd,
+    SUM(amount) AS holding_amount,
+    COALESCE(AVG(NULLIF(marketcap, 0)), 0) AS avg_entry_marketcap
+FROM dex_query_v1.t_smart_holding
+WHERE amount > 0
+GROUP BY chain_id, token_address`
+
+	var rows []tokenSmartHoldingRow
+	if err := j.repo.GetDB().WithContext(ctx).Raw(sql).Scan(&rows).Error; err != nil {
+		j.tl.Warn("query token smart holdings failed", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// queryActiveTokens 查询 24h 内有聪明钱交易的 token
+func (j *TokenSmartMoney) queryActiveTokens(ctx context.Context, since int64) ([]TokenKey, error) {
+	var rows []TokenKey
+	err := j.repo.GetDB().WithContext(ctx).
+		Model(&model.WalletTransaction{}).
+		Distinct("chain_id", "token_address").
+		Where("transaction_time >= ?", since).
+		Scan(&rows).Error
+	if err != nil {
+		j.tl.Warn("query active smart tokens failed", zap.Error(err))
+		return nil, err
+	}
+	return rows, nil
+}
+
+// queryTransactions 按 token 聚合聪明钱交易
+func (j *TokenSmartMoney) queryTransactions(ctx context.Context, chainID uint64, tokens []string, since int64) ([]tokenSmartTxRow, error) {
+	sql := `
+SELECT
+    token_address,
+    SUM(CASE WHEN transaction_type IN ('buy', 'build') AND transaction_time >= ? THEN value ELSE 0 END) AS buy_value_24h,
+    SUM(CASE WHEN transaction_type IN ('sell', 'clean') AND transaction_time >= ? THEN value ELSE 0 END) AS sell_value_24h,
+    MIN(CASE WHEN transaction_type IN ('buy', 'build') THEN transaction_time END) AS first_buy_time,
+    MAX(CASE WHEN transaction_type IN ('buy', 'build') THEN transaction_time END) AS last_buy_time
+FROM dex_query_v1.t_smart_transaction
+WHERE chain_id = ?
+  AND token_address IN ?
+GROUP BY token_address`
+
+	var rows []tokenSmartTxRow
+	if err := j.repo.GetDB().WithContext(ctx).Raw(sql, since, since, chainID, tokens).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// processBatch 计算一批 token 的聪明钱信息，并写入 PG 与 ES
+func (j *TokenSmartMoney) processBatch(
+	ctx context.Context,
+	chainID uint64,
+	tokens []string,
+	holdingMap map[string]*tokenSmartHoldingRow,
+	since24h int64,
+) (int, error) {
+	txRows, err := j.queryTransactions(ctx, chainID, tokens, since24h)
+	if err != nil {
+		return 0, err
+	}
+	txMap := make(map[string]*tokenSmartTxRow, len(txRows))
+	for i := range txRows {
+		txMap[txRows[i].TokenAddress] = &txRows[i]
+	}
+
+	supplyMap := j.fetchSupplies(ctx, chainID, tokens)
+
+	network := bip0044.ChainIdToString(chainID)
+	indexName := j.cfg.Elasticsearch.Web3TokensIndexName
+	tokenDAO := j.repo.GetDAOManager().TokenDAO
+	now := time.Now().UnixMilli()
+
+	operations := make([]elasticsearch.BulkOperation, 0, len(tokens))
+	updatedCount := 0
+	for _, tokenAddr := range tokens {
+		info := buildTokenSmartMoneyInfo(holdingMap[tokenSmartMoneyKey(chainID, tokenAddr)], txMap[tokenAddr], supplyMap[tokenAddr], now)
+
+		if err := tokenDAO.UpdateSmartMoneyInfo(ctx, chainID, tokenAddr, info); err != nil {
+			j.tl.Warn("update token smart_money_info failed",
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", tokenAddr),
+				zap.Error(err))
+			continue
+		}
+		updatedCount++
+
+		if network != "" && indexName != "" {
+			operations = append(operations, elasticsearch.BulkOperation{
+				Action: "update",
+				Index:  indexName,
+				ID:     fmt.Sprintf("%s_%s", network, tokenAddr),
+				Document: map[string]interface{}{
+					"doc": map[string]interface{}{
+						"smart_money_info": info,
+					},
+				},
+			})
+		}
+	}
+
+	if len(operations) > 0 {
+		if err := j.repo.GetElasticsearchClient().BulkWrite(ctx, operations); err != nil {
+			j.tl.Warn("write token smart_money_info to ES failed",
+				zap.Uint64("chain_id", chainID),
+				zap.Int("operations", len(operations)),
+				zap.Error(err))
+		}
+	}
+
+	return updatedCount, nil
+}
+
+// fetchSupplies 从 ES 批量获取 token 总供应量
+func (j *TokenSmartMoney) fetchSupplies(ctx context.Context, chainID uint64, tokens []string) map[string]decimal.Decimal {
+	supplies := make(map[string]decimal.Decimal, len(tokens))
+
+	network := bip0044.ChainIdToString(chainID)
+	esClient := j.repo.GetElasticsearchClient()
+	if network == "" || esClient == nil {
+		return supplies
+	}
+
+	docIDs := make([]string, 0, len(tokens))
+	docToToken := make(map[string]string, len(tokens))
+	for _, tokenAddr := range tokens {
+		docID := fmt.Sprintf("%s_%s", network, tokenAddr)
+		docIDs = append(docIDs, docID)
+		docToToken[docID] = tokenAddr
+	}
+
+	mgetResult, err := esClient.Mget(ctx, j.cfg.Elasticsearch.Web3TokensIndexName, docIDs)
+	if err != nil {
+		j.tl.Warn("mget token supply from ES failed", zap.Uint64("chain_id", chainID), zap.Error(err))
+		return supplies
+	}
+
+	for _, doc := range mgetResult.Docs {
+		if !doc.Found || doc.Source == nil {
+			continue
+		}
+		if totalSupply, ok := doc.Source["total_supply"].(float64); ok {
+			supplies[docToToken[doc.ID]] = decimal.NewFromFloat(totalSupply)
+		}
+	}
+	return supplies
+}
+
+// buildTokenSmartMoneyInfo 由持仓聚合、交易聚合与总供应量计算 token 聪明钱信息，h、t 可为 nil
+func buildTokenSmartMoneyInfo(h *tokenSmartHoldingRow, t *tokenSmartTxRow, supply decimal.Decimal, now int64) *model.TokenSmartMoneyInfo {
+	info := &model.TokenSmartMoneyInfo{UpdatedAt: now}
+
+	if h != nil {
+		info.HolderCount = h.HolderCount
+		info.HoldingValueUSD = h.HoldingValueUSD
+		info.HoldingAmount = h.HoldingAmount
+		info.AvgEntryMarketCap = h.AvgEntryMarketCap
+		if supply.GreaterThan(decimal.Zero) {
+			info.HoldingSupplyPercent = h.HoldingAmount.Div(supply).Mul(decimal.NewFromInt(100))
+		}
+	}
+
+	if t != nil {
+		info.BuyValue24h = t.BuyValue24h
+		info.SellValue24h = t.SellValue24h
+		if t.FirstBuyTime != nil {
+			info.FirstBuyTime = *t.FirstBuyTime
+		}
+		if t.LastBuyTime != nil {
+			info.LastBuyTime = *t.LastBuyTime
+		}
+	}
+	info.NetFlow24h = info.BuyValue24h.Sub(info.SellValue24h)
+	return info
+}
+
+func tokenSmartMoneyKey(chainID uint64, tokenAddress string) string {
+	return fmt.Sprintf("%d_%s", chainID, tokenAddress)
+}
@@ -0,0 +1,52 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestBuildTokenSmartMoneyInfo(t *testing.T) {
+	first, last := int64(1000), int64(2000)
+	h := &tokenSmartHoldingRow{
+		HolderCount:       3,
+		HoldingValueUSD:   decimal.NewFromInt(1500),
+		HoldingAmount:     decimal.NewFromInt(250),
+		AvgEntryMarketCap: decimal.NewFromInt(80000),
+	}
+	tx := &tokenSmartTxRow{
+		BuyValue24h:  decimal.NewFromInt(700),
+		SellValue24h: decimal.NewFromInt(200),
+		FirstBuyTime: &first,
+		LastBuyTime:  &last,
+	}
+
+	info := buildTokenSmartMoneyInfo(h, tx, decimal.NewFromInt(1000), 42)
+	if info.HolderCount != 3 || !info.HoldingValueUSD.Equal(decimal.NewFromInt(1500)) {
+		t.Errorf("holding fields = %d / %s", info.HolderCount, info.HoldingValueUSD)
+	}
+	if !info.HoldingSupplyPercent.Equal(decimal.NewFromInt(25)) {
+		t.Errorf("HoldingSupplyPercent = %s, want 25", info.HoldingSupplyPercent)
+	}
+	if !info.NetFlow24h.Equal(decimal.NewFromInt(500)) {
+		t.Errorf("NetFlow24h = %s, want 500", info.NetFlow24h)
+	}
+	if info.FirstBuyTime != first || info.LastBuyTime != last || info.UpdatedAt != 42 {
+		t.Errorf("times = %d / %d / %d", info.FirstBuyTime, info.LastBuyTime, info.UpdatedAt)
+	}
+
+	// 聪明钱已全部清仓、只剩 24h 卖出：持仓归零，净流入为负
+	info = buildTokenSmartMoneyInfo(nil, &tokenSmartTxRow{SellValue24h: decimal.NewFromInt(300)}, decimal.Zero, 42)
+	if info.HolderCount != 0 || !info.HoldingSupplyPercent.IsZero() {
+		t.Errorf("cleared token holding = %d / %s", info.HolderCount, info.HoldingSupplyPercent)
+	}
+	if !info.NetFlow24h.Equal(decimal.NewFromInt(-300)) {
+		t.Errorf("NetFlow24h = %s, want -300", info.NetFlow24h)
+	}
+
+	// 没有总供应量时不计算占比
+	info = buildTokenSmartMoneyInfo(h, nil, decimal.Zero, 42)
+	if !info.HoldingSupplyPercent.IsZero() || !info.NetFlow24h.IsZero() {
+		t.Errorf("no supply: percent = %s, net flow = %s", info.HoldingSupplyPercent, info.NetFlow24h)
+	}
+}
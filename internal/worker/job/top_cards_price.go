@@ -0,0 +1,42 @@
+package job
+
+import (
+	"context"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"go.uber.org/zap"
+)
+
+// TopCardsPriceRefresh 定时刷新小卡片榜单前 detail_count 个 token 的价格与周期涨跌幅
+type TopCardsPriceRefresh struct {
+	cfg      config.Config
+	repo     repository.Repository
+	tl       *zap.Logger
+	topCards *service.TopCardsService
+}
+
+// NewTopCardsPriceRefresh 创建小卡片价格刷新任务，topCards 与 trade 处理器共用同一实例
+func NewTopCardsPriceRefresh(cfg config.Config, repo repository.Repository, logger *zap.Logger, topCards *service.TopCardsService) *TopCardsPriceRefresh {
+	return &TopCardsPriceRefresh{
+		cfg:      cfg,
+		repo:     repo,
+		tl:       logger,
+		topCards: topCards,
+	}
+}
+
+// Run 执行价格刷新
+func (j *TopCardsPriceRefresh) Run(ctx context.Context) error {
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("top cards price refresh lease check failed", zap.Error(err))
+		return err
+	}
+	if err := j.topCards.RefreshPrices(ctx); err != nil {
+		j.tl.Warn("refresh top cards prices failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
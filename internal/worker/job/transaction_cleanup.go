@@ -38,7 +38,11 @@ func (j *TransactionCleanup) Run(ctx context.Context) error {
 		zap.Int64("cutoff_timestamp", oneMonthAgo),
 		zap.String("cutoff_time", time.Unix(oneMonthAgo, 0).Format("2006-01-02 15:04:05")))
 
-	// 执行删除操作
+	// 执行删除操作，删除前确认租约仍有效
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("transaction cleanup lease check failed", zap.Error(err))
+		return err
+	}
 	db := j.repo.GetDB()
 	result := db.WithContext(ctx).
 		Where("transaction_time < ?", oneMonthAgo).
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"context"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/service"
+
+	"go.uber.org/zap"
+)
+
+// TransactionPairsMarketRefresh 定时批量刷新最新交易对 Top50 的价格、市值、24h 成交额与涨跌幅
+type TransactionPairsMarketRefresh struct {
+	cfg              config.Config
+	repo             repository.Repository
+	tl               *zap.Logger
+	transactionPairs *service.TransactionPairsService
+	tradeStats       *service.TokenTradeStatsService
+}
+
+// NewTransactionPairsMarketRefresh 创建最新交易对行情刷新任务，tradeStats 与 trade 处理器共用同一实例
+func NewTransactionPairsMarketRefresh(cfg config.Config, repo repository.Repository, logger *zap.Logger, tradeStats *service.TokenTradeStatsService) *TransactionPairsMarketRefresh {
+	return &TransactionPairsMarketRefresh{
+		cfg:              cfg,
+		repo:             repo,
+		tl:               logger,
+		transactionPairs: service.NewTransactionPairsService(cfg, logger, repo),
+		tradeStats:       tradeStats,
+	}
+}
+
+// Run 执行行情刷新
+func (j *TransactionPairsMarketRefresh) Run(ctx context.Context) error {
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("transaction pairs market refresh lease check failed", zap.Error(err))
+		return err
+	}
+	if err := j.transactionPairs.RefreshMarketData(ctx, j.tradeStats); err != nil {
+		j.tl.Warn("refresh transaction pairs market data failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+
+	"go.uber.org/zap"
+)
+
+// WalletDailySnapshot 日终任务：为每个钱包生成上一个 UTC 自然日的快照（t_smart_wallet_daily）
+//
+// 任务按较短间隔运行，只有当上一个自然日尚未生成快照时才会执行，重复执行是幂等的。
+// 是否已生成按 snapshot_at 判断，Backfill 写入的行不算。
+// 未实现盈亏、余额与持仓数取自快照时刻的 t_smart_wallet / t_smart_holding，
+// 因此应尽量在跨日后尽快执行；停机错过的日期无法还原这些字段，只补交易聚合。
+type WalletDailySnapshot struct {
+	cfg  config.Config
+	repo repository.Repository
+	tl   *zap.Logger
+}
+
+// 停机后补交易聚合最多回看的天数，更早的用 Backfill 手动补
+const walletDailyCatchUpDays = 7
+
+// NewWalletDailySnapshot 创建钱包每日快照任务
+func NewWalletDailySnapshot(cfg config.Config, repo repository.Repository, logger *zap.Logger) *WalletDailySnapshot {
+	return &WalletDailySnapshot{
+		cfg:  cfg,
+		repo: repo,
+		tl:   logger,
+	}
+}
+
+// Run 生成上一个 UTC 自然日的快照（已生成则跳过），
+// 上次快照与昨天之间错过的日期先从 t_smart_transaction 补交易聚合
+func (j *WalletDailySnapshot) Run(ctx context.Context) error {
+	walletDailyDAO := j.repo.GetDAOManager().WalletDailyDAO
+
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	latest, err := walletDailyDAO.GetLatestSnapshotDay(ctx)
+	if err != nil {
+		j.tl.Warn("get latest wallet daily snapshot failed", zap.Error(err))
+		return err
+	}
+	if !latest.Before(yesterday) {
+		return nil
+	}
+
+	if !latest.IsZero() {
+		from := latest.AddDate(0, 0, 1)
+		if earliest := yesterday.AddDate(0, 0, -walletDailyCatchUpDays); from.Before(earliest) {
+			j.tl.Warn("wallet daily snapshot gap exceeds catch-up window, run backfill for older days",
+				zap.String("latest", latest.Format(time.DateOnly)),
+				zap.String("from", earliest.Format(time.DateOnly)))
+			from = earliest
+		}
+		for day := from; day.Before(yesterday); day = day.AddDate(0, 0, 1) {
+			if err := CheckLease(ctx); err != nil {
+				j.tl.Warn("wallet daily snapshot lease check failed", zap.Error(err))
+				return err
+			}
+			rows, err := walletDailyDAO.RebuildDay(ctx, day)
+			if err != nil {
+				j.tl.Error("wallet daily catch-up failed", zap.String("day", day.Format(time.DateOnly)), zap.Error(err))
+				return err
+			}
+			j.tl.Info("wallet daily catch-up", zap.String("day", day.Format(time.DateOnly)), zap.Int64("rows", rows))
+		}
+	}
+
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("wallet daily snapshot lease check failed", zap.Error(err))
+		return err
+	}
+	startTime := time.Now()
+	rows, err := walletDailyDAO.SnapshotDay(ctx, yesterday)
+	if err != nil {
+		j.tl.Error("wallet daily snapshot failed", zap.String("day", yesterday.Format(time.DateOnly)), zap.Error(err))
+		return err
+	}
+	j.tl.Info("wallet daily snapshot completed",
+		zap.String("day", yesterday.Format(time.DateOnly)),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
+
+// Backfill 从 t_smart_transaction 重建 [from, to] 区间内每日的已实现盈亏与买卖次数
+//
+// 历史的未实现盈亏、余额与持仓数无法还原：新行保持为 0 且 snapshot_at 为 0，已有行保留原值。
+// 受交易清理任务影响，只能回填 t_smart_transaction 仍保留的区间。
+func (j *WalletDailySnapshot) Backfill(ctx context.Context, from, to time.Time) error {
+	from = from.UTC().Truncate(24 * time.Hour)
+	to = to.UTC().Truncate(24 * time.Hour)
+	if to.Before(from) {
+		return fmt.Errorf("invalid backfill range: %s > %s", from.Format(time.DateOnly), to.Format(time.DateOnly))
+	}
+
+	walletDailyDAO := j.repo.GetDAOManager().WalletDailyDAO
+	var total int64
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		rows, err := walletDailyDAO.RebuildDay(ctx, day)
+		if err != nil {
+			j.tl.Error("backfill wallet daily failed", zap.String("day", day.Format(time.DateOnly)), zap.Error(err))
+			return err
+		}
+		total += rows
+		j.tl.Info("backfill wallet daily", zap.String("day", day.Format(time.DateOnly)), zap.Int64("rows", rows))
+	}
+	j.tl.Info("backfill wallet daily completed",
+		zap.String("from", from.Format(time.DateOnly)),
+		zap.String("to", to.Format(time.DateOnly)),
+		zap.Int64("rows", total))
+	return nil
+}
@@ -0,0 +1,90 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+
+	"go.uber.org/zap"
+)
+
+// WalletEquitySnapshot 记录钱包资产净值（原生币余额 + 持仓价值）曲线，并维护 30 天最大回撤
+//
+//   - 最近 24 小时有交易的钱包：每个整点一条
+//   - 其他钱包：每个 UTC 自然日一条
+//
+// 任务间隔小于一小时，同一时间点已存在的快照直接跳过，因此重复执行是幂等的；
+// 只有写入了新的时间点才重算最大回撤并清理过期快照。
+type WalletEquitySnapshot struct {
+	cfg  config.Config
+	repo repository.Repository
+	tl   *zap.Logger
+}
+
+const (
+	walletEquityActiveWindow  = 24 * time.Hour
+	walletEquityDrawdownRange = 30 * 24 * time.Hour
+	walletEquityRetention     = 90 * 24 * time.Hour
+)
+
+// NewWalletEquitySnapshot 创建钱包资产净值快照任务
+func NewWalletEquitySnapshot(cfg config.Config, repo repository.Repository, logger *zap.Logger) *WalletEquitySnapshot {
+	return &WalletEquitySnapshot{
+		cfg:  cfg,
+		repo: repo,
+		tl:   logger,
+	}
+}
+
+// Run 执行快照
+func (j *WalletEquitySnapshot) Run(ctx context.Context) error {
+	startTime := time.Now()
+	equityDAO := j.repo.GetDAOManager().WalletEquityDAO
+
+	now := time.Now().UTC()
+	hourTs := now.Truncate(time.Hour).UnixMilli()
+	dayTs := now.Truncate(24 * time.Hour).UnixMilli()
+	activeSince := now.Add(-walletEquityActiveWindow).UnixMilli()
+
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("wallet equity snapshot lease check failed", zap.Error(err))
+		return err
+	}
+	activeRows, err := equityDAO.SnapshotActive(ctx, hourTs, activeSince)
+	if err != nil {
+		j.tl.Error("snapshot active wallet equity failed", zap.Error(err))
+		return err
+	}
+	inactiveRows, err := equityDAO.SnapshotInactive(ctx, dayTs, activeSince)
+	if err != nil {
+		j.tl.Error("snapshot inactive wallet equity failed", zap.Error(err))
+		return err
+	}
+	if activeRows == 0 && inactiveRows == 0 {
+		return nil
+	}
+
+	// 快照耗时较长，更新回撤与清理前再确认一次租约
+	if err := CheckLease(ctx); err != nil {
+		j.tl.Warn("wallet equity snapshot lease check failed", zap.Error(err))
+		return err
+	}
+	drawdownRows, err := equityDAO.UpdateMaxDrawdown(ctx, now.Add(-walletEquityDrawdownRange).UnixMilli())
+	if err != nil {
+		j.tl.Warn("update wallet max drawdown failed", zap.Error(err))
+	}
+	deleted, err := equityDAO.DeleteBefore(ctx, now.Add(-walletEquityRetention).UnixMilli())
+	if err != nil {
+		j.tl.Warn("delete expired wallet equity failed", zap.Error(err))
+	}
+
+	j.tl.Info("wallet equity snapshot completed",
+		zap.Int64("active_rows", activeRows),
+		zap.Int64("inactive_rows", inactiveRows),
+		zap.Int64("drawdown_updated", drawdownRows),
+		zap.Int64("deleted", deleted),
+		zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
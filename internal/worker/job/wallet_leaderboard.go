@@ -0,0 +1,329 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// WalletLeaderboard 定时将钱包榜单物化到 Redis，前端直接读取，不再实时排序 t_smart_wallet
+//
+// Redis 结构设计：
+//
+//  1. 榜单
+//     key:  smart_money:leaderboard:<chain_id>:<period>:<metric>
+//     type: zset，member=wallet_address，score=指标值
+//
+//  2. 榜单成员 payload
+//     key:  smart_money:leaderboard:<chain_id>:<period>:<metric>:payload
+//     type: hash，field=wallet_address，value=model.WalletLeaderboardEntry JSON
+//
+//  3. 榜单更新时间
+//     key:   smart_money:leaderboard:meta
+//     type:  hash，field=<chain_id>:<period>:<metric>，value=毫秒时间戳
+//
+// period: 1d / 7d / 30d；metric: pnl / pnl_pct / win_rate / score（综合评分）
+//
+// 每轮先写入临时 key，再在事务中 RENAME 覆盖正式 key，读取方不会看到写了一半的榜单。
+// 名次变化基于上一轮榜单计算。
+type WalletLeaderboard struct {
+	cfg  config.Config
+	repo repository.Repository
+	tl   *zap.Logger
+}
+
+// walletLeaderboardCandidate 单个周期下的候选钱包
+type walletLeaderboardCandidate struct {
+	wallet  *model.WalletSummary
+	pnl     float64
+	pnlPct  float64
+	winRate float64
+	txns    int
+	score   float64
+}
+
+const (
+	walletLeaderboardSize      = 500  // 每个榜单保留的钱包数量
+	walletLeaderboardPageSize  = 2000 // 分页读取钱包
+	walletLeaderboardMinTxns   = 5    // 胜率榜、综合榜的最少交易笔数，避免 1 笔交易 100% 胜率上榜
+	walletLeaderboardMetaKey   = "smart_money:leaderboard:meta"
+	walletLeaderboardKeyPrefix = "smart_money:leaderboard"
+)
+
+// 综合评分权重（各指标在候选集合中的百分位加权）
+const (
+	walletScoreWeightPNL     = 0.4
+	walletScoreWeightPNLPct  = 0.3
+	walletScoreWeightWinRate = 0.3
+)
+
+// NewWalletLeaderboard 创建钱包榜单任务
+func NewWalletLeaderboard(cfg config.Config, repo repository.Repository, logger *zap.Logger) *WalletLeaderboard {
+	return &WalletLeaderboard{
+		cfg:  cfg,
+		repo: repo,
+		tl:   logger,
+	}
+}
+
+// Run 执行榜单物化
+func (j *WalletLeaderboard) Run(ctx context.Context) error {
+	startTime := time.Now()
+
+	wallets, err := j.loadWallets(ctx)
+	if err != nil {
+		return err
+	}
+
+	byChain := make(map[uint64][]*model.WalletSummary)
+	for _, w := range wallets {
+		byChain[w.ChainID] = append(byChain[w.ChainID], w)
+	}
+
+	published := 0
+	for chainID, chainWallets := range byChain {
+		for _, period := range model.LeaderboardPeriods {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := CheckLease(ctx); err != nil {
+				j.tl.Warn("wallet leaderboard lease check failed", zap.Error(err))
+				return err
+			}
+			candidates := j.buildCandidates(chainWallets, period)
+			for _, metric := range model.LeaderboardMetrics {
+				if err := j.publish(ctx, chainID, period, metric, candidates); err != nil {
+					j.tl.Warn("publish wallet leaderboard failed",
+						zap.Uint64("chain_id", chainID),
+						zap.String("period", period),
+						zap.String("metric", metric),
+						zap.Error(err))
+					continue
+				}
+				published++
+			}
+		}
+	}
+
+	j.tl.Info("wallet leaderboard materialized",
+		zap.Int("wallets", len(wallets)),
+		zap.Int("chains", len(byChain)),
+		zap.Int("leaderboards", published),
+		zap.Duration("elapsed", time.Since(startTime)))
+	return nil
+}
+
+// loadWallets 按 id 分页读取活跃钱包
+func (j *WalletLeaderboard) loadWallets(ctx context.Context) ([]*model.WalletSummary, error) {
+	var all []*model.WalletSummary
+	var lastID int64
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var page []*model.WalletSummary
+		err := j.repo.GetDB().WithContext(ctx).
+			Select("id", "wallet_address", "chain_id", "avatar", "twitter_name", "twitter_username", "tags",
+				"pnl_1d", "pnl_7d", "pnl_30d",
+				"pnl_percentage_1d", "pnl_percentage_7d", "pnl_percentage_30d",
+				"win_rate_1d", "win_rate_7d", "win_rate_30d",
+				"buy_num_1d", "sell_num_1d", "buy_num_7d", "sell_num_7d", "buy_num_30d", "sell_num_30d").
+			Where("is_active = ? AND id > ?", true, lastID).
+			Order("id ASC").
+			Limit(walletLeaderboardPageSize).
+			Find(&page).Error
+		if err != nil {
+			j.tl.Warn("load wallets for leaderboard failed", zap.Error(err))
+			return nil, err
+		}
+		if len(page) == 0 {
+			break
+		}
+		all = append(all, page...)
+		lastID = page[len(page)-1].ID
+		if len(page) < walletLeaderboardPageSize {
+			break
+		}
+	}
+	return all, nil
+}
+
+// buildCandidates 取出周期指标并计算综合评分
+func (j *WalletLeaderboard) buildCandidates(wallets []*model.WalletSummary, period string) []*walletLeaderboardCandidate {
+	candidates := make([]*walletLeaderboardCandidate, 0, len(wallets))
+	for _, w := range wallets {
+		c := &walletLeaderboardCandidate{wallet: w}
+		switch period {
+		case "1d":
+			c.pnl, c.pnlPct, c.winRate = w.PNL1d.InexactFloat64(), w.PNLPercentage1d.InexactFloat64(), w.WinRate1d.InexactFloat64()
+			c.txns = w.BuyNum1d + w.SellNum1d
+		case "7d":
+			c.pnl, c.pnlPct, c.winRate = w.PNL7d.InexactFloat64(), w.PNLPercentage7d.InexactFloat64(), w.WinRate7d.InexactFloat64()
+			c.txns = w.BuyNum7d + w.SellNum7d
+		default:
+			c.pnl, c.pnlPct, c.winRate = w.PNL30d.InexactFloat64(), w.PNLPercentage30d.InexactFloat64(), w.WinRate30d.InexactFloat64()
+			c.txns = w.BuyNum30d + w.SellNum30d
+		}
+		// 周期内没有交易的钱包不参与排名
+		if c.txns == 0 {
+			continue
+		}
+		candidates = append(candidates, c)
+	}
+
+	// 综合评分：各指标百分位加权，结果范围 0-100
+	pnlRank := percentileRanks(candidates, func(c *walletLeaderboardCandidate) float64 { return c.pnl })
+	pnlPctRank := percentileRanks(candidates, func(c *walletLeaderboardCandidate) float64 { return c.pnlPct })
+	winRateRank := percentileRanks(candidates, func(c *walletLeaderboardCandidate) float64 { return c.winRate })
+	for i, c := range candidates {
+		c.score = 100 * (walletScoreWeightPNL*pnlRank[i] +
+			walletScoreWeightPNLPct*pnlPctRank[i] +
+			walletScoreWeightWinRate*winRateRank[i])
+	}
+	return candidates
+}
+
+// publish 计算单个榜单并原子替换 Redis 中的旧榜单
+func (j *WalletLeaderboard) publish(
+	ctx context.Context,
+	chainID uint64,
+	period, metric string,
+	candidates []*walletLeaderboardCandidate,
+) error {
+	rdb := j.repo.GetMetricsRDB()
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
+	}
+
+	value := func(c *walletLeaderboardCandidate) float64 {
+		switch metric {
+		case model.LEADERBOARD_METRIC_PNL:
+			return c.pnl
+		case model.LEADERBOARD_METRIC_PNL_PCT:
+			return c.pnlPct
+		case model.LEADERBOARD_METRIC_WIN_RATE:
+			return c.winRate
+		default:
+			return c.score
+		}
+	}
+
+	ranked := make([]*walletLeaderboardCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if (metric == model.LEADERBOARD_METRIC_WIN_RATE || metric == model.LEADERBOARD_METRIC_COMPOSITE) &&
+			c.txns < walletLeaderboardMinTxns {
+			continue
+		}
+		ranked = append(ranked, c)
+	}
+	sort.SliceStable(ranked, func(a, b int) bool { return value(ranked[a]) > value(ranked[b]) })
+	if len(ranked) > walletLeaderboardSize {
+		ranked = ranked[:walletLeaderboardSize]
+	}
+
+	key := fmt.Sprintf("%s:%d:%s:%s", walletLeaderboardKeyPrefix, chainID, period, metric)
+	payloadKey := key + ":payload"
+
+	// 上一轮名次
+	prevMembers, err := rdb.ZRevRange(ctx, key, 0, -1).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	prevRanks := make(map[string]int, len(prevMembers))
+	for i, m := range prevMembers {
+		prevRanks[m] = i + 1
+	}
+
+	now := time.Now().UnixMilli()
+	zs := make([]redis.Z, 0, len(ranked))
+	payloads := make(map[string]interface{}, len(ranked))
+	for i, c := range ranked {
+		rank := i + 1
+		entry := model.WalletLeaderboardEntry{
+			Rank:      rank,
+			PNL:       c.pnl,
+			PNLPct:    c.pnlPct,
+			WinRate:   c.winRate,
+			Score:     c.score,
+			Txns:      c.txns,
+			Avatar:    c.wallet.Avatar,
+			Twitter:   c.wallet.TwitterUsername,
+			Name:      c.wallet.TwitterName,
+			Tags:      c.wallet.Tags,
+			UpdatedAt: now,
+		}
+		if prev, ok := prevRanks[c.wallet.WalletAddress]; ok {
+			entry.PrevRank = prev
+			entry.Delta = prev - rank
+		}
+		data, err := sonic.Marshal(entry)
+		if err != nil {
+			continue
+		}
+		zs = append(zs, redis.Z{Score: value(c), Member: c.wallet.WalletAddress})
+		payloads[c.wallet.WalletAddress] = string(data)
+	}
+
+	tmpKey := fmt.Sprintf("%s:tmp:%d", key, now)
+	tmpPayloadKey := fmt.Sprintf("%s:tmp:%d", payloadKey, now)
+
+	// 先写临时 key
+	_, err = rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(zs) > 0 {
+			pipe.ZAdd(ctx, tmpKey, zs...)
+			pipe.HSet(ctx, tmpPayloadKey, payloads)
+			pipe.Expire(ctx, tmpKey, time.Hour)
+			pipe.Expire(ctx, tmpPayloadKey, time.Hour)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 事务内替换正式 key
+	_, err = rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if len(zs) == 0 {
+			pipe.Del(ctx, key, payloadKey)
+		} else {
+			pipe.Rename(ctx, tmpKey, key)
+			pipe.Rename(ctx, tmpPayloadKey, payloadKey)
+			pipe.Persist(ctx, key)
+			pipe.Persist(ctx, payloadKey)
+		}
+		pipe.HSet(ctx, walletLeaderboardMetaKey, fmt.Sprintf("%d:%s:%s", chainID, period, metric), now)
+		return nil
+	})
+	return err
+}
+
+// percentileRanks 计算每个候选在指标上的百分位（0-1，值越大排名越靠前）
+func percentileRanks(candidates []*walletLeaderboardCandidate, value func(*walletLeaderboardCandidate) float64) []float64 {
+	n := len(candidates)
+	ranks := make([]float64, n)
+	if n <= 1 {
+		for i := range ranks {
+			ranks[i] = 1
+		}
+		return ranks
+	}
+
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return value(candidates[idx[a]]) < value(candidates[idx[b]]) })
+	for pos, i := range idx {
+		ranks[i] = float64(pos) / float64(n-1)
+	}
+	return ranks
+}
@@ -0,0 +1,32 @@
+package model
+
+const (
+	JOB_RUN_STATUS_SUCCESS = "success"
+	JOB_RUN_STATUS_FAILED  = "failed"
+	JOB_RUN_STATUS_TIMEOUT = "timeout" // 超过最大执行时长被取消
+
+	JOB_TRIGGER_SCHEDULE = "schedule" // 定时 / cron 触发
+	JOB_TRIGGER_MANUAL   = "manual"   // 管理接口手动触发
+	JOB_TRIGGER_STARTUP  = "startup"  // 启动时执行的一次性作业
+)
+
+// JobHistory 定时作业执行记录
+//
+// 只记录实际执行的作业，因重叠或租约被其他实例持有而跳过的不落库，只计入监控指标。
+type JobHistory struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	JobName    string `gorm:"column:job_name;type:varchar(128);not null" json:"job_name"`
+	Instance   string `gorm:"column:instance;type:varchar(256);not null" json:"instance"`
+	Trigger    string `gorm:"column:trigger;type:varchar(20);not null" json:"trigger"`
+	Status     string `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Attempts   int    `gorm:"column:attempts;not null" json:"attempts"`       // 含重试的执行次数
+	LeaseToken int64  `gorm:"column:lease_token;not null" json:"lease_token"` // singleton 作业的租约 fencing token，其余为 0
+	StartedAt  int64  `gorm:"column:started_at;not null" json:"started_at"`   // 毫秒时间戳
+	FinishedAt int64  `gorm:"column:finished_at;not null" json:"finished_at"` // 毫秒时间戳
+	DurationMs int64  `gorm:"column:duration_ms;not null" json:"duration_ms"`
+	Error      string `gorm:"column:error;type:text" json:"error,omitempty"`
+}
+
+func (j *JobHistory) TableName() string {
+	return "dex_query_v1.t_smart_job_history"
+}
@@ -0,0 +1,25 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// SmartFlowBucket token 聪明钱资金流时间桶（1m/5m/1h）
+// Redis 中实时维护，5m/1h 桶定时压缩写入 SelectDB 保存长期历史
+type SmartFlowBucket struct {
+	ChainID      uint64          `gorm:"column:chain_id" json:"chain_id"`
+	TokenAddress string          `gorm:"column:token_address" json:"token_address"`
+	Resolution   string          `gorm:"column:resolution" json:"resolution"`   // 1m / 5m / 1h
+	BucketTime   int64           `gorm:"column:bucket_time" json:"bucket_time"` // 桶起始时间（毫秒）
+	BuyValue     decimal.Decimal `gorm:"column:buy_value" json:"buy_value"`     // 买入金额USD
+	SellValue    decimal.Decimal `gorm:"column:sell_value" json:"sell_value"`   // 卖出金额USD
+	NetFlow      decimal.Decimal `gorm:"column:net_flow" json:"net_flow"`       // 净流入USD（买入-卖出）
+	BuyTxns      int64           `gorm:"column:buy_txns" json:"buy_txns"`       // 买入笔数
+	SellTxns     int64           `gorm:"column:sell_txns" json:"sell_txns"`     // 卖出笔数
+	Buyers       int64           `gorm:"column:buyers" json:"buyers"`           // 去重买入钱包数
+	Sellers      int64           `gorm:"column:sellers" json:"sellers"`         // 去重卖出钱包数
+	UpdatedAt    int64           `gorm:"column:updated_at" json:"updated_at"`   // 毫秒时间戳
+}
+
+// TableName SelectDB 表名
+func (SmartFlowBucket) TableName() string {
+	return "smart_money_flow"
+}
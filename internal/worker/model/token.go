@@ -63,3 +63,18 @@ type SmTokenRet struct {
 	Creater *string          `gorm:"column:creater;comment:创建者" json:"creater"`                // 创建者
 	Logo    string           `gorm:"column:logo;comment:token logo" json:"logo"`               // token logo
 }
+
+// TokenSmartMoneyInfo token 维度的聪明钱聚合信息，写入 web3_tokens.smart_money_info
+type TokenSmartMoneyInfo struct {
+	HolderCount          int64           `json:"holder_count"`           // 当前持仓的聪明钱数量
+	HoldingValueUSD      decimal.Decimal `json:"holding_value_usd"`      // 聪明钱持仓总价值USD
+	HoldingAmount        decimal.Decimal `json:"holding_amount"`         // 聪明钱持仓总数量
+	HoldingSupplyPercent decimal.Decimal `json:"holding_supply_percent"` // 聪明钱持仓占总供应量百分比（0-100）
+	AvgEntryMarketCap    decimal.Decimal `json:"avg_entry_marketcap"`    // 聪明钱平均买入市值USD
+	BuyValue24h          decimal.Decimal `json:"buy_value_24h"`          // 24h 聪明钱买入金额USD
+	SellValue24h         decimal.Decimal `json:"sell_value_24h"`         // 24h 聪明钱卖出金额USD
+	NetFlow24h           decimal.Decimal `json:"net_flow_24h"`           // 24h 聪明钱净流入USD（买入-卖出）
+	FirstBuyTime         int64           `json:"first_buy_time"`         // 聪明钱首次买入时间（毫秒）
+	LastBuyTime          int64           `json:"last_buy_time"`          // 聪明钱最近买入时间（毫秒）
+	UpdatedAt            int64           `json:"updated_at"`             // 毫秒时间戳
+}
@@ -100,6 +100,9 @@ type WalletSummary struct {
 	TotalCost1d         decimal.Decimal `gorm:"column:total_cost_1d;type:decimal(50,20);not null;default:0" json:"total_cost_1d"`
 	AvgRealizedProfit1d decimal.Decimal `gorm:"column:avg_realized_profit_1d;type:decimal(50,20);not null;default:0" json:"avg_realized_profit_1d"`
 
+	// 资产净值最大回撤 - 30天（由 WalletEquitySnapshot 任务维护，交易写入不覆盖）
+	MaxDrawdown30d decimal.Decimal `gorm:"column:max_drawdown_30d;type:decimal(50,20);not null;default:0" json:"max_drawdown_30d"`
+
 	// 收益分布数据 - 30天
 	DistributionGt500_30d             int             `gorm:"column:distribution_gt500_30d" json:"distribution_gt500_30d"`
 	Distribution200to500_30d          int             `gorm:"column:distribution_200to500_30d" json:"distribution_200to500_30d"`
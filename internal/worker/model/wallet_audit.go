@@ -0,0 +1,46 @@
+package model
+
+import "encoding/json"
+
+// 钱包人工变更操作类型
+const (
+	WALLET_AUDIT_ACTION_TRACK   = "track"   // 新增跟踪钱包
+	WALLET_AUDIT_ACTION_TAGS    = "tags"    // 增删标签
+	WALLET_AUDIT_ACTION_PROFILE = "profile" // 修改 twitter / wallet_type
+)
+
+// WalletAudit 钱包人工变更审计记录
+//
+// before / after 只记录人工可修改的字段（tags、twitter_name、twitter_username、wallet_type），JSON 格式。
+type WalletAudit struct {
+	ID            int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChainID       uint64          `gorm:"column:chain_id;not null" json:"chain_id"`
+	WalletAddress string          `gorm:"column:wallet_address;type:varchar(512);not null" json:"wallet_address"`
+	Action        string          `gorm:"column:action;type:varchar(20);not null" json:"action"`
+	Operator      string          `gorm:"column:operator;type:varchar(128);not null" json:"operator"`
+	Before        json.RawMessage `gorm:"column:before;type:jsonb" json:"before"` // 新增跟踪时为空
+	After         json.RawMessage `gorm:"column:after;type:jsonb" json:"after"`
+	CreatedAt     int64           `gorm:"column:created_at;not null" json:"created_at"` // 毫秒时间戳
+}
+
+func (w *WalletAudit) TableName() string {
+	return "dex_query_v1.t_smart_wallet_audit"
+}
+
+// WalletManualFields 人工可维护的钱包字段，用于审计快照
+type WalletManualFields struct {
+	Tags            []string `json:"tags"`
+	TwitterName     string   `json:"twitter_name"`
+	TwitterUsername string   `json:"twitter_username"`
+	WalletType      int      `json:"wallet_type"`
+}
+
+// ManualFields 提取钱包的人工可维护字段
+func (w *WalletSummary) ManualFields() WalletManualFields {
+	return WalletManualFields{
+		Tags:            append([]string{}, w.Tags...),
+		TwitterName:     w.TwitterName,
+		TwitterUsername: w.TwitterUsername,
+		WalletType:      w.WalletType,
+	}
+}
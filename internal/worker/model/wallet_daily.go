@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WalletDaily 钱包每日快照，每个钱包每个 UTC 自然日一行
+//
+// realized_pnl / buy_num / sell_num 由当日 t_smart_transaction 聚合得出，可通过回填重建；
+// unrealized_pnl / balance / balance_usd / open_positions 为快照时刻的持仓状态，回填时无法还原，
+// 只有回填的行 snapshot_at 为 0。
+type WalletDaily struct {
+	ID            int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChainID       uint64          `gorm:"column:chain_id;not null" json:"chain_id"`
+	WalletAddress string          `gorm:"column:wallet_address;type:varchar(100);not null" json:"wallet_address"`
+	Day           time.Time       `gorm:"column:day;type:date;not null" json:"day"` // UTC 自然日
+	RealizedPNL   decimal.Decimal `gorm:"column:realized_pnl;type:decimal(50,20);not null;default:0" json:"realized_pnl"`
+	UnrealizedPNL decimal.Decimal `gorm:"column:unrealized_pnl;type:decimal(50,20);not null;default:0" json:"unrealized_pnl"`
+	Balance       decimal.Decimal `gorm:"column:balance;type:decimal(50,20);not null;default:0" json:"balance"`
+	BalanceUSD    decimal.Decimal `gorm:"column:balance_usd;type:decimal(50,20);not null;default:0" json:"balance_usd"`
+	BuyNum        int             `gorm:"column:buy_num;not null;default:0" json:"buy_num"`
+	SellNum       int             `gorm:"column:sell_num;not null;default:0" json:"sell_num"`
+	OpenPositions int             `gorm:"column:open_positions;not null;default:0" json:"open_positions"`
+	SnapshotAt    int64           `gorm:"column:snapshot_at;not null;default:0" json:"snapshot_at"` // 快照时刻（毫秒），0 表示快照时刻字段未填充
+	CreatedAt     int64           `gorm:"column:created_at;not null" json:"created_at"`             // 毫秒时间戳
+	UpdatedAt     int64           `gorm:"column:updated_at;not null" json:"updated_at"`             // 毫秒时间戳
+}
+
+func (w *WalletDaily) TableName() string {
+	return "dex_query_v1.t_smart_wallet_daily"
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// WalletEquity 钱包资产净值快照
+//
+// equity_usd = balance_usd（原生币余额）+ holding_value_usd（未清仓持仓价值）。
+// 活跃钱包按小时记录，其他钱包按 UTC 自然日记录，ts 为对应整点/零点的毫秒时间戳。
+type WalletEquity struct {
+	ID              int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	ChainID         uint64          `gorm:"column:chain_id;not null" json:"chain_id"`
+	WalletAddress   string          `gorm:"column:wallet_address;type:varchar(100);not null" json:"wallet_address"`
+	Ts              int64           `gorm:"column:ts;not null" json:"ts"` // 毫秒时间戳
+	BalanceUSD      decimal.Decimal `gorm:"column:balance_usd;type:decimal(50,20);not null;default:0" json:"balance_usd"`
+	HoldingValueUSD decimal.Decimal `gorm:"column:holding_value_usd;type:decimal(50,20);not null;default:0" json:"holding_value_usd"`
+	EquityUSD       decimal.Decimal `gorm:"column:equity_usd;type:decimal(50,20);not null;default:0" json:"equity_usd"`
+	CreatedAt       int64           `gorm:"column:created_at;not null" json:"created_at"` // 毫秒时间戳
+}
+
+func (w *WalletEquity) TableName() string {
+	return "dex_query_v1.t_smart_wallet_equity"
+}
+
+// EquityCurve 按时间升序排列的资产净值曲线
+type EquityCurve []*WalletEquity
+
+// MaxDrawdown 曲线的最大回撤：max((峰值 - 当前值) / 峰值)，范围 0-1
+func (c EquityCurve) MaxDrawdown() decimal.Decimal {
+	peak := decimal.Zero
+	maxDrawdown := decimal.Zero
+	for _, p := range c {
+		if p.EquityUSD.GreaterThan(peak) {
+			peak = p.EquityUSD
+			continue
+		}
+		if !peak.IsPositive() {
+			continue
+		}
+		drawdown := peak.Sub(p.EquityUSD).Div(peak)
+		if drawdown.GreaterThan(maxDrawdown) {
+			maxDrawdown = drawdown
+		}
+	}
+	return maxDrawdown
+}
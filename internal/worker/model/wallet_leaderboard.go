@@ -0,0 +1,35 @@
+package model
+
+// 钱包榜单周期与指标
+const (
+	LEADERBOARD_METRIC_PNL       = "pnl"
+	LEADERBOARD_METRIC_PNL_PCT   = "pnl_pct"
+	LEADERBOARD_METRIC_WIN_RATE  = "win_rate"
+	LEADERBOARD_METRIC_COMPOSITE = "score"
+)
+
+var LeaderboardPeriods = []string{"1d", "7d", "30d"}
+
+var LeaderboardMetrics = []string{
+	LEADERBOARD_METRIC_PNL,
+	LEADERBOARD_METRIC_PNL_PCT,
+	LEADERBOARD_METRIC_WIN_RATE,
+	LEADERBOARD_METRIC_COMPOSITE,
+}
+
+// WalletLeaderboardEntry 榜单成员的紧凑 payload（存放在榜单 payload hash 中，field=wallet_address）
+type WalletLeaderboardEntry struct {
+	Rank      int      `json:"r"`            // 当前名次，从 1 开始
+	PrevRank  int      `json:"pr"`           // 上一轮名次，0 表示新上榜
+	Delta     int      `json:"d"`            // 名次变化，正数表示上升
+	PNL       float64  `json:"pnl"`          // 周期盈亏（USD）
+	PNLPct    float64  `json:"pnlp"`         // 周期盈亏百分比
+	WinRate   float64  `json:"wr"`           // 周期胜率
+	Score     float64  `json:"sc"`           // 综合评分（0-100）
+	Txns      int      `json:"tx"`           // 周期交易笔数
+	Avatar    string   `json:"a,omitempty"`  // 头像
+	Twitter   string   `json:"tw,omitempty"` // twitter 用户名
+	Name      string   `json:"n,omitempty"`  // twitter 名称
+	Tags      []string `json:"tg,omitempty"` // 钱包标签
+	UpdatedAt int64    `json:"t"`            // 毫秒时间戳
+}
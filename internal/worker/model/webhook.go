@@ -0,0 +1,91 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	WEBHOOK_DELIVERY_STATUS_PARKED    = "parked"    // 重试耗尽，等待人工处理
+	WEBHOOK_DELIVERY_STATUS_DELIVERED = "delivered" // 人工重投成功
+)
+
+// WebhookSubscription 外部 webhook 订阅
+//
+// 过滤条件为空表示不过滤该维度，多个维度之间为 AND 关系。
+type WebhookSubscription struct {
+	ID        int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string          `gorm:"column:name;type:varchar(128);not null" json:"name"`
+	URL       string          `gorm:"column:url;type:varchar(1024);not null" json:"url"` // 仅支持 https
+	Secret    string          `gorm:"column:secret;type:varchar(256);not null" json:"secret,omitempty"`
+	ChainIDs  pq.Int64Array   `gorm:"column:chain_ids;type:bigint[]" json:"chain_ids"`
+	Wallets   pq.StringArray  `gorm:"column:wallets;type:varchar(512)[]" json:"wallets"`
+	Tokens    pq.StringArray  `gorm:"column:tokens;type:varchar(512)[]" json:"tokens"`
+	TxTypes   pq.StringArray  `gorm:"column:tx_types;type:varchar(20)[]" json:"tx_types"` // build / buy / sell / clean
+	MinValue  decimal.Decimal `gorm:"column:min_value;type:decimal(50,20);not null;default:0" json:"min_value"`
+	Enabled   bool            `gorm:"column:enabled;type:boolean;not null;default:true" json:"enabled"`
+	CreatedAt int64           `gorm:"column:created_at;not null" json:"created_at"` // 毫秒时间戳
+	UpdatedAt int64           `gorm:"column:updated_at;not null" json:"updated_at"` // 毫秒时间戳
+}
+
+func (w *WebhookSubscription) TableName() string {
+	return "dex_query_v1.t_smart_webhook_subscription"
+}
+
+// Match 判断一条聪明钱交易是否命中订阅条件
+func (w *WebhookSubscription) Match(event *SmartTxEventDetails) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.ChainIDs) > 0 && !containsInt64(w.ChainIDs, int64(event.ChainID)) {
+		return false
+	}
+	if len(w.Wallets) > 0 && !containsString(w.Wallets, event.WalletAddress) {
+		return false
+	}
+	if len(w.Tokens) > 0 && !containsString(w.Tokens, event.TokenAddress) {
+		return false
+	}
+	if len(w.TxTypes) > 0 && !containsString(w.TxTypes, event.TransactionType) {
+		return false
+	}
+	if w.MinValue.GreaterThan(decimal.Zero) && event.Value.LessThan(w.MinValue) {
+		return false
+	}
+	return true
+}
+
+// WebhookDelivery 投递失败记录
+type WebhookDelivery struct {
+	ID             int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	SubscriptionID int64  `gorm:"column:subscription_id;not null;index" json:"subscription_id"`
+	EventID        string `gorm:"column:event_id;type:varchar(64);not null" json:"event_id"`
+	Payload        string `gorm:"column:payload;type:text;not null" json:"payload"`
+	Attempts       int    `gorm:"column:attempts;not null;default:0" json:"attempts"`
+	LastError      string `gorm:"column:last_error;type:text" json:"last_error"`
+	Status         string `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	CreatedAt      int64  `gorm:"column:created_at;not null" json:"created_at"` // 毫秒时间戳
+	UpdatedAt      int64  `gorm:"column:updated_at;not null" json:"updated_at"` // 毫秒时间戳
+}
+
+func (w *WebhookDelivery) TableName() string {
+	return "dex_query_v1.t_smart_webhook_delivery"
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(list []int64, v int64) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+	"web3-smart/internal/worker/config"
+
+	"github.com/bytedance/sonic"
+)
+
+// CheckFunc 单项健康检查，返回 nil 表示正常
+type CheckFunc func(ctx context.Context) error
+
+// CheckResult 单项检查结果
+type CheckResult struct {
+	Status  string `json:"status"` // ok / fail
+	Error   string `json:"error,omitempty"`
+	Latency int64  `json:"latency_ms"`
+}
+
+// HealthReport 检查报告
+type HealthReport struct {
+	Status    string                 `json:"status"` // ok / fail
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt int64                  `json:"checked_at"` // 毫秒时间戳
+}
+
+type namedCheck struct {
+	name string
+	fn   CheckFunc
+}
+
+// HealthChecker 依赖健康检查
+//
+//	/healthz 执行 liveness 检查（各外部依赖的连通性）
+//	/readyz  执行 liveness + readiness 检查（消费积压、写入队列、消费停滞等）
+//
+// 每项检查单独超时、并发执行；结果按 endpoint 缓存，避免探针频繁请求打满依赖。
+type HealthChecker struct {
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	liveness  []namedCheck
+	readiness []namedCheck
+	cache     map[string]*HealthReport
+}
+
+func NewHealthChecker(cfg config.MonitorConfig) *HealthChecker {
+	timeout := time.Duration(cfg.HealthTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	ttl := time.Duration(cfg.HealthCacheTTL) * time.Second
+	if ttl <= 0 {
+		ttl = 5 * time.Second
+	}
+	return &HealthChecker{
+		timeout: timeout,
+		ttl:     ttl,
+		cache:   make(map[string]*HealthReport),
+	}
+}
+
+// AddLiveness 注册依赖检查，同时作用于 /healthz 与 /readyz
+func (h *HealthChecker) AddLiveness(name string, fn CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.liveness = append(h.liveness, namedCheck{name: name, fn: fn})
+}
+
+// AddReadiness 注册只作用于 /readyz 的检查
+func (h *HealthChecker) AddReadiness(name string, fn CheckFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readiness = append(h.readiness, namedCheck{name: name, fn: fn})
+}
+
+// Healthz liveness 检查
+func (h *HealthChecker) Healthz(ctx context.Context) *HealthReport {
+	return h.report(ctx, "healthz", false)
+}
+
+// Readyz readiness 检查
+func (h *HealthChecker) Readyz(ctx context.Context) *HealthReport {
+	return h.report(ctx, "readyz", true)
+}
+
+// report 读取缓存或执行检查；持锁执行，同一时刻只有一轮检查在跑
+func (h *HealthChecker) report(ctx context.Context, endpoint string, ready bool) *HealthReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if r, ok := h.cache[endpoint]; ok && time.Since(time.UnixMilli(r.CheckedAt)) < h.ttl {
+		return r
+	}
+
+	checks := append([]namedCheck{}, h.liveness...)
+	if ready {
+		checks = append(checks, h.readiness...)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	report := &HealthReport{
+		Status:    "ok",
+		Checks:    make(map[string]CheckResult, len(checks)),
+		CheckedAt: time.Now().UnixMilli(),
+	}
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c namedCheck) {
+			defer wg.Done()
+			results[i] = h.run(ctx, c.fn)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != "ok" {
+			report.Status = "fail"
+		}
+	}
+	h.cache[endpoint] = report
+	return report
+}
+
+func (h *HealthChecker) run(ctx context.Context, fn CheckFunc) CheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- fn(checkCtx)
+	}()
+
+	// 部分客户端不响应 ctx，超时后直接判定失败
+	var err error
+	select {
+	case err = <-errCh:
+	case <-checkCtx.Done():
+		err = checkCtx.Err()
+	}
+
+	result := CheckResult{Status: "ok", Latency: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = "fail"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func (h *HealthChecker) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, h.Healthz(r.Context()))
+}
+
+func (h *HealthChecker) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, h.Readyz(r.Context()))
+}
+
+func writeReport(w http.ResponseWriter, report *HealthReport) {
+	status := http.StatusOK
+	if report.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+	data, _ := sonic.Marshal(report)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -14,7 +14,8 @@ type MetricsServer struct {
 	server *http.Server
 }
 
-func NewMetricsServer(cfg config.MonitorConfig) *MetricsServer {
+// NewMetricsServer 创建指标服务，health 为 nil 时 /healthz、/readyz 只返回进程存活
+func NewMetricsServer(cfg config.MonitorConfig, health *HealthChecker) *MetricsServer {
 	if !cfg.Enable || cfg.PrometheusAddr == "" {
 		return &MetricsServer{cfg: cfg}
 	}
@@ -22,10 +23,17 @@ func NewMetricsServer(cfg config.MonitorConfig) *MetricsServer {
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.Handler())
 
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"status": "ok"}`))
-	})
+	if health != nil {
+		mux.HandleFunc("/healthz", health.healthzHandler)
+		mux.HandleFunc("/readyz", health.readyzHandler)
+	} else {
+		alive := func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status": "ok"}`))
+		}
+		mux.HandleFunc("/healthz", alive)
+		mux.HandleFunc("/readyz", alive)
+	}
 
 	return &MetricsServer{
 		cfg: cfg,
@@ -45,7 +45,28 @@ var (
 	AsyncWriterMessagesDropped = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "async_writer_messages_dropped_total",
-			Help: "Total number of messages dropped due to full queue.",
+			Help: "Total number of messages dropped due to full queue, by reason (full, evicted, timeout).",
+		},
+		[]string{"writer_id", "reason"},
+	)
+	AsyncWriterQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "async_writer_queue_depth",
+			Help: "Number of items waiting in async writer queues, including coalesce overflow.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterItemsCoalesced = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "async_writer_items_coalesced_total",
+			Help: "Total number of pending items superseded by a newer item with the same key before being written.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterCoalesceRatio = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "async_writer_coalesce_ratio",
+			Help: "Fraction of submitted items superseded before flush, over the last flush window.",
 		},
 		[]string{"writer_id"},
 	)
@@ -79,6 +100,48 @@ var (
 		},
 		[]string{"writer_id"},
 	)
+	AsyncWriterRetryPending = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "async_writer_retry_pending_items",
+			Help: "Number of items in failed batches waiting for retry.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "async_writer_retries_total",
+			Help: "Total number of retry attempts for failed batches.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterItemsSpilled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "async_writer_items_spilled_total",
+			Help: "Total number of items spilled to disk after retries were exhausted.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterItemsReplayed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "async_writer_items_replayed_total",
+			Help: "Total number of spilled items successfully replayed to the sink.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterSpillPendingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "async_writer_spill_pending_bytes",
+			Help: "Size of spilled data on disk waiting to be replayed.",
+		},
+		[]string{"writer_id"},
+	)
+	AsyncWriterItemsLost = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "async_writer_items_lost_total",
+			Help: "Total number of items discarded because retries were exhausted and spilling failed or is disabled.",
+		},
+		[]string{"writer_id"},
+	)
 	BalanceDelay = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "balance_delay",
@@ -86,6 +149,65 @@ var (
 		},
 		[]string{"network"},
 	)
+
+	StreamConnections = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stream_connections",
+			Help: "Current number of smart trade stream connections.",
+		},
+		[]string{"transport"},
+	)
+	StreamClientsDropped = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "stream_clients_dropped_total",
+			Help: "Total number of stream connections closed because the send queue was full.",
+		},
+	)
+
+	// JobRunsTotal 定时作业指标
+	JobRunsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_runs_total",
+			Help: "Total number of scheduled job runs by final status.",
+		},
+		[]string{"job", "status"},
+	)
+	JobRunDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "job_run_duration_seconds",
+			Help:    "Time taken by a scheduled job run, including retries.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600},
+		},
+		[]string{"job"},
+	)
+	JobRunning = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "job_running",
+			Help: "Whether the scheduled job is currently running on this instance.",
+		},
+		[]string{"job"},
+	)
+	JobSkippedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_skipped_total",
+			Help: "Total number of scheduled job runs skipped (overlap / lease).",
+		},
+		[]string{"job", "reason"},
+	)
+	JobRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "job_retries_total",
+			Help: "Total number of scheduled job retries after a failed attempt.",
+		},
+		[]string{"job"},
+	)
+	JobLastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "job_last_success_timestamp_seconds",
+			Help: "Unix time of the last successful scheduled job run.",
+		},
+		[]string{"job"},
+	)
 )
 
 func init() {
@@ -99,10 +221,31 @@ func init() {
 		// async 写入指标
 		AsyncWriterMessagesQueued,
 		AsyncWriterMessagesDropped,
+		AsyncWriterQueueDepth,
+		AsyncWriterItemsCoalesced,
+		AsyncWriterCoalesceRatio,
 		AsyncWriterBatchSize,
 		AsyncWriterFlushCount,
 		AsyncWriterFlushDuration,
 		AsyncWriterItemsWritten,
+		AsyncWriterRetryPending,
+		AsyncWriterRetries,
+		AsyncWriterItemsSpilled,
+		AsyncWriterItemsReplayed,
+		AsyncWriterSpillPendingBytes,
+		AsyncWriterItemsLost,
 		BalanceDelay,
+
+		// 实时推送网关指标
+		StreamConnections,
+		StreamClientsDropped,
+
+		// 定时作业指标
+		JobRunsTotal,
+		JobRunDuration,
+		JobRunning,
+		JobSkippedTotal,
+		JobRetriesTotal,
+		JobLastSuccess,
 	)
 }
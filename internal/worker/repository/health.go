@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"web3-smart/internal/worker/monitor"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+	"gorm.io/gorm"
+)
+
+// HealthChecks 返回各外部依赖的连通性检查，key 为依赖名
+func (r *repositoryImpl) HealthChecks() map[string]monitor.CheckFunc {
+	checks := map[string]monitor.CheckFunc{
+		"postgres":      pingGorm(r.db),
+		"selectdb":      pingGorm(r.selectDB),
+		"redis_main":    pingRedis(r.mainRdb),
+		"redis_metrics": pingRedis(r.metricsRdb),
+		"redis_price":   pingRedis(r.priceRdb),
+		"kafka":         r.pingKafka,
+	}
+	if r.esClient != nil {
+		checks["elasticsearch"] = r.esClient.Ping
+	}
+	if r.bscClient != nil {
+		checks["bsc_rpc"] = func(ctx context.Context) error {
+			_, err := r.bscClient.BlockNumber(ctx)
+			return err
+		}
+	}
+	if r.solanaClient != nil {
+		checks["solana_rpc"] = func(ctx context.Context) error {
+			_, err := r.solanaClient.GetHealth(ctx)
+			return err
+		}
+	}
+	if r.cfg.BydRpcUrl != "" {
+		checks["byd_rpc"] = r.dialBydRpc
+	}
+	return checks
+}
+
+func pingGorm(db *gorm.DB) monitor.CheckFunc {
+	return func(ctx context.Context) error {
+		if db == nil {
+			return errors.New("not initialized")
+		}
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+func pingRedis(rdb *redis.Client) monitor.CheckFunc {
+	return func(ctx context.Context) error {
+		if rdb == nil {
+			return errors.New("not initialized")
+		}
+		return rdb.Ping(ctx).Err()
+	}
+}
+
+// pingKafka 任一 broker 可连接即视为可用
+func (r *repositoryImpl) pingKafka(ctx context.Context) error {
+	var err error
+	for _, broker := range strings.Split(r.cfg.Kafka.Brokers, ",") {
+		var conn *kafka.Conn
+		conn, err = kafka.DialContext(ctx, "tcp", strings.TrimSpace(broker))
+		if err == nil {
+			return conn.Close()
+		}
+	}
+	return err
+}
+
+// dialBydRpc byd rpc 客户端没有健康检查接口，只检查端口连通性
+func (r *repositoryImpl) dialBydRpc(ctx context.Context) error {
+	u, err := url.Parse(r.cfg.BydRpcUrl)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"web3-smart/internal/worker/dao"
+	"web3-smart/internal/worker/monitor"
 	"web3-smart/pkg/elasticsearch"
 	selectdbclient "web3-smart/pkg/selectdb_client"
 
@@ -34,5 +35,8 @@ type Repository interface {
 	//DAO
 	GetDAOManager() *dao.DAOManager
 
+	// HealthChecks 各外部依赖的连通性检查
+	HealthChecks() map[string]monitor.CheckFunc
+
 	Close() error
 }
@@ -19,6 +19,8 @@ import (
 	"go.uber.org/zap"
 )
 
+// BalanceUpdate 余额写入服务，持有固定 id 的异步 writer，全局只创建一个（见 worker.New），
+// 由余额消费者与 token_balance 任务共用
 type BalanceUpdate struct {
 	tl                           *zap.Logger
 	mooxWallets                  wallet.MooxWallet
@@ -29,9 +31,9 @@ type BalanceUpdate struct {
 }
 
 func NewBalanceUpdate(cfg config.Config, logger *zap.Logger, repo repository.Repository) *BalanceUpdate {
-	balanceDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewDbBalanceWriter(repo.GetDB(), logger), 1000, 300*time.Millisecond, "balance_db_writer", 1)
-	balanceSelectDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewSelectDBBalanceWriter(repo.GetSelectDBHttp(), logger), 5000, 1000*time.Millisecond, "balance_select_db_writer", 2)
-	balanceHistorySelectDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewSelectDBBalanceWriter(repo.GetSelectDBHttp(), logger), 5000, 1000*time.Millisecond, "balance_select_db_history_writer", 2)
+	balanceDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewDbBalanceWriter(repo.GetDB(), logger), "balance_db_writer")
+	balanceSelectDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewSelectDBBalanceWriter(repo.GetSelectDBHttp(), logger), "balance_select_db_writer")
+	balanceHistorySelectDbWriter := writer.NewAsyncBatchWriter(logger, balance.NewSelectDBBalanceWriter(repo.GetSelectDBHttp(), logger), "balance_select_db_history_writer")
 	balanceDbWriter.Start(context.Background())
 	balanceSelectDbWriter.Start(context.Background())
 	balanceHistorySelectDbWriter.Start(context.Background())
@@ -147,6 +149,10 @@ func (b *BalanceUpdate) LoadBalanceFromExternal(ctx context.Context, token model
 	return nil
 }
 
+// Stop 写完队列中的余额后关闭 writer，需在余额消费者与调度器停止后调用
 func (b *BalanceUpdate) Stop() error {
+	b.balanceSelectDbWriter.Close()
+	b.balanceHistorySelectDbWriter.Close()
+	b.balanceDbWriter.Close()
 	return nil
 }
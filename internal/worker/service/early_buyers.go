@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/repository"
+
+	"github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// EarlyBuyersService 统计单个 token 最早买入的聪明钱，以及他们目前的持仓状态与盈亏
+//
+// Redis 结构设计：
+//
+//	key:   smart_money:monitor:transaction_pairs:early_buyers:<bip0044_chain_id>:<token_addr>
+//	type:  string（JSON 数组，按首次买入时间升序，最多 earlyBuyersLimit 个钱包）
+//
+// 首次买入时间取 t_smart_holding.position_opened_at，首笔买入的市值/金额取自 t_smart_transaction
+// （交易清理后可能缺失，此时为 0）；持仓状态与盈亏取自 t_smart_holding。
+// 只有最新交易对榜单中的 token 会被定时刷新，其他 token 通过 Get 按需构建。
+type EarlyBuyersService struct {
+	cfg  config.Config
+	tl   *zap.Logger
+	repo repository.Repository
+}
+
+const (
+	earlyBuyersLimit    = 50
+	earlyBuyersCacheTTL = 24 * time.Hour
+
+	EARLY_BUYER_STATUS_HOLDING = "holding" // 未卖出
+	EARLY_BUYER_STATUS_PARTIAL = "partial" // 部分卖出
+	EARLY_BUYER_STATUS_EXITED  = "exited"  // 已清仓
+)
+
+// EarlyBuyer 早期买家
+type EarlyBuyer struct {
+	Rank              int             `json:"rank"`
+	WalletAddress     string          `json:"wallet_address"`
+	Tags              pq.StringArray  `json:"tags"`
+	FirstBuyTime      int64           `json:"first_buy_time"`               // 首次买入时间（毫秒）
+	SincePairCreated  *int64          `json:"since_pair_created,omitempty"` // 首次买入距交易对创建（毫秒），创建时间未知时为空
+	FirstBuyMarketCap decimal.Decimal `json:"first_buy_marketcap"`          // 首笔买入时的市值USD
+	FirstBuyValue     decimal.Decimal `json:"first_buy_value"`              // 首笔买入金额USD
+	BuyAmount         decimal.Decimal `json:"buy_amount"`                   // 累计买入数量
+	SellAmount        decimal.Decimal `json:"sell_amount"`                  // 累计卖出数量
+	HoldingAmount     decimal.Decimal `json:"holding_amount"`               // 当前持仓数量
+	Status            string          `json:"status"`                       // holding / partial / exited
+	RealizedPNL       decimal.Decimal `json:"realized_pnl"`                 // 已实现盈亏USD
+	UnrealizedPNL     decimal.Decimal `json:"unrealized_pnl"`               // 未实现盈亏USD
+	TotalPNL          decimal.Decimal `json:"total_pnl"`                    // 总盈亏USD
+}
+
+// earlyBuyerRow 对应早期买家 SQL 的返回结构
+type earlyBuyerRow struct {
+	WalletAddress        string
+	Tags                 pq.StringArray
+	FirstBuyTime         int64
+	FirstBuyMarketCap    decimal.Decimal
+	FirstBuyValue        decimal.Decimal
+	Amount               decimal.Decimal
+	HistoricalBuyAmount  decimal.Decimal
+	HistoricalSellAmount decimal.Decimal
+	PNL                  decimal.Decimal
+	UnrealizedProfits    decimal.Decimal
+}
+
+func NewEarlyBuyersService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *EarlyBuyersService {
+	return &EarlyBuyersService{
+		cfg:  cfg,
+		tl:   logger,
+		repo: repo,
+	}
+}
+
+// Get 读取缓存的早期买家，缓存不存在时按需构建
+func (s *EarlyBuyersService) Get(ctx context.Context, chainID uint64, tokenAddr string) ([]*EarlyBuyer, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, fmt.Errorf("metrics redis not configured")
+	}
+
+	cached, err := rdb.Get(ctx, s.cacheKey(chainID, tokenAddr)).Result()
+	if err == nil {
+		var buyers []*EarlyBuyer
+		if err := json.Unmarshal([]byte(cached), &buyers); err == nil {
+			return buyers, nil
+		}
+	} else if err != redis.Nil {
+		return nil, err
+	}
+	return s.Build(ctx, chainID, tokenAddr)
+}
+
+// Build 构建单个 token 的早期买家并写入缓存
+func (s *EarlyBuyersService) Build(ctx context.Context, chainID uint64, tokenAddr string) ([]*EarlyBuyer, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, fmt.Errorf("metrics redis not configured")
+	}
+
+	rows, err := s.queryEarlyBuyers(ctx, chainID, tokenAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	// 交易对创建时间，用于计算买入时机
+	var pairCreatedAt *int64
+	if pairsDAO := s.repo.GetDAOManager().PairsDAO; pairsDAO != nil {
+		pairCreatedAt, err = pairsDAO.GetEarliestBlockTimestamp(ctx, chainID, tokenAddr)
+		if err != nil {
+			s.tl.Warn("get pair created time for early buyers failed",
+				zap.Error(err),
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", tokenAddr),
+			)
+		}
+	}
+
+	buyers := make([]*EarlyBuyer, 0, len(rows))
+	for i, r := range rows {
+		buyer := &EarlyBuyer{
+			Rank:              i + 1,
+			WalletAddress:     r.WalletAddress,
+			Tags:              r.Tags,
+			FirstBuyTime:      r.FirstBuyTime,
+			FirstBuyMarketCap: r.FirstBuyMarketCap,
+			FirstBuyValue:     r.FirstBuyValue,
+			BuyAmount:         r.HistoricalBuyAmount,
+			SellAmount:        r.HistoricalSellAmount,
+			HoldingAmount:     r.Amount,
+			RealizedPNL:       r.PNL,
+			UnrealizedPNL:     r.UnrealizedProfits,
+			TotalPNL:          r.PNL.Add(r.UnrealizedProfits),
+		}
+		switch {
+		case !r.Amount.IsPositive():
+			buyer.Status = EARLY_BUYER_STATUS_EXITED
+			buyer.UnrealizedPNL = decimal.Zero
+			buyer.TotalPNL = r.PNL
+		case r.HistoricalSellAmount.IsPositive():
+			buyer.Status = EARLY_BUYER_STATUS_PARTIAL
+		default:
+			buyer.Status = EARLY_BUYER_STATUS_HOLDING
+		}
+		if pairCreatedAt != nil && *pairCreatedAt > 0 && r.FirstBuyTime > 0 {
+			since := r.FirstBuyTime - *pairCreatedAt
+			buyer.SincePairCreated = &since
+		}
+		buyers = append(buyers, buyer)
+	}
+
+	data, err := json.Marshal(buyers)
+	if err != nil {
+		return nil, err
+	}
+	if err := rdb.Set(ctx, s.cacheKey(chainID, tokenAddr), string(data), earlyBuyersCacheTTL).Err(); err != nil {
+		s.tl.Warn("set early buyers cache failed",
+			zap.Error(err),
+			zap.Uint64("chain_id", chainID),
+			zap.String("token", tokenAddr),
+		)
+	}
+	return buyers, nil
+}
+
+// RefreshLeaderboardTokens 刷新最新交易对榜单中全部 token 的早期买家
+func (s *EarlyBuyersService) RefreshLeaderboardTokens(ctx context.Context) error {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
+	}
+
+	for _, chainID := range transactionPairsChains(s.cfg) {
+		tokens, err := rdb.ZRevRange(ctx, transactionPairsLeaderboardKey(chainID), 0, -1).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		for _, tokenAddr := range tokens {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if _, err := s.Build(ctx, chainID, tokenAddr); err != nil {
+				s.tl.Warn("build early buyers failed",
+					zap.Error(err),
+					zap.Uint64("chain_id", chainID),
+					zap.String("token", tokenAddr),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+// queryEarlyBuyers 先按建仓时间取前 N 个持仓，再关联各自的首笔买入交易
+func (s *EarlyBuyersService) queryEarlyBuyers(ctx context.Context, chainID uint64, tokenAddr string) ([]earlyBuyerRow, error) {
+	sql := `
+SELECT
+    h.wallet_address,
+    h.tags,
+    COALESCE(h.position_opened_at, ft.transaction_time, 0) AS first_buy_time,
+    COALESCE(ft.marketcap, 0) AS first_buy_market_cap,
+    COALESCE(ft.value, 0) AS first_buy_value,
+    h.amount,
+    h.historical_buy_amount,
+    h.historical_sell_amount,
+    h.pnl,
+    h.unrealized_profits
+FROM (
+    SELECT *
+    FROM dex_query_v1.t_smart_holding
+    WHERE chain_id = ?
+      AND token_address = ?
+      AND historical_buy_count > 0
+    ORDER BY position_opened_at ASC NULLS LAST
+    LIMIT ?
+) h
+LEFT JOIN LATERAL (
+    SELECT transaction_time, marketcap, value
+    FROM dex_query_v1.t_smart_transaction t
+    WHERE t.chain_id = h.chain_id
+      AND t.wallet_address = h.wallet_address
+      AND t.token_address = h.token_address
+      AND t.transaction_type IN ('build', 'buy')
+    ORDER BY t.transaction_time ASC
+    LIMIT 1
+) ft ON TRUE
+ORDER BY first_buy_time ASC`
+
+	var rows []earlyBuyerRow
+	if err := s.repo.GetDB().WithContext(ctx).Raw(sql, chainID, tokenAddr, earlyBuyersLimit).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (s *EarlyBuyersService) cacheKey(chainID uint64, tokenAddr string) string {
+	return fmt.Sprintf("smart_money:monitor:transaction_pairs:early_buyers:%d:%s", chainID, tokenAddr)
+}
@@ -2,12 +2,12 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
 
 	"github.com/bytedance/sonic"
 	"github.com/redis/go-redis/v9"
@@ -79,8 +79,7 @@ func (s *LatestTradesService) Record(tx *model.WalletTransaction) {
 }
 
 func (s *LatestTradesService) latestTradesKey(chainID uint64) string {
-	// 名称中带 24h，实际读取时由 API 控制时间窗口
-	return fmt.Sprintf("smart_money:monitor:latest_trades:%d:24h", chainID)
+	return utils.LatestTradesKey(chainID)
 }
 
 // zaddLatestTrade 写入一条最新成交，并维护 zset 大小 & TTL
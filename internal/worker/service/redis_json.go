@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rewriteJSON 通过 WATCH 事务对 key 中的 JSON 做读改写，乐观锁冲突时重试，
+// 用于交易侧与行情/价格刷新侧各自维护同一份 detail 的不同字段
+//   - update 返回 false 表示无需写入；exists 表示 key 存在且能解析
+//   - ttl 为 0 时保留原有过期时间
+func rewriteJSON[T any](
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+	ttl time.Duration,
+	update func(v *T, exists bool) bool,
+) error {
+	txf := func(tx *redis.Tx) error {
+		var v T
+		exists := false
+		raw, err := tx.Get(ctx, key).Result()
+		switch {
+		case err == nil:
+			exists = json.Unmarshal([]byte(raw), &v) == nil
+		case err != redis.Nil:
+			return err
+		}
+
+		if !update(&v, exists) {
+			return nil
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if ttl > 0 {
+				pipe.Set(ctx, key, string(data), ttl)
+			} else {
+				pipe.SetArgs(ctx, key, string(data), redis.SetArgs{KeepTTL: true})
+			}
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for i := 0; i < 3; i++ {
+		err = rdb.Watch(ctx, txf, key)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return err
+}
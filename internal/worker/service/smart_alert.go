@@ -0,0 +1,307 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/notifier"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+	"go.uber.org/zap"
+)
+
+// SmartAlertService 基于聪明钱成交触发告警，并通过 Notifier（默认 Lark）发送
+//
+// 告警类型：
+//
+//  1. 集群买入：窗口内 N 个不同聪明钱买入同一 token
+//     key:   smart_money:alert:cluster:<chain_id>:<token_addr>
+//     type:  zset，member=wallet_address，score=最近买入时间（毫秒）
+//
+//  2. 头部钱包开仓：满足胜率/盈亏条件的钱包单笔买入金额超过阈值
+//
+// 冷却：
+//
+//	key: smart_money:alert:cooldown:<type>:<chain_id>:<token_addr>
+//	SET NX + TTL，冷却期内同一 token 同类告警只发送一次
+//
+// 发送在后台 goroutine 中完成，队列满时丢弃，避免阻塞消费主流程。
+type SmartAlertService struct {
+	cfg      config.Config
+	tl       *zap.Logger
+	repo     repository.Repository
+	notifier notifier.Notifier
+
+	clusterTmpl *template.Template
+	whaleTmpl   *template.Template
+
+	msgCh  chan notifier.Message
+	stopCh chan struct{}
+	done   chan struct{}
+}
+
+const (
+	alertTypeCluster = "cluster"
+	alertTypeWhale   = "whale"
+
+	smartAlertQueueSize  = 256
+	smartAlertMaxWallets = 10 // 消息中最多展示的钱包数量
+)
+
+const defaultClusterTemplate = `**Token:** {{.TokenName}} ({{.Network}})
+**Address:** {{.TokenAddress}}
+**{{.WalletCount}}** 个聪明钱在 {{.Window}} 内买入
+**钱包:** {{.Wallets}}
+**最新成交:** ${{.Value}} @ 市值 ${{.MarketCap}}`
+
+const defaultWhaleTemplate = `**Token:** {{.TokenName}} ({{.Network}})
+**Address:** {{.TokenAddress}}
+**钱包:** {{.WalletAddress}}（30d 胜率 {{.WinRate30d}}%，30d PnL ${{.PNL30d}}）
+**开仓金额:** ${{.Value}} @ 市值 ${{.MarketCap}}
+**Tx:** {{.Signature}}`
+
+// smartAlertData 模板渲染数据
+type smartAlertData struct {
+	Network       string
+	TokenAddress  string
+	TokenName     string
+	WalletAddress string
+	Signature     string
+	Value         string
+	MarketCap     string
+	WinRate30d    string
+	PNL30d        string
+	WalletCount   int64
+	Window        string
+	Wallets       string
+}
+
+func NewSmartAlertService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *SmartAlertService {
+	s := &SmartAlertService{
+		cfg:    cfg,
+		tl:     logger,
+		repo:   repo,
+		msgCh:  make(chan notifier.Message, smartAlertQueueSize),
+		stopCh: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if cfg.Lark.Webhook != "" {
+		s.notifier = notifier.NewLarkNotifier(cfg.Lark.Webhook, logger)
+	}
+	s.clusterTmpl = s.parseTemplate(alertTypeCluster, cfg.Alert.ClusterTemplate, defaultClusterTemplate)
+	s.whaleTmpl = s.parseTemplate(alertTypeWhale, cfg.Alert.WhaleTemplate, defaultWhaleTemplate)
+
+	go s.loop()
+	return s
+}
+
+// Record 接收一条已经计算完成的 WalletTransaction，检查是否需要触发告警
+func (s *SmartAlertService) Record(tx *model.WalletTransaction, smartMoney *model.WalletSummary) {
+	if !s.cfg.Alert.Enable || s.notifier == nil {
+		return
+	}
+	if tx == nil || smartMoney == nil || tx.TokenAddress == "" {
+		return
+	}
+	if tx.TransactionType != model.TX_TYPE_BUILD && tx.TransactionType != model.TX_TYPE_BUY {
+		return
+	}
+
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return
+	}
+
+	// 使用较短超时，避免阻塞消费主流程
+	ctx, cancel := context.WithTimeout(context.Background(), 800*time.Millisecond)
+	defer cancel()
+
+	s.checkCluster(ctx, rdb, tx)
+	s.checkWhale(ctx, rdb, tx, smartMoney)
+}
+
+// checkCluster 集群买入检测
+func (s *SmartAlertService) checkCluster(ctx context.Context, rdb *redis.Client, tx *model.WalletTransaction) {
+	threshold := s.cfg.Alert.ClusterWallets
+	window := time.Duration(s.cfg.Alert.ClusterWindow) * time.Second
+	if threshold <= 0 || window <= 0 {
+		return
+	}
+
+	key := fmt.Sprintf("smart_money:alert:cluster:%d:%s", tx.ChainID, tx.TokenAddress)
+	nowMs := time.Now().UnixMilli()
+
+	var cardCmd *redis.IntCmd
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(tx.TransactionTime), Member: tx.WalletAddress})
+		pipe.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("(%d", nowMs-window.Milliseconds()))
+		cardCmd = pipe.ZCard(ctx, key)
+		pipe.Expire(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		s.tl.Warn("update cluster alert window failed", zap.Error(err), zap.String("token", tx.TokenAddress))
+		return
+	}
+
+	count := cardCmd.Val()
+	if count < int64(threshold) {
+		return
+	}
+	if !s.acquireCooldown(ctx, rdb, alertTypeCluster, tx.ChainID, tx.TokenAddress) {
+		return
+	}
+
+	wallets, err := rdb.ZRevRange(ctx, key, 0, smartAlertMaxWallets-1).Result()
+	if err != nil {
+		s.tl.Warn("get cluster alert wallets failed", zap.Error(err), zap.String("token", tx.TokenAddress))
+	}
+	walletsText := strings.Join(wallets, ", ")
+	if count > smartAlertMaxWallets {
+		walletsText += fmt.Sprintf(" 等 %d 个", count)
+	}
+
+	data := s.newAlertData(tx)
+	data.WalletCount = count
+	data.Window = window.String()
+	data.Wallets = walletsText
+
+	s.enqueue(notifier.Message{
+		Title:   fmt.Sprintf("聪明钱集群买入：%s", data.TokenName),
+		Content: s.render(s.clusterTmpl, data),
+		Level:   notifier.LevelWarning,
+	})
+}
+
+// checkWhale 头部钱包大额开仓检测
+func (s *SmartAlertService) checkWhale(ctx context.Context, rdb *redis.Client, tx *model.WalletTransaction, smartMoney *model.WalletSummary) {
+	minValue := s.cfg.Alert.WhaleMinValueUSD
+	if minValue <= 0 || tx.Value.LessThan(decimal.NewFromFloat(minValue)) {
+		return
+	}
+	if !s.isTopWallet(smartMoney) {
+		return
+	}
+	if !s.acquireCooldown(ctx, rdb, alertTypeWhale, tx.ChainID, tx.TokenAddress) {
+		return
+	}
+
+	data := s.newAlertData(tx)
+	data.WinRate30d = smartMoney.WinRate30d.StringFixed(1) // 库中已是百分比
+	data.PNL30d = smartMoney.PNL30d.StringFixed(2)
+
+	s.enqueue(notifier.Message{
+		Title:   fmt.Sprintf("头部聪明钱开仓：%s", data.TokenName),
+		Content: s.render(s.whaleTmpl, data),
+		Level:   notifier.LevelCritical,
+	})
+}
+
+// isTopWallet 判断是否为头部钱包（30d 胜率与盈亏均达到阈值），win_rate_30d 与阈值都是百分比
+func (s *SmartAlertService) isTopWallet(w *model.WalletSummary) bool {
+	if w.WinRate30d.LessThan(decimal.NewFromFloat(s.cfg.Alert.WhaleMinWinRate)) {
+		return false
+	}
+	if w.PNL30d.LessThan(decimal.NewFromFloat(s.cfg.Alert.WhaleMinPnl30d)) {
+		return false
+	}
+	return true
+}
+
+// acquireCooldown 获取冷却锁，成功表示可以发送
+func (s *SmartAlertService) acquireCooldown(ctx context.Context, rdb *redis.Client, alertType string, chainID uint64, tokenAddr string) bool {
+	cooldown := time.Duration(s.cfg.Alert.Cooldown) * time.Second
+	if cooldown <= 0 {
+		return true
+	}
+	key := fmt.Sprintf("smart_money:alert:cooldown:%s:%d:%s", alertType, chainID, tokenAddr)
+	ok, err := rdb.SetNX(ctx, key, strconv.FormatInt(time.Now().UnixMilli(), 10), cooldown).Result()
+	if err != nil {
+		s.tl.Warn("acquire alert cooldown failed", zap.Error(err), zap.String("key", key))
+		return false
+	}
+	return ok
+}
+
+func (s *SmartAlertService) newAlertData(tx *model.WalletTransaction) smartAlertData {
+	tokenName := tx.TokenName
+	if tokenName == "" {
+		tokenName = tx.TokenAddress
+	}
+	return smartAlertData{
+		Network:       bip0044.ChainIdToString(tx.ChainID),
+		TokenAddress:  tx.TokenAddress,
+		TokenName:     tokenName,
+		WalletAddress: tx.WalletAddress,
+		Signature:     tx.Signature,
+		Value:         tx.Value.StringFixed(2),
+		MarketCap:     tx.MarketCap.StringFixed(0),
+	}
+}
+
+// parseTemplate 解析自定义模板，失败时回退到默认模板
+func (s *SmartAlertService) parseTemplate(name, text, fallback string) *template.Template {
+	if text != "" {
+		tmpl, err := template.New(name).Parse(text)
+		if err == nil {
+			return tmpl
+		}
+		s.tl.Warn("parse alert template failed, use default", zap.String("name", name), zap.Error(err))
+	}
+	return template.Must(template.New(name).Parse(fallback))
+}
+
+func (s *SmartAlertService) render(tmpl *template.Template, data smartAlertData) string {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		s.tl.Warn("render alert template failed", zap.String("name", tmpl.Name()), zap.Error(err))
+		return fmt.Sprintf("%s %s", data.TokenName, data.TokenAddress)
+	}
+	return buf.String()
+}
+
+// enqueue 投递到发送队列，队列满时丢弃
+func (s *SmartAlertService) enqueue(msg notifier.Message) {
+	select {
+	case s.msgCh <- msg:
+	default:
+		s.tl.Warn("alert queue full, drop message", zap.String("title", msg.Title))
+	}
+}
+
+// loop 后台发送告警
+func (s *SmartAlertService) loop() {
+	defer close(s.done)
+	for {
+		select {
+		case msg := <-s.msgCh:
+			s.send(msg)
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *SmartAlertService) send(msg notifier.Message) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.notifier.Notify(ctx, msg); err != nil {
+		s.tl.Warn("send alert failed", zap.String("title", msg.Title), zap.Error(err))
+	}
+}
+
+// Close 停止后台发送
+func (s *SmartAlertService) Close() {
+	close(s.stopCh)
+	<-s.done
+}
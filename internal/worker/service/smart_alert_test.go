@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestIsTopWalletWinRatePercent(t *testing.T) {
+	var cfg config.Config
+	cfg.Alert.WhaleMinWinRate = 60
+	cfg.Alert.WhaleMinPnl30d = 10000
+	s := &SmartAlertService{cfg: cfg}
+
+	cases := []struct {
+		name    string
+		winRate int64 // 百分比，与 t_smart_wallet.win_rate_30d 一致
+		want    bool
+	}{
+		{"50% filtered out", 50, false},
+		{"60% passes", 60, true},
+		{"65% passes", 65, true},
+	}
+	for _, c := range cases {
+		w := &model.WalletSummary{WinRate30d: decimal.NewFromInt(c.winRate), PNL30d: decimal.NewFromInt(20000)}
+		if got := s.isTopWallet(w); got != c.want {
+			t.Errorf("%s: isTopWallet = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
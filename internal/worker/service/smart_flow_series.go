@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// SmartFlowSeriesService 负责按时间桶维护 token 级别的聪明钱资金流时间序列
+//
+// Redis 结构设计：
+//
+//  1. 单个时间桶的买卖统计
+//     key:   smart_money:monitor:smart_flow:<chain_id>:<token_addr>:<resolution>:<bucket_ms>
+//     type:  hash
+//     field: buy_value / sell_value / buy_txns / sell_txns
+//
+//  2. 单个时间桶的去重买入/卖出钱包
+//     key:   smart_money:monitor:smart_flow:<chain_id>:<token_addr>:<resolution>:<bucket_ms>:buyers|sellers
+//     type:  set
+//
+//  3. 时间桶索引（用于按区间读取）
+//     key:   smart_money:monitor:smart_flow:<chain_id>:<token_addr>:<resolution>:index
+//     type:  zset，member=bucket_ms，score=bucket_ms
+//
+//  4. 有聪明钱交易的 token 列表（用于定时压缩到 SelectDB）
+//     key:   smart_money:monitor:smart_flow:tokens
+//     type:  zset，member=<chain_id>:<token_addr>，score=最新成交时间（毫秒）
+//
+// 说明：
+//   - 所有 key 按分辨率设置保留时长，超过保留时长的桶自动过期
+//   - 净流入 = 买入金额 - 卖出金额，读取时计算
+type SmartFlowSeriesService struct {
+	cfg  config.Config
+	tl   *zap.Logger
+	repo repository.Repository
+}
+
+// SmartFlowResolution 时间桶分辨率
+type SmartFlowResolution struct {
+	Name      string        // "1m" / "5m" / "1h"
+	Step      time.Duration // 桶长度
+	Retention time.Duration // Redis 保留时长
+	Compact   bool          // 是否压缩写入 SelectDB
+}
+
+// SmartFlowResolutions 支持的时间桶分辨率
+var SmartFlowResolutions = []SmartFlowResolution{
+	{Name: "1m", Step: time.Minute, Retention: 6 * time.Hour},
+	{Name: "5m", Step: 5 * time.Minute, Retention: 48 * time.Hour, Compact: true},
+	{Name: "1h", Step: time.Hour, Retention: 7 * 24 * time.Hour, Compact: true},
+}
+
+const smartFlowTokensRetention = 7 * 24 * time.Hour
+
+func NewSmartFlowSeriesService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *SmartFlowSeriesService {
+	return &SmartFlowSeriesService{
+		cfg:  cfg,
+		tl:   logger,
+		repo: repo,
+	}
+}
+
+// Record 接收一条已经计算完成的 WalletTransaction，增量更新各分辨率的时间桶
+func (s *SmartFlowSeriesService) Record(tx *model.WalletTransaction) {
+	if tx == nil || tx.TokenAddress == "" || tx.WalletAddress == "" {
+		return
+	}
+
+	var valueField, countField, walletSuffix string
+	switch tx.TransactionType {
+	case model.TX_TYPE_BUILD, model.TX_TYPE_BUY:
+		valueField, countField, walletSuffix = "buy_value", "buy_txns", "buyers"
+	case model.TX_TYPE_SELL, model.TX_TYPE_CLEAN:
+		valueField, countField, walletSuffix = "sell_value", "sell_txns", "sellers"
+	default:
+		return
+	}
+
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return
+	}
+
+	// 使用较短超时，避免阻塞消费主流程
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	value := tx.Value.InexactFloat64()
+	nowMs := time.Now().UnixMilli()
+
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, r := range SmartFlowResolutions {
+			bucket := smartFlowBucketStart(tx.TransactionTime, r.Step)
+			// 已超出保留时长的迟到数据直接忽略
+			if bucket+r.Retention.Milliseconds() < nowMs {
+				continue
+			}
+
+			bucketKey := s.bucketKey(tx.ChainID, tx.TokenAddress, r.Name, bucket)
+			walletsKey := bucketKey + ":" + walletSuffix
+			indexKey := s.indexKey(tx.ChainID, tx.TokenAddress, r.Name)
+
+			pipe.HIncrByFloat(ctx, bucketKey, valueField, value)
+			pipe.HIncrBy(ctx, bucketKey, countField, 1)
+			pipe.Expire(ctx, bucketKey, r.Retention)
+
+			pipe.SAdd(ctx, walletsKey, tx.WalletAddress)
+			pipe.Expire(ctx, walletsKey, r.Retention)
+
+			pipe.ZAdd(ctx, indexKey, redis.Z{Score: float64(bucket), Member: bucket})
+			pipe.ZRemRangeByScore(ctx, indexKey, "0", fmt.Sprintf("(%d", nowMs-r.Retention.Milliseconds()))
+			pipe.Expire(ctx, indexKey, r.Retention)
+		}
+
+		tokensKey := s.tokensKey()
+		pipe.ZAdd(ctx, tokensKey, redis.Z{
+			Score:  float64(tx.TransactionTime),
+			Member: fmt.Sprintf("%d:%s", tx.ChainID, tx.TokenAddress),
+		})
+		pipe.ZRemRangeByScore(ctx, tokensKey, "0", fmt.Sprintf("(%d", nowMs-smartFlowTokensRetention.Milliseconds()))
+		pipe.Expire(ctx, tokensKey, smartFlowTokensRetention)
+		return nil
+	})
+	if err != nil {
+		s.tl.Warn("update smart flow series failed",
+			zap.Error(err),
+			zap.Uint64("chain_id", tx.ChainID),
+			zap.String("token", tx.TokenAddress),
+		)
+	}
+}
+
+// GetSeries 读取 token 在 [from, to] 区间内（毫秒，按桶起始时间）的时间序列，按时间升序返回
+func (s *SmartFlowSeriesService) GetSeries(
+	ctx context.Context,
+	chainID uint64,
+	tokenAddr string,
+	resolution string,
+	from, to int64,
+) ([]model.SmartFlowBucket, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, fmt.Errorf("metrics redis not configured")
+	}
+
+	buckets, err := rdb.ZRangeByScore(ctx, s.indexKey(chainID, tokenAddr, resolution), &redis.ZRangeBy{
+		Min: strconv.FormatInt(from, 10),
+		Max: strconv.FormatInt(to, 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	type bucketCmds struct {
+		bucket  int64
+		stats   *redis.MapStringStringCmd
+		buyers  *redis.IntCmd
+		sellers *redis.IntCmd
+	}
+	cmds := make([]bucketCmds, 0, len(buckets))
+
+	_, err = rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, b := range buckets {
+			bucket, err := strconv.ParseInt(b, 10, 64)
+			if err != nil {
+				continue
+			}
+			bucketKey := s.bucketKey(chainID, tokenAddr, resolution, bucket)
+			cmds = append(cmds, bucketCmds{
+				bucket:  bucket,
+				stats:   pipe.HGetAll(ctx, bucketKey),
+				buyers:  pipe.SCard(ctx, bucketKey+":buyers"),
+				sellers: pipe.SCard(ctx, bucketKey+":sellers"),
+			})
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	series := make([]model.SmartFlowBucket, 0, len(cmds))
+	for _, c := range cmds {
+		stats := c.stats.Val()
+		if len(stats) == 0 {
+			// 桶已过期，索引中残留的成员
+			continue
+		}
+
+		buyValue, _ := decimal.NewFromString(stats["buy_value"])
+		sellValue, _ := decimal.NewFromString(stats["sell_value"])
+		buyTxns, _ := strconv.ParseInt(stats["buy_txns"], 10, 64)
+		sellTxns, _ := strconv.ParseInt(stats["sell_txns"], 10, 64)
+
+		series = append(series, model.SmartFlowBucket{
+			ChainID:      chainID,
+			TokenAddress: tokenAddr,
+			Resolution:   resolution,
+			BucketTime:   c.bucket,
+			BuyValue:     buyValue,
+			SellValue:    sellValue,
+			NetFlow:      buyValue.Sub(sellValue),
+			BuyTxns:      buyTxns,
+			SellTxns:     sellTxns,
+			Buyers:       c.buyers.Val(),
+			Sellers:      c.sellers.Val(),
+			UpdatedAt:    now,
+		})
+	}
+	return series, nil
+}
+
+// ActiveTokens 返回 since（毫秒）之后有聪明钱交易的 token，格式为 (chain_id, token_addr)
+func (s *SmartFlowSeriesService) ActiveTokens(ctx context.Context, since int64) ([]TokenRef, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, fmt.Errorf("metrics redis not configured")
+	}
+
+	members, err := rdb.ZRangeByScore(ctx, s.tokensKey(), &redis.ZRangeBy{
+		Min: strconv.FormatInt(since, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]TokenRef, 0, len(members))
+	for _, m := range members {
+		parts := strings.SplitN(m, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		chainID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		tokens = append(tokens, TokenRef{ChainID: chainID, TokenAddress: parts[1]})
+	}
+	return tokens, nil
+}
+
+// TokenRef 链 + token 地址
+type TokenRef struct {
+	ChainID      uint64
+	TokenAddress string
+}
+
+func (s *SmartFlowSeriesService) bucketKey(chainID uint64, tokenAddr, resolution string, bucket int64) string {
+	return fmt.Sprintf("smart_money:monitor:smart_flow:%d:%s:%s:%d", chainID, tokenAddr, resolution, bucket)
+}
+
+func (s *SmartFlowSeriesService) indexKey(chainID uint64, tokenAddr, resolution string) string {
+	return fmt.Sprintf("smart_money:monitor:smart_flow:%d:%s:%s:index", chainID, tokenAddr, resolution)
+}
+
+func (s *SmartFlowSeriesService) tokensKey() string {
+	return "smart_money:monitor:smart_flow:tokens"
+}
+
+// smartFlowBucketStart 计算毫秒时间戳所在桶的起始时间
+func smartFlowBucketStart(ts int64, step time.Duration) int64 {
+	stepMs := step.Milliseconds()
+	return ts - ts%stepMs
+}
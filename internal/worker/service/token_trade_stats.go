@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+
+	"github.com/redis/go-redis/v9"
+	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+	"go.uber.org/zap"
+)
+
+// TokenTradeStatsService 基于全量成交流维护 token 的 24h 滚动成交额与各时间桶开盘价
+//
+// Redis 结构设计：
+//
+//	key:   smart_money:monitor:token_trade_stats:<chain_id>:<token_addr>
+//	type:  hash
+//	field: v:<bucket_ms> 桶内成交额USD（HINCRBYFLOAT 累加，多实例可叠加）
+//	       p:<bucket_ms> 桶内首笔成交价（HSETNX，作为窗口起点参考价）
+//
+// 成交先在内存中按 token + 桶聚合，由后台协程定期批量写入 Redis，避免每笔成交一次 Redis 往返。
+// 全量成交流中的每个 token 都有一个 key：无成交 25h 后整个 key 过期；
+// 持续有成交的 key 每小时删除一次滑出窗口的桶，hash 大小不超过窗口内的桶数。
+type TokenTradeStatsService struct {
+	cfg  config.Config
+	tl   *zap.Logger
+	repo repository.Repository
+
+	mu      sync.Mutex
+	pending map[tokenTradeStatsBucket]*tokenTradeStatsDelta
+
+	lastTrim  map[string]time.Time // key -> 上次清理过期桶的时间，只在 flush 协程中访问
+	lastSweep time.Time            // 上次清理 lastTrim 的时间
+
+	stopCh chan struct{}
+	done   chan struct{}
+}
+
+// TokenWindowStats 单个 token 在 24h 窗口内的统计
+type TokenWindowStats struct {
+	Volume    float64 // 窗口内成交额USD
+	OpenPrice float64 // 窗口内最早时间桶的首笔成交价，没有时为 0
+	LastPrice float64 // 窗口内最新时间桶的首笔成交价，没有时为 0
+}
+
+type tokenTradeStatsBucket struct {
+	chainID   uint64
+	tokenAddr string
+	bucket    int64
+}
+
+type tokenTradeStatsDelta struct {
+	volume    float64
+	openPrice float64
+	openTime  int64
+}
+
+const (
+	tokenTradeStatsStep          = 5 * time.Minute
+	tokenTradeStatsWindow        = 24 * time.Hour
+	tokenTradeStatsFlushInterval = 2 * time.Second
+	tokenTradeStatsTrimInterval  = time.Hour
+	tokenTradeStatsTTL           = tokenTradeStatsWindow + time.Hour
+)
+
+// tokenTradeStatsTrimScript 删除 bucket 早于 ARGV[1] 的全部 v:/p: field
+var tokenTradeStatsTrimScript = redis.NewScript(`
+local stale = {}
+for _, field in ipairs(redis.call('HKEYS', KEYS[1])) do
+  local bucket = tonumber(string.sub(field, 3))
+  if bucket and bucket < tonumber(ARGV[1]) then
+    stale[#stale + 1] = field
+  end
+end
+if #stale > 0 then
+  redis.call('HDEL', KEYS[1], unpack(stale))
+end
+return #stale
+`)
+
+func NewTokenTradeStatsService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *TokenTradeStatsService {
+	s := &TokenTradeStatsService{
+		cfg:      cfg,
+		tl:       logger,
+		repo:     repo,
+		pending:  make(map[tokenTradeStatsBucket]*tokenTradeStatsDelta),
+		lastTrim: make(map[string]time.Time),
+		stopCh:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// Record 累加一笔成交（只写内存）
+func (s *TokenTradeStatsService) Record(trade model.TradeEvent) {
+	tokenAddr := strings.TrimSpace(trade.Event.TokenAddress)
+	if tokenAddr == "" || trade.Event.Time <= 0 {
+		return
+	}
+	chainID := bip0044.NetworkNameToChainId(trade.Event.Network)
+	if chainID == 0 {
+		return
+	}
+
+	tsMs := trade.Event.Time * 1000
+	key := tokenTradeStatsBucket{
+		chainID:   chainID,
+		tokenAddr: tokenAddr,
+		bucket:    tsMs - tsMs%tokenTradeStatsStep.Milliseconds(),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delta, ok := s.pending[key]
+	if !ok {
+		delta = &tokenTradeStatsDelta{}
+		s.pending[key] = delta
+	}
+	delta.volume += trade.Event.VolumeUsd
+	if trade.Event.Price > 0 && (delta.openTime == 0 || tsMs < delta.openTime) {
+		delta.openPrice = trade.Event.Price
+		delta.openTime = tsMs
+	}
+}
+
+// Window 批量读取多个 token 的 24h 窗口统计
+func (s *TokenTradeStatsService) Window(ctx context.Context, chainID uint64, tokenAddrs []string) (map[string]TokenWindowStats, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, fmt.Errorf("metrics redis not configured")
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(tokenAddrs))
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, tokenAddr := range tokenAddrs {
+			cmds[i] = pipe.HGetAll(ctx, s.statsKey(chainID, tokenAddr))
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-tokenTradeStatsWindow).UnixMilli()
+	result := make(map[string]TokenWindowStats, len(tokenAddrs))
+	for i, tokenAddr := range tokenAddrs {
+		var stats TokenWindowStats
+		var openBucket, lastBucket int64
+		for field, value := range cmds[i].Val() {
+			kind, bucketStr, ok := strings.Cut(field, ":")
+			if !ok {
+				continue
+			}
+			bucket, err := strconv.ParseInt(bucketStr, 10, 64)
+			if err != nil || bucket < since {
+				continue
+			}
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			switch kind {
+			case "v":
+				stats.Volume += v
+			case "p":
+				if openBucket == 0 || bucket < openBucket {
+					openBucket, stats.OpenPrice = bucket, v
+				}
+				if bucket > lastBucket {
+					lastBucket, stats.LastPrice = bucket, v
+				}
+			}
+		}
+		result[tokenAddr] = stats
+	}
+	return result, nil
+}
+
+// flush 将内存中的增量批量写入 Redis
+func (s *TokenTradeStatsService) flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[tokenTradeStatsBucket]*tokenTradeStatsDelta, len(pending))
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	var trimKeys []string
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for b, delta := range pending {
+			key := s.statsKey(b.chainID, b.tokenAddr)
+			field := strconv.FormatInt(b.bucket, 10)
+			pipe.HIncrByFloat(ctx, key, "v:"+field, delta.volume)
+			if delta.openPrice > 0 {
+				pipe.HSetNX(ctx, key, "p:"+field, strconv.FormatFloat(delta.openPrice, 'g', -1, 64))
+			}
+			pipe.Expire(ctx, key, tokenTradeStatsTTL)
+
+			if last, ok := s.lastTrim[key]; !ok || now.Sub(last) >= tokenTradeStatsTrimInterval {
+				s.lastTrim[key] = now
+				trimKeys = append(trimKeys, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.tl.Warn("flush token trade stats failed", zap.Int("buckets", len(pending)), zap.Error(err))
+	}
+
+	s.trim(ctx, trimKeys, now)
+}
+
+// trim 删除 keys 中滑出窗口的桶，并清理已随 key 过期的 lastTrim 记录
+func (s *TokenTradeStatsService) trim(ctx context.Context, keys []string, now time.Time) {
+	if len(keys) > 0 {
+		rdb := s.repo.GetMetricsRDB()
+		cutoff := now.Add(-tokenTradeStatsWindow).UnixMilli()
+		_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys {
+				tokenTradeStatsTrimScript.Eval(ctx, pipe, []string{key}, cutoff)
+			}
+			return nil
+		})
+		if err != nil {
+			s.tl.Warn("trim token trade stats failed", zap.Int("keys", len(keys)), zap.Error(err))
+		}
+	}
+
+	if now.Sub(s.lastSweep) < tokenTradeStatsTrimInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, last := range s.lastTrim {
+		if now.Sub(last) > tokenTradeStatsTTL {
+			delete(s.lastTrim, key)
+		}
+	}
+}
+
+// loop 定期批量写入
+func (s *TokenTradeStatsService) loop() {
+	defer close(s.done)
+	ticker := time.NewTicker(tokenTradeStatsFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.stopCh:
+			s.flush()
+			return
+		}
+	}
+}
+
+func (s *TokenTradeStatsService) statsKey(chainID uint64, tokenAddr string) string {
+	return fmt.Sprintf("smart_money:monitor:token_trade_stats:%d:%s", chainID, tokenAddr)
+}
+
+// Close 停止后台协程并写入剩余增量
+func (s *TokenTradeStatsService) Close() {
+	close(s.stopCh)
+	<-s.done
+}
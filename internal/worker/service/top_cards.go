@@ -2,16 +2,18 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/dao"
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
 
 	"github.com/redis/go-redis/v9"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
@@ -22,80 +24,272 @@ import (
 //
 // 目标：
 //  1. token 统计 key：记录周期内哪些聪明钱交易过该 token（zset：member=wallet，score=timestamp）
-//  2. 榜单 key：按周期内聪明钱交易过的数量排序（zset：member=token，score=smart money wallet count，最多保留 200）
-//  3. 榜单前 10 token detail key：存储小卡片展示用的聚合信息（string，json）
+//  2. 榜单 key：按周期内聪明钱交易过的数量排序（zset：member=token，score=smart money wallet count，最多保留 leaderboard_size）
+//  3. 榜单前 detail_count token detail key：存储小卡片展示用的聚合信息（string，json）
+//  4. token 价格采样 key：用于计算周期涨跌幅（zset：member=<ts>:<price>，score=timestamp）
+//  5. 卖出方向的 dump 卡片，结构与以上对应，见 top_cards_dump.go
 //
 // 说明：
 //  - 这里只处理 “已确认是系统聪明钱的钱包” 的交易，调用入口在 TradeHandler 中。
-//  - 价格、涨跌等信息可以先写入基础值，后续由 ticker 流程覆盖/补充。
+//  - 价格、涨跌由 RefreshPrices 定时刷新（见 job.TopCardsPriceRefresh），
+//    交易侧重建 detail 时保留已有的价格字段，两边都通过 WATCH 事务读改写 detail。
+//  - 周期、链与黑名单来自配置 top_cards，随 config.WatchConfig 热加载；
+//    已删除周期的 key 由 reconcilePeriods 清理（见 periods 集合 key）。
 type TopCardsService struct {
 	cfg        config.Config
 	tl         *zap.Logger
 	repo       repository.Repository
 	daoManager *dao.DAOManager
-	periods    []periodConfig
+	settings   atomic.Pointer[topCardsSettings]
+
+	// dump 卡片清仓比例的分母，见 smartHolderCount
+	holderMu     sync.Mutex
+	holderCounts map[string]holderCountEntry
 }
 
 type periodConfig struct {
-	Name     string        // "1h" / "6h" / "24h"
-	Duration time.Duration // 周期长度
+	Name            string        // "1h" / "6h" / "24h"
+	Duration        time.Duration // 周期长度
+	LeaderboardSize int64         // 榜单保留数量
+	DetailCount     int64         // 生成 detail 的榜单前 N 名
+	LeaderboardTTL  time.Duration // 榜单 key 过期时间
+}
+
+// topCardsSettings 由配置生成的运行时参数，热加载时整体替换
+type topCardsSettings struct {
+	periods        []periodConfig
+	chainIDs       []uint64
+	blacklist      map[uint64]map[string]struct{} // chain_id -> 不在小卡片中展示的 token（原生币 & 稳定币等），地址经 normalizeTokenAddress
+	priceRetention time.Duration                  // 价格采样保留时长，覆盖最长的统计周期
 }
 
+// 配置缺省时的默认值
+const (
+	defaultTopCardsLeaderboardSize = 200
+	defaultTopCardsDetailCount     = 10
+	defaultTopCardsLeaderboardTTL  = 7 * 24 * time.Hour
+)
+
+// 当前生效的周期集合，用于发现已删除的周期并清理对应 key
+const topCardsPeriodsKey = "smart_money:monitor:top_cards:periods"
+
 // topCardDetail 小卡片详情结构，存为 JSON string
 type topCardDetail struct {
 	Symbol        string  `json:"symbol"`
 	Logo          string  `json:"logo"`
-	Price         float64 `json:"price"`          // 当前价格（USDT），先占位，后续可由 ticker 覆盖
-	ChangePercent float64 `json:"change_percent"` // 周期内涨跌幅，先占位，后续可由 ticker 覆盖
+	Price         float64 `json:"price"`          // 当前价格（USDT），由 RefreshPrices 定时刷新
+	ChangePercent float64 `json:"change_percent"` // 周期内涨跌幅（%），由 RefreshPrices 定时刷新
 	BuyTxns       int64   `json:"buy_txns"`       // 周期内聪明钱买入笔数
 	SellTxns      int64   `json:"sell_txns"`      // 周期内聪明钱卖出笔数
-}
 
-// 需要从小卡片中过滤掉的 token 列表（原生币 & 稳定币等）
-// 说明：Solana 地址区分大小写，这里直接使用原始字符串；BSC 地址使用 EqualFold 做大小写无关比较。
-var topCardsBlacklist = []string{
-	// Solana 稳定币 & 原生币等
-	"EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v",
-	"Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB",
-	"2b1kV6DkPAnxd5ixfnxCpjxmKwqjjaYmCZfHsFu24GXo",
-	"A1KLoBrKBde8Ty9qtNQUtq3C2ortoC3u7twggz7sEto6",
-	"FR87nWEUxVgerFGhZM8Y4AggKGLnaXswr1Pd8wZ4kZcp",
-	"Ea5SjE2Y6yvCeW5dYTn7PYMuW5ikXkvbGdcmSnXeaLjS",
-	"So11111111111111111111111111111111111111112",
-
-	// BSC 稳定币 & 原生币等
-	"0x55d398326f99059ff775485246999027b3197955",
-	"0x8ac76a51cc950d9822d68b83fe1ad97b32cd580d",
-	"0x1af3f329e8be154074d8769d1ffA4ee058b1dbC3",
-	"0xe9e7cea3dedca5984780bafc599bd69add087d56",
-	"0x14016e85a25aeb13065688cafb43044c2ef86784",
-	"0xbb4cdb9cbd36b01bd1cbaebf2de08d9173bc095c",
-	"0x8d0d000ee44948fc98c9b98a4fa4921476f08b0d",
+	// dump 卡片字段（见 top_cards_dump.go）
+	Sellers    int64   `json:"sellers,omitempty"`     // 周期内卖出的不同聪明钱数量
+	SellValue  float64 `json:"sell_value,omitempty"`  // 周期内聪明钱卖出金额（USD）
+	Cleaners   int64   `json:"cleaners,omitempty"`    // 周期内清仓的不同聪明钱数量
+	CleanRatio float64 `json:"clean_ratio,omitempty"` // 清仓比例 = cleaners / (当前持仓聪明钱 + cleaners)
 }
 
 func NewTopCardsService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *TopCardsService {
-	return &TopCardsService{
+	s := &TopCardsService{
 		cfg:        cfg,
 		tl:         logger,
 		repo:       repo,
 		daoManager: repo.GetDAOManager(),
-		periods: []periodConfig{
-			{Name: "1h", Duration: time.Hour},
-			{Name: "6h", Duration: 6 * time.Hour},
-			{Name: "24h", Duration: 24 * time.Hour},
-		},
 	}
+	s.settings.Store(newTopCardsSettings(cfg.TopCards))
+	go s.reconcilePeriods()
+
+	// 配置热加载
+	config.OnChange(s.reload)
+	return s
+}
+
+// newTopCardsSettings 由配置生成运行时参数，缺省字段使用默认值
+func newTopCardsSettings(cfg config.TopCardsConfig) *topCardsSettings {
+	settings := &topCardsSettings{
+		blacklist: make(map[uint64]map[string]struct{}, len(cfg.Chains)),
+	}
+
+	periods := cfg.Periods
+	if len(periods) == 0 {
+		periods = []config.TopCardsPeriodConfig{
+			{Name: "1h", Duration: 3600},
+			{Name: "6h", Duration: 6 * 3600},
+			{Name: "24h", Duration: 24 * 3600},
+		}
+	}
+	maxDuration := time.Duration(0)
+	for _, p := range periods {
+		if p.Name == "" || p.Duration <= 0 {
+			continue
+		}
+		pc := periodConfig{
+			Name:            p.Name,
+			Duration:        time.Duration(p.Duration) * time.Second,
+			LeaderboardSize: int64(p.LeaderboardSize),
+			DetailCount:     int64(p.DetailCount),
+			LeaderboardTTL:  time.Duration(p.LeaderboardTTL) * time.Second,
+		}
+		if pc.LeaderboardSize <= 0 {
+			pc.LeaderboardSize = defaultTopCardsLeaderboardSize
+		}
+		if pc.DetailCount <= 0 {
+			pc.DetailCount = defaultTopCardsDetailCount
+		}
+		if pc.LeaderboardTTL <= 0 {
+			pc.LeaderboardTTL = defaultTopCardsLeaderboardTTL
+		}
+		if pc.Duration > maxDuration {
+			maxDuration = pc.Duration
+		}
+		settings.periods = append(settings.periods, pc)
+	}
+	settings.priceRetention = maxDuration + time.Hour
+
+	chains := cfg.Chains
+	if len(chains) == 0 {
+		chains = []config.TopCardsChainConfig{{ChainID: bip0044.SOLANA}, {ChainID: bip0044.BSC}}
+	}
+	for _, c := range chains {
+		if c.ChainID == 0 {
+			continue
+		}
+		if _, ok := settings.blacklist[c.ChainID]; !ok {
+			settings.chainIDs = append(settings.chainIDs, c.ChainID)
+			settings.blacklist[c.ChainID] = make(map[string]struct{}, len(c.Blacklist))
+		}
+		for _, addr := range c.Blacklist {
+			settings.blacklist[c.ChainID][normalizeTokenAddress(strings.TrimSpace(addr))] = struct{}{}
+		}
+	}
+	return settings
+}
+
+// normalizeTokenAddress 黑名单比较用的地址形式：EVM 地址大小写无关，统一小写；
+// Solana 地址区分大小写，保持原样，避免误命中
+func normalizeTokenAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// reload 配置热加载回调
+func (s *TopCardsService) reload(cfg config.Config) {
+	settings := newTopCardsSettings(cfg.TopCards)
+	s.settings.Store(settings)
+	s.tl.Info("小卡片配置已重新加载",
+		zap.Int("periods", len(settings.periods)),
+		zap.Int("chains", len(settings.chainIDs)),
+	)
+	go s.reconcilePeriods()
+}
+
+// reconcilePeriods 对比 redis 中记录的周期集合与当前配置，清理已删除周期的 key
+//
+// 周期集合持久化在 redis 中，因此进程重启期间删除的周期也能被清理；
+// 多实例并发执行时通过 SREM 的返回值保证同一周期只由一个实例清理。
+func (s *TopCardsService) reconcilePeriods() {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return
+	}
+	settings := s.settings.Load()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	current := make(map[string]struct{}, len(settings.periods))
+	members := make([]interface{}, 0, len(settings.periods))
+	for _, p := range settings.periods {
+		current[p.Name] = struct{}{}
+		members = append(members, p.Name)
+	}
+
+	stored, err := rdb.SMembers(ctx, topCardsPeriodsKey).Result()
+	if err != nil {
+		s.tl.Warn("读取小卡片周期集合失败", zap.Error(err))
+		return
+	}
+	if len(members) > 0 {
+		if err := rdb.SAdd(ctx, topCardsPeriodsKey, members...).Err(); err != nil {
+			s.tl.Warn("更新小卡片周期集合失败", zap.Error(err))
+			return
+		}
+	}
+
+	for _, name := range stored {
+		if _, ok := current[name]; ok {
+			continue
+		}
+		removed, err := rdb.SRem(ctx, topCardsPeriodsKey, name).Result()
+		if err != nil || removed == 0 {
+			continue // 已被其他实例处理
+		}
+		deleted, err := s.deletePeriodKeys(ctx, rdb, name)
+		if err != nil {
+			s.tl.Warn("清理已删除周期的小卡片 key 失败", zap.String("period", name), zap.Error(err))
+			continue
+		}
+		s.tl.Info("已清理删除周期的小卡片 key", zap.String("period", name), zap.Int("keys", deleted))
+	}
+}
+
+// deletePeriodKeys 删除某个周期的全部 key（榜单 + token 的 wallets/stats/detail）
+func (s *TopCardsService) deletePeriodKeys(ctx context.Context, rdb *redis.Client, period string) (int, error) {
+	patterns := []string{
+		fmt.Sprintf("smart_money:monitor:top_cards:*:%s:leaderboard", period),
+		fmt.Sprintf("smart_money:monitor:top_cards:*:*:%s:wallets", period),
+		fmt.Sprintf("smart_money:monitor:top_cards:*:*:%s:stats", period),
+		fmt.Sprintf("smart_money:monitor:top_cards:*:*:%s:detail", period),
+		// dump 卡片
+		fmt.Sprintf("smart_money:monitor:top_cards:dump:*:%s:leaderboard:value", period),
+		fmt.Sprintf("smart_money:monitor:top_cards:dump:*:*:%s:sellers", period),
+		fmt.Sprintf("smart_money:monitor:top_cards:dump:*:*:%s:cleaners", period),
+	}
+
+	deleted := 0
+	for _, pattern := range patterns {
+		iter := rdb.Scan(ctx, 0, pattern, 1000).Iterator()
+		batch := make([]string, 0, 500)
+		for iter.Next(ctx) {
+			batch = append(batch, iter.Val())
+			if len(batch) >= 500 {
+				if err := rdb.Unlink(ctx, batch...).Err(); err != nil {
+					return deleted, err
+				}
+				deleted += len(batch)
+				batch = batch[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, err
+		}
+		if len(batch) > 0 {
+			if err := rdb.Unlink(ctx, batch...).Err(); err != nil {
+				return deleted, err
+			}
+			deleted += len(batch)
+		}
+	}
+	return deleted, nil
 }
 
 // HandleSmartTrade 处理一笔来自系统聪明钱的钱包交易
-// 目前只统计建仓/买入类型的交易到小卡片中。
+// 建仓/买入计入小卡片，卖出/清仓计入 dump 卡片（见 top_cards_dump.go），其余类型忽略。
 func (s *TopCardsService) HandleSmartTrade(trade model.TradeEvent, smartMoney *model.WalletSummary, txType string) {
 	if smartMoney == nil {
 		return
 	}
 
-	// 只统计买入/建仓方向
-	if txType != model.TX_TYPE_BUILD && txType != model.TX_TYPE_BUY {
+	// 买入/建仓进入小卡片，卖出/清仓进入 dump 卡片
+	var isBuy bool
+	switch txType {
+	case model.TX_TYPE_BUILD, model.TX_TYPE_BUY:
+		isBuy = true
+	case model.TX_TYPE_SELL, model.TX_TYPE_CLEAN:
+		isBuy = false
+	default:
 		return
 	}
 
@@ -104,13 +298,19 @@ func (s *TopCardsService) HandleSmartTrade(trade model.TradeEvent, smartMoney *m
 		return
 	}
 
+	settings := s.settings.Load()
+	blacklist, ok := settings.blacklist[chainID]
+	if !ok {
+		return // 未配置的链不统计
+	}
+
 	tokenAddr := strings.TrimSpace(trade.Event.TokenAddress)
 	if tokenAddr == "" {
 		return
 	}
 
 	// 过滤掉原生币 / 稳定币等不需要在小卡片中展示的 token
-	if s.isBlacklistedToken(tokenAddr) {
+	if _, ok := blacklist[normalizeTokenAddress(tokenAddr)]; ok {
 		return
 	}
 
@@ -118,28 +318,34 @@ func (s *TopCardsService) HandleSmartTrade(trade model.TradeEvent, smartMoney *m
 	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
 	defer cancel()
 
-	for _, p := range s.periods {
-		if err := s.updateForPeriod(ctx, p, chainID, trade, smartMoney, txType); err != nil {
-			s.tl.Warn("更新小卡片聚合数据失败",
+	// 记录成交价作为涨跌幅参考价，保证 token 刚上榜时也有起始参考
+	if trade.Event.Price > 0 {
+		if err := s.recordPriceSample(ctx, s.repo.GetMetricsRDB(), chainID, tokenAddr, trade.Event.Time, trade.Event.Price, settings.priceRetention); err != nil {
+			s.tl.Warn("记录小卡片价格采样失败",
 				zap.Error(err),
 				zap.Uint64("chain_id", chainID),
 				zap.String("token", tokenAddr),
-				zap.String("period", p.Name),
 			)
 		}
 	}
-}
 
-func (s *TopCardsService) isBlacklistedToken(tokenAddr string) bool {
-	if tokenAddr == "" {
-		return false
-	}
-	for _, addr := range topCardsBlacklist {
-		if strings.EqualFold(tokenAddr, addr) {
-			return true
+	for _, p := range settings.periods {
+		var err error
+		if isBuy {
+			err = s.updateForPeriod(ctx, p, chainID, trade, smartMoney, txType)
+		} else {
+			err = s.updateDumpForPeriod(ctx, p, chainID, trade, smartMoney, txType)
+		}
+		if err != nil {
+			s.tl.Warn("更新小卡片聚合数据失败",
+				zap.Error(err),
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", tokenAddr),
+				zap.String("period", p.Name),
+				zap.Bool("dump", !isBuy),
+			)
 		}
 	}
-	return false
 }
 
 // updateForPeriod 针对某个统计周期更新 token 相关的 redis 数据
@@ -148,7 +354,7 @@ func (s *TopCardsService) isBlacklistedToken(tokenAddr string) bool {
 //  1. 更新 token 统计 key（wallets zset）
 //  2. 更新该 token 在榜单 key 中的 score（按聪明钱数量）
 //  3. 更新周期内买卖次数统计（hash）
-//  4. 重算榜单前 10 个 token 的 detail
+//  4. 重算榜单前 detail_count 个 token 的 detail
 func (s *TopCardsService) updateForPeriod(
 	ctx context.Context,
 	p periodConfig,
@@ -184,12 +390,12 @@ func (s *TopCardsService) updateForPeriod(
 
 	// 3. 根据 wallets zset 的 ZCARD 更新榜单 key
 	leaderboardKey := fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:leaderboard", chainID, p.Name)
-	if err := s.updateLeaderboard(ctx, rdb, walletsKey, leaderboardKey, tokenAddr); err != nil {
+	if err := s.updateLeaderboard(ctx, rdb, walletsKey, leaderboardKey, tokenAddr, p); err != nil {
 		return err
 	}
 
-	// 4. 重算榜单前 10 token 的 detail
-	if err := s.refreshTop10Details(ctx, rdb, leaderboardKey, chainID, p, trade.Event.Network); err != nil {
+	// 4. 重算榜单前 N token 的 detail
+	if err := s.refreshTopDetails(ctx, rdb, leaderboardKey, chainID, p, trade.Event.Network); err != nil {
 		return err
 	}
 
@@ -264,31 +470,41 @@ func (s *TopCardsService) updateLeaderboard(
 	ctx context.Context,
 	rdb *redis.Client,
 	walletsKey, leaderboardKey, tokenAddr string,
+	p periodConfig,
 ) error {
 	count, err := rdb.ZCard(ctx, walletsKey).Result()
 	if err != nil {
 		return err
 	}
+	return s.setLeaderboardScore(ctx, rdb, leaderboardKey, tokenAddr, float64(count), p)
+}
 
+// setLeaderboardScore 写入榜单 score，并裁剪榜单长度、刷新 TTL
+func (s *TopCardsService) setLeaderboardScore(
+	ctx context.Context,
+	rdb *redis.Client,
+	leaderboardKey, tokenAddr string,
+	score float64,
+	p periodConfig,
+) error {
 	if _, err := rdb.ZAdd(ctx, leaderboardKey, redis.Z{
-		Score:  float64(count),
+		Score:  score,
 		Member: tokenAddr,
 	}).Result(); err != nil {
 		return err
 	}
 
-	// 只保留前 200 名
-	if _, err := rdb.ZRemRangeByRank(ctx, leaderboardKey, 0, -201).Result(); err != nil {
+	// 只保留前 leaderboard_size 名
+	if _, err := rdb.ZRemRangeByRank(ctx, leaderboardKey, 0, -(p.LeaderboardSize + 1)).Result(); err != nil {
 		return err
 	}
 
-	// 过期时间 7 天
-	_ = rdb.Expire(ctx, leaderboardKey, 7*24*time.Hour).Err()
+	_ = rdb.Expire(ctx, leaderboardKey, p.LeaderboardTTL).Err()
 	return nil
 }
 
-// refreshTop10Details 重算当前榜单前 10 个 token 的小卡片详情
-func (s *TopCardsService) refreshTop10Details(
+// refreshTopDetails 重算当前榜单前 detail_count 个 token 的小卡片详情
+func (s *TopCardsService) refreshTopDetails(
 	ctx context.Context,
 	rdb *redis.Client,
 	leaderboardKey string,
@@ -296,8 +512,8 @@ func (s *TopCardsService) refreshTop10Details(
 	p periodConfig,
 	network string,
 ) error {
-	// 取前 10 名 token 地址（按 score 从大到小）
-	tokenAddrs, err := rdb.ZRevRange(ctx, leaderboardKey, 0, 9).Result()
+	// 取前 N 名 token 地址（按 score 从大到小）
+	tokenAddrs, err := rdb.ZRevRange(ctx, leaderboardKey, 0, p.DetailCount-1).Result()
 	if err != nil {
 		return err
 	}
@@ -358,35 +574,201 @@ func (s *TopCardsService) buildAndSetDetail(
 		}
 	}
 
-	// 3. 价格与涨跌，这里先占位 0，后续由 ticker 或其他行情服务覆盖
-	price := 0.0
-	changePercent := 0.0
+	// 3. 价格与涨跌由 RefreshPrices 维护，这里保留 detail 中已有的值
+	detailKey := fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:%s:detail", chainID, tokenAddr, p.Name)
+	return rewriteJSON(ctx, rdb, detailKey, p.Duration, func(detail *topCardDetail, exists bool) bool {
+		detail.Symbol = symbol
+		detail.Logo = logo
+		detail.BuyTxns = buyTxns
+		detail.SellTxns = sellTxns
+		return true
+	})
+}
+
+// recordPriceSample 记录一次价格采样
+//  - key: smart_money:monitor:top_cards:<chain_id>:<token addr>:prices
+//  - member: <timestamp>:<price>
+//  - score:  timestamp（秒）
+func (s *TopCardsService) recordPriceSample(
+	ctx context.Context,
+	rdb *redis.Client,
+	chainID uint64,
+	tokenAddr string,
+	timestamp int64,
+	price float64,
+	retention time.Duration,
+) error {
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
+	}
+
+	key := s.priceSamplesKey(chainID, tokenAddr)
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{
+			Score:  float64(timestamp),
+			Member: fmt.Sprintf("%d:%s", timestamp, strconv.FormatFloat(price, 'g', -1, 64)),
+		})
+		pipe.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("(%d", timestamp-int64(retention.Seconds())))
+		pipe.Expire(ctx, key, retention)
+		return nil
+	})
+	return err
+}
+
+// referencePrice 获取周期起点之后最早的一次价格采样，作为涨跌幅参考价
+func (s *TopCardsService) referencePrice(
+	ctx context.Context,
+	rdb *redis.Client,
+	chainID uint64,
+	tokenAddr string,
+	since int64,
+) (float64, bool) {
+	members, err := rdb.ZRangeByScore(ctx, s.priceSamplesKey(chainID, tokenAddr), &redis.ZRangeBy{
+		Min:   strconv.FormatInt(since, 10),
+		Max:   "+inf",
+		Count: 1,
+	}).Result()
+	if err != nil || len(members) == 0 {
+		return 0, false
+	}
 
-	detail := topCardDetail{
-		Symbol:        symbol,
-		Logo:          logo,
-		Price:         price,
-		ChangePercent: changePercent,
-		BuyTxns:       buyTxns,
-		SellTxns:      sellTxns,
+	parts := strings.SplitN(members[0], ":", 2)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	price, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || price <= 0 {
+		return 0, false
 	}
+	return price, true
+}
 
-	data, err := json.Marshal(detail)
+// currentPrice 从价格 Redis 读取 token 当前 USDT 价格
+func (s *TopCardsService) currentPrice(ctx context.Context, tokenAddr string) (float64, bool) {
+	priceRdb := s.repo.GetPriceRDB()
+	if priceRdb == nil {
+		return 0, false
+	}
+	res, err := priceRdb.Get(ctx, utils.WapperPriceKey(tokenAddr, "USDT")).Result()
 	if err != nil {
-		return err
+		return 0, false
 	}
+	price, err := strconv.ParseFloat(res, 64)
+	if err != nil || price <= 0 {
+		return 0, false
+	}
+	return price, true
+}
 
-	detailKey := fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:%s:detail", chainID, tokenAddr, p.Name)
-	if err := rdb.Set(ctx, detailKey, string(data), p.Duration).Err(); err != nil {
-		return err
+// RefreshPrices 刷新各链各周期榜单前 detail_count 个 token 的价格与周期涨跌幅
+//
+// 当前价格来自价格 Redis（BYD:price:<token>_USDT），缺失时使用最近一次价格采样；
+// 涨跌幅 = (当前价格 - 周期起点后最早的采样价格) / 采样价格 * 100。
+func (s *TopCardsService) RefreshPrices(ctx context.Context) error {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
 	}
 
+	now := time.Now().Unix()
+	sampled := make(map[string]float64) // 同一 token 在多个周期中只取一次价格
+	refreshed := 0
+
+	settings := s.settings.Load()
+	for _, chainID := range settings.chainIDs {
+		for _, p := range settings.periods {
+			details, err := s.priceRefreshTargets(ctx, rdb, chainID, p)
+			if err != nil {
+				return err
+			}
+
+			for tokenAddr, detailKeys := range details {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+
+				sampleKey := fmt.Sprintf("%d:%s", chainID, tokenAddr)
+				price, ok := sampled[sampleKey]
+				if !ok {
+					if price, ok = s.currentPrice(ctx, tokenAddr); ok {
+						if err := s.recordPriceSample(ctx, rdb, chainID, tokenAddr, now, price, settings.priceRetention); err != nil {
+							s.tl.Warn("记录小卡片价格采样失败", zap.Error(err), zap.String("token", tokenAddr))
+						}
+					} else if price, ok = s.referencePrice(ctx, rdb, chainID, tokenAddr, now-int64(settings.priceRetention.Seconds())); !ok {
+						continue
+					}
+					sampled[sampleKey] = price
+				}
+
+				changePercent := 0.0
+				if ref, ok := s.referencePrice(ctx, rdb, chainID, tokenAddr, now-int64(p.Duration.Seconds())); ok {
+					changePercent = (price - ref) / ref * 100
+				}
+
+				for _, detailKey := range detailKeys {
+					err := rewriteJSON(ctx, rdb, detailKey, 0, func(detail *topCardDetail, exists bool) bool {
+						// detail 尚未由交易侧生成时不创建，避免写入只有价格的空卡片
+						if !exists {
+							return false
+						}
+						detail.Price = price
+						detail.ChangePercent = changePercent
+						return true
+					})
+					if err != nil {
+						s.tl.Warn("刷新小卡片价格失败",
+							zap.Error(err),
+							zap.Uint64("chain_id", chainID),
+							zap.String("token", tokenAddr),
+							zap.String("period", p.Name),
+						)
+						continue
+					}
+					refreshed++
+				}
+			}
+		}
+	}
+
+	s.tl.Debug("小卡片价格刷新完成", zap.Int("tokens", len(sampled)), zap.Int("details", refreshed))
 	return nil
 }
 
+// priceRefreshTargets 收集需要刷新价格的 detail key（小卡片榜单 + dump 榜单的前 detail_count 名）
+// 返回 token -> detail keys
+func (s *TopCardsService) priceRefreshTargets(
+	ctx context.Context,
+	rdb *redis.Client,
+	chainID uint64,
+	p periodConfig,
+) (map[string][]string, error) {
+	targets := make(map[string][]string)
+
+	leaderboardKey := fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:leaderboard", chainID, p.Name)
+	tokenAddrs, err := rdb.ZRevRange(ctx, leaderboardKey, 0, p.DetailCount-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	for _, tokenAddr := range tokenAddrs {
+		targets[tokenAddr] = append(targets[tokenAddr],
+			fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:%s:detail", chainID, tokenAddr, p.Name))
+	}
+
+	dumpTokens, err := s.topDumpTokens(ctx, rdb, chainID, p)
+	if err != nil {
+		return nil, err
+	}
+	for _, tokenAddr := range dumpTokens {
+		targets[tokenAddr] = append(targets[tokenAddr], s.dumpKey(chainID, tokenAddr, p.Name, "detail"))
+	}
+	return targets, nil
+}
+
+func (s *TopCardsService) priceSamplesKey(chainID uint64, tokenAddr string) string {
+	return fmt.Sprintf("smart_money:monitor:top_cards:%d:%s:prices", chainID, tokenAddr)
+}
+
 // Close 目前没有需要关闭的资源，预留接口便于未来扩展
 func (s *TopCardsService) Close() {
 	// no-op
 }
-
-
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"web3-smart/internal/worker/model"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// dump 卡片：聪明钱卖出方向的小卡片，复用小卡片的周期配置与价格刷新
+//
+// Redis 结构设计：
+//
+//  1. 周期内卖出过该 token 的聪明钱
+//     key:  smart_money:monitor:top_cards:dump:<chain_id>:<token_addr>:<period>:sellers
+//     type: zset，member=wallet，score=timestamp（秒）
+//
+//  2. 周期内清仓该 token 的聪明钱
+//     key:  smart_money:monitor:top_cards:dump:<chain_id>:<token_addr>:<period>:cleaners
+//     type: zset，member=wallet，score=timestamp（秒）
+//
+//  3. 周期内卖出统计
+//     key:   smart_money:monitor:top_cards:dump:<chain_id>:<token_addr>:<period>:stats
+//     type:  hash，field=sell_txns / sell_value
+//
+//  4. 榜单（最多保留 leaderboard_size）
+//     key: smart_money:monitor:top_cards:dump:<chain_id>:<period>:leaderboard        按卖出聪明钱数量
+//     key: smart_money:monitor:top_cards:dump:<chain_id>:<period>:leaderboard:value  按卖出金额
+//
+//  5. 两个榜单前 detail_count 名的 detail
+//     key: smart_money:monitor:top_cards:dump:<chain_id>:<token_addr>:<period>:detail
+
+// updateDumpForPeriod 针对某个统计周期更新卖出方向的 redis 数据
+func (s *TopCardsService) updateDumpForPeriod(
+	ctx context.Context,
+	p periodConfig,
+	chainID uint64,
+	trade model.TradeEvent,
+	smartMoney *model.WalletSummary,
+	txType string,
+) error {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
+	}
+
+	tokenAddr := strings.TrimSpace(trade.Event.TokenAddress)
+	walletAddr := strings.TrimSpace(smartMoney.WalletAddress)
+	if tokenAddr == "" || walletAddr == "" {
+		return nil
+	}
+
+	ts := trade.Event.Time // 目前事件时间为秒级时间戳
+
+	// 1. 卖出钱包 / 清仓钱包
+	sellersKey := s.dumpKey(chainID, tokenAddr, p.Name, "sellers")
+	if err := s.updateTokenWallets(ctx, rdb, sellersKey, walletAddr, ts, p.Duration); err != nil {
+		return err
+	}
+	if txType == model.TX_TYPE_CLEAN {
+		cleanersKey := s.dumpKey(chainID, tokenAddr, p.Name, "cleaners")
+		if err := s.updateTokenWallets(ctx, rdb, cleanersKey, walletAddr, ts, p.Duration); err != nil {
+			return err
+		}
+	}
+
+	// 2. 卖出笔数与金额
+	statsKey := s.dumpKey(chainID, tokenAddr, p.Name, "stats")
+	var sellValueCmd *redis.FloatCmd
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HIncrBy(ctx, statsKey, "sell_txns", 1)
+		sellValueCmd = pipe.HIncrByFloat(ctx, statsKey, "sell_value", trade.Event.VolumeUsd)
+		pipe.Expire(ctx, statsKey, p.Duration)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 3. 两个榜单
+	leaderboardKey := s.dumpLeaderboardKey(chainID, p.Name)
+	if err := s.updateLeaderboard(ctx, rdb, sellersKey, leaderboardKey, tokenAddr, p); err != nil {
+		return err
+	}
+	if err := s.setLeaderboardScore(ctx, rdb, leaderboardKey+":value", tokenAddr, sellValueCmd.Val(), p); err != nil {
+		return err
+	}
+
+	// 4. 重算两个榜单前 detail_count 名的 detail
+	tokenAddrs, err := s.topDumpTokens(ctx, rdb, chainID, p)
+	if err != nil {
+		return err
+	}
+	for _, addr := range tokenAddrs {
+		if err := s.buildAndSetDumpDetail(ctx, rdb, chainID, p, addr); err != nil {
+			s.tl.Warn("构建 dump 卡片详情失败",
+				zap.Error(err),
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", addr),
+				zap.String("period", p.Name),
+			)
+		}
+	}
+	return nil
+}
+
+// topDumpTokens 按卖出聪明钱数量与卖出金额两个榜单的前 detail_count 名（去重）
+func (s *TopCardsService) topDumpTokens(ctx context.Context, rdb *redis.Client, chainID uint64, p periodConfig) ([]string, error) {
+	leaderboardKey := s.dumpLeaderboardKey(chainID, p.Name)
+
+	bySellers, err := rdb.ZRevRange(ctx, leaderboardKey, 0, p.DetailCount-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	byValue, err := rdb.ZRevRange(ctx, leaderboardKey+":value", 0, p.DetailCount-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(bySellers)+len(byValue))
+	tokenAddrs := make([]string, 0, len(bySellers)+len(byValue))
+	for _, addr := range append(bySellers, byValue...) {
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		tokenAddrs = append(tokenAddrs, addr)
+	}
+	return tokenAddrs, nil
+}
+
+// buildAndSetDumpDetail 构建单个 token+period 的 dump 卡片详情并写入 Redis
+func (s *TopCardsService) buildAndSetDumpDetail(
+	ctx context.Context,
+	rdb *redis.Client,
+	chainID uint64,
+	p periodConfig,
+	tokenAddr string,
+) error {
+	// 1. token 基本信息（symbol / logo）
+	var symbol, logo string
+	if s.daoManager != nil && s.daoManager.TokenDAO != nil {
+		tokenInfo, err := s.daoManager.TokenDAO.GetTokenInfo(ctx, chainID, tokenAddr)
+		if err != nil {
+			s.tl.Warn("获取 token 信息失败（dump 卡片）",
+				zap.Error(err),
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", tokenAddr),
+			)
+		}
+		if tokenInfo != nil {
+			symbol = tokenInfo.Symbol
+			logo = tokenInfo.Logo
+		}
+	}
+
+	// 2. 卖出钱包数、清仓钱包数、卖出统计
+	var sellersCmd, cleanersCmd *redis.IntCmd
+	var statsCmd *redis.MapStringStringCmd
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		sellersCmd = pipe.ZCard(ctx, s.dumpKey(chainID, tokenAddr, p.Name, "sellers"))
+		cleanersCmd = pipe.ZCard(ctx, s.dumpKey(chainID, tokenAddr, p.Name, "cleaners"))
+		statsCmd = pipe.HGetAll(ctx, s.dumpKey(chainID, tokenAddr, p.Name, "stats"))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	sellers := sellersCmd.Val()
+	cleaners := cleanersCmd.Val()
+	stats := statsCmd.Val()
+	sellTxns, _ := strconv.ParseInt(stats["sell_txns"], 10, 64)
+	sellValue, _ := strconv.ParseFloat(stats["sell_value"], 64)
+
+	// 清仓比例以周期初的聪明钱持仓数为分母：当前仍持仓的加上周期内已清仓的
+	cleanRatio := 0.0
+	if cleaners > 0 {
+		holders, err := s.smartHolderCount(ctx, chainID, tokenAddr)
+		if err != nil {
+			s.tl.Warn("统计 token 聪明钱持仓数失败（dump 卡片）",
+				zap.Error(err),
+				zap.Uint64("chain_id", chainID),
+				zap.String("token", tokenAddr),
+			)
+		}
+		cleanRatio = float64(cleaners) / float64(holders+cleaners)
+	}
+
+	// 3. 价格与涨跌由 RefreshPrices 维护，这里保留 detail 中已有的值
+	detailKey := s.dumpKey(chainID, tokenAddr, p.Name, "detail")
+	return rewriteJSON(ctx, rdb, detailKey, p.Duration, func(detail *topCardDetail, exists bool) bool {
+		detail.Symbol = symbol
+		detail.Logo = logo
+		detail.SellTxns = sellTxns
+		detail.Sellers = sellers
+		detail.SellValue = sellValue
+		detail.Cleaners = cleaners
+		detail.CleanRatio = cleanRatio
+		return true
+	})
+}
+
+// 持仓钱包数缓存：每笔卖出都会重算榜单前几名的 detail，避免逐笔查库
+const (
+	holderCountTTL        = time.Minute
+	holderCountMaxEntries = 4096
+)
+
+type holderCountEntry struct {
+	count    int64
+	expireAt time.Time
+}
+
+// smartHolderCount 当前持有该 token 的聪明钱数量，短时间缓存
+func (s *TopCardsService) smartHolderCount(ctx context.Context, chainID uint64, tokenAddr string) (int64, error) {
+	if s.daoManager == nil || s.daoManager.HoldingDAO == nil {
+		return 0, nil
+	}
+	key := fmt.Sprintf("%d:%s", chainID, tokenAddr)
+	now := time.Now()
+
+	s.holderMu.Lock()
+	entry, ok := s.holderCounts[key]
+	s.holderMu.Unlock()
+	if ok && now.Before(entry.expireAt) {
+		return entry.count, nil
+	}
+
+	count, err := s.daoManager.HoldingDAO.CountHolders(ctx, chainID, tokenAddr)
+	if err != nil {
+		return 0, err
+	}
+
+	s.holderMu.Lock()
+	defer s.holderMu.Unlock()
+	if s.holderCounts == nil {
+		s.holderCounts = make(map[string]holderCountEntry)
+	}
+	if len(s.holderCounts) >= holderCountMaxEntries {
+		for k, e := range s.holderCounts {
+			if !now.Before(e.expireAt) {
+				delete(s.holderCounts, k)
+			}
+		}
+	}
+	s.holderCounts[key] = holderCountEntry{count: count, expireAt: now.Add(holderCountTTL)}
+	return count, nil
+}
+
+func (s *TopCardsService) dumpKey(chainID uint64, tokenAddr, period, suffix string) string {
+	return fmt.Sprintf("smart_money:monitor:top_cards:dump:%d:%s:%s:%s", chainID, tokenAddr, period, suffix)
+}
+
+func (s *TopCardsService) dumpLeaderboardKey(chainID uint64, period string) string {
+	return fmt.Sprintf("smart_money:monitor:top_cards:dump:%d:%s:leaderboard", chainID, period)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/dao"
 	"web3-smart/internal/worker/model"
 	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/shopspring/decimal"
@@ -37,7 +39,11 @@ import (
 //       volume_24h, change_24h,
 //       buy_txns_24h, sell_txns_24h,
 //       buy_value_24h, sell_value_24h,
-//       smart_wallet_count, smart_trade_count, last_transaction_time
+//       smart_wallet_count, smart_trade_count, last_transaction_time,
+//       updated_at
+//
+//     price / marketcap / volume_24h / change_24h 由 RefreshMarketData 对 Top50 批量刷新，
+//     其余字段在成交时由 refreshTokenData 刷新，两侧写入时互相保留对方的字段。
 //
 //  3) 单个 token 的聪明钱弹窗数据
 //     key:   smart_money:monitor:transaction_pairs:smart_window:<bip0044_chain_id>:<token_addr>:24h
@@ -57,10 +63,10 @@ type TransactionPairsService struct {
 type transactionPairDetail struct {
 	Symbol             string  `json:"symbol"`
 	Logo               string  `json:"logo"`
-	Price              float64 `json:"price"`               // 当前价格（占位，由 ticker 覆盖）
-	MarketCap          float64 `json:"marketcap"`           // 当前市值（占位，由 ticker 覆盖）
-	Volume24h          float64 `json:"volume_24h"`          // 24h 成交额（占位，后续可从行情或 SQL 获取）
-	Change24h          float64 `json:"change_24h"`          // 24h 涨跌幅（占位）
+	Price              float64 `json:"price"`               // 当前价格USD
+	MarketCap          float64 `json:"marketcap"`           // 当前市值USD（当前价格 * 总供应量）
+	Volume24h          float64 `json:"volume_24h"`          // 24h 全市场成交额USD
+	Change24h          float64 `json:"change_24h"`          // 24h 涨跌幅（%）
 	BuyTxns24h         int64   `json:"buy_txns_24h"`        // 24h 内 buy/build 笔数
 	SellTxns24h        int64   `json:"sell_txns_24h"`       // 24h 内 sell/clean 笔数
 	BuyValue24h        float64 `json:"buy_value_24h"`       // 24h 内买入金额 USD 累计
@@ -68,6 +74,7 @@ type transactionPairDetail struct {
 	SmartWalletCount   int64   `json:"smart_wallet_count"`  // 24h 内参与该 token 的聪明钱包数量
 	SmartTradeCount    int64   `json:"smart_trade_count"`   // 24h 内聪明钱总交易笔数（buy+sell）
 	LastTransactionTime int64  `json:"last_transaction_time"` // 最新成交时间（毫秒）
+	UpdatedAt           int64  `json:"updated_at"`            // detail 最近一次写入时间（毫秒）
 }
 
 // smartWindowRow 对应聪明钱弹窗 SQL 的返回结构
@@ -146,9 +153,22 @@ func (s *TransactionPairsService) Record(tx *model.WalletTransaction) {
 }
 
 func (s *TransactionPairsService) leaderboardKey(chainID uint64) string {
+	return transactionPairsLeaderboardKey(chainID)
+}
+
+// transactionPairsLeaderboardKey 最新交易对榜单 key，其他服务（如早期买家）也基于该榜单选取 token
+func transactionPairsLeaderboardKey(chainID uint64) string {
 	return fmt.Sprintf("smart_money:monitor:transaction_pairs:%d:24h", chainID)
 }
 
+// transactionPairsChains 最新交易对榜单覆盖的链，配置缺省时为 Solana、BSC
+func transactionPairsChains(cfg config.Config) []uint64 {
+	if len(cfg.TransactionPairs.Chains) > 0 {
+		return cfg.TransactionPairs.Chains
+	}
+	return []uint64{bip0044.SOLANA, bip0044.BSC}
+}
+
 func (s *TransactionPairsService) detailKey(chainID uint64, tokenAddr string) string {
 	return fmt.Sprintf("smart_money:monitor:transaction_pairs:%d:%s:24h", chainID, tokenAddr)
 }
@@ -238,42 +258,28 @@ func (s *TransactionPairsService) refreshTokenData(ctx context.Context, chainID
 		}
 	}
 
-	// 4. 价格、24h 成交额、涨跌幅暂时由行情模块 / ticker 覆盖，这里先占位 0
-	detail := transactionPairDetail{
-		Symbol:             symbol,
-		Logo:               logo,
-		Price:              0,
-		MarketCap:          0,
-		Volume24h:          0,
-		Change24h:          0,
-		BuyTxns24h:         buyTxnsTotal,
-		SellTxns24h:        sellTxnsTotal,
-		BuyValue24h:        buyValueTotal.InexactFloat64(),
-		SellValue24h:       sellValueTotal.InexactFloat64(),
-		SmartWalletCount:   smartWalletCount,
-		SmartTradeCount:    smartTradeCount,
-		LastTransactionTime: lastTs,
-	}
-
-	// 5. 写入 token detail key
-	detailBytes, err := json.Marshal(detail)
-	if err == nil {
-		if err := rdb.Set(ctx, s.detailKey(chainID, tokenAddr), string(detailBytes), 24*time.Hour).Err(); err != nil {
-			s.tl.Warn("set transaction_pairs detail failed",
-				zap.Error(err),
-				zap.Uint64("chain_id", chainID),
-				zap.String("token", tokenAddr),
-			)
-		}
-	} else {
-		s.tl.Warn("marshal transaction_pairs detail failed",
+	// 4. 写入 token detail key，价格、市值、24h 成交额与涨跌幅由 RefreshMarketData 维护，这里保留已有值
+	err = s.rewriteDetail(ctx, rdb, s.detailKey(chainID, tokenAddr), func(detail *transactionPairDetail, exists bool) bool {
+		detail.Symbol = symbol
+		detail.Logo = logo
+		detail.BuyTxns24h = buyTxnsTotal
+		detail.SellTxns24h = sellTxnsTotal
+		detail.BuyValue24h = buyValueTotal.InexactFloat64()
+		detail.SellValue24h = sellValueTotal.InexactFloat64()
+		detail.SmartWalletCount = smartWalletCount
+		detail.SmartTradeCount = smartTradeCount
+		detail.LastTransactionTime = lastTs
+		return true
+	})
+	if err != nil {
+		s.tl.Warn("set transaction_pairs detail failed",
 			zap.Error(err),
 			zap.Uint64("chain_id", chainID),
 			zap.String("token", tokenAddr),
 		)
 	}
 
-	// 6. 写入聪明钱弹窗 smart_window key（直接把 SQL 结果数组序列化）
+	// 5. 写入聪明钱弹窗 smart_window key（直接把 SQL 结果数组序列化）
 	windowBytes, err := json.Marshal(rows)
 	if err == nil {
 		if err := rdb.Set(ctx, s.smartWindowKey(chainID, tokenAddr), string(windowBytes), 24*time.Hour).Err(); err != nil {
@@ -292,6 +298,137 @@ func (s *TransactionPairsService) refreshTokenData(ctx context.Context, chainID
 	}
 }
 
+// RefreshMarketData 批量刷新各链 Top50 token 的价格、市值、24h 成交额与涨跌幅
+//
+//   - 价格：价格 Redis（BYD:price:<token>_USDT），缺失时使用成交流中最新时间桶的价格
+//   - 市值：价格 * TokenDAO 中的总供应量
+//   - 成交额与涨跌幅：TokenTradeStatsService 维护的 24h 窗口，涨跌幅参考价为窗口内最早时间桶的首笔成交价
+func (s *TransactionPairsService) RefreshMarketData(ctx context.Context, tradeStats *TokenTradeStatsService) error {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return fmt.Errorf("metrics redis not configured")
+	}
+
+	refreshed := 0
+	for _, chainID := range transactionPairsChains(s.cfg) {
+		tokenAddrs, err := rdb.ZRevRange(ctx, s.leaderboardKey(chainID), 0, 49).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+		if len(tokenAddrs) == 0 {
+			continue
+		}
+
+		windows, err := tradeStats.Window(ctx, chainID, tokenAddrs)
+		if err != nil {
+			return err
+		}
+		prices := s.livePrices(ctx, tokenAddrs)
+
+		for _, tokenAddr := range tokenAddrs {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			window := windows[tokenAddr]
+			price, ok := prices[tokenAddr]
+			if !ok {
+				price = window.LastPrice
+			}
+
+			change := 0.0
+			if price > 0 && window.OpenPrice > 0 {
+				change = (price - window.OpenPrice) / window.OpenPrice * 100
+			}
+
+			marketCap := 0.0
+			if price > 0 && s.daoManager != nil && s.daoManager.TokenDAO != nil {
+				tokenInfo, err := s.daoManager.TokenDAO.GetTokenInfo(ctx, chainID, tokenAddr)
+				if err != nil {
+					s.tl.Warn("get token info for transaction_pairs market data failed",
+						zap.Error(err),
+						zap.Uint64("chain_id", chainID),
+						zap.String("token", tokenAddr),
+					)
+				}
+				if tokenInfo != nil && tokenInfo.Supply != nil {
+					marketCap = tokenInfo.Supply.Mul(decimal.NewFromFloat(price)).InexactFloat64()
+				}
+			}
+
+			err := s.rewriteDetail(ctx, rdb, s.detailKey(chainID, tokenAddr), func(detail *transactionPairDetail, exists bool) bool {
+				// detail 尚未由成交侧生成时不创建
+				if !exists {
+					return false
+				}
+				detail.Price = price
+				detail.MarketCap = marketCap
+				detail.Volume24h = window.Volume
+				detail.Change24h = change
+				return true
+			})
+			if err != nil {
+				s.tl.Warn("refresh transaction_pairs market data failed",
+					zap.Error(err),
+					zap.Uint64("chain_id", chainID),
+					zap.String("token", tokenAddr),
+				)
+				continue
+			}
+			refreshed++
+		}
+	}
+
+	s.tl.Debug("transaction_pairs market data refreshed", zap.Int("details", refreshed))
+	return nil
+}
+
+// livePrices 批量读取 token 当前 USDT 价格
+func (s *TransactionPairsService) livePrices(ctx context.Context, tokenAddrs []string) map[string]float64 {
+	prices := make(map[string]float64, len(tokenAddrs))
+	priceRdb := s.repo.GetPriceRDB()
+	if priceRdb == nil {
+		return prices
+	}
+
+	keys := make([]string, len(tokenAddrs))
+	for i, tokenAddr := range tokenAddrs {
+		keys[i] = utils.WapperPriceKey(tokenAddr, "USDT")
+	}
+	values, err := priceRdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		s.tl.Warn("get transaction_pairs live prices failed", zap.Error(err))
+		return prices
+	}
+	for i, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if price, err := strconv.ParseFloat(str, 64); err == nil && price > 0 {
+			prices[tokenAddrs[i]] = price
+		}
+	}
+	return prices
+}
+
+// rewriteDetail 以 WATCH 事务读改写 detail，避免成交侧与行情侧互相覆盖字段
+// update 返回 false 表示不写入
+func (s *TransactionPairsService) rewriteDetail(
+	ctx context.Context,
+	rdb *redis.Client,
+	key string,
+	update func(detail *transactionPairDetail, exists bool) bool,
+) error {
+	return rewriteJSON(ctx, rdb, key, 24*time.Hour, func(detail *transactionPairDetail, exists bool) bool {
+		if !update(detail, exists) {
+			return false
+		}
+		detail.UpdatedAt = time.Now().UnixMilli()
+		return true
+	})
+}
+
 // querySmartWindow 使用提供的 SQL 模板聚合 24h 内聪明钱的买卖行为
 func (s *TransactionPairsService) querySmartWindow(
 	ctx context.Context,
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/dao"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/internal/worker/writer"
+	"web3-smart/internal/worker/writer/wallet"
+
+	"github.com/lib/pq"
+	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+	"go.uber.org/zap"
+)
+
+// 钱包人工维护（管理接口）：
+//   - 增删标签、修改 twitter 信息与 wallet_type、新增跟踪钱包；
+//   - Postgres 为准，变更与审计记录同一事务提交，提交后覆盖 Redis / 本地缓存并同步重建 ES 文档；
+//   - ES 写入失败只记日志，下次交易或分析任务会再次写入。
+
+var (
+	ErrInvalidWalletInput = errors.New("invalid wallet input")
+	ErrWalletNotFound     = errors.New("wallet not found")
+)
+
+// manualTagPattern 自定义标签只允许小写字母、数字与下划线，长度受 tags varchar(50) 限制
+var manualTagPattern = regexp.MustCompile(`^[a-z0-9_]{1,50}$`)
+
+// reservedTags 由交易侧按持仓实时计算的标签，不允许人工维护
+var reservedTags = map[string]struct{}{
+	model.TAG_DEV:          {},
+	model.TAG_SNIPER:       {},
+	model.TAG_FRESH_WALLET: {},
+}
+
+const maxTwitterFieldLen = 50
+
+// TrackWalletRequest 新增跟踪钱包
+type TrackWalletRequest struct {
+	ChainID         uint64   `json:"chain_id"`
+	WalletAddress   string   `json:"wallet_address"`
+	Tags            []string `json:"tags"`
+	TwitterName     string   `json:"twitter_name"`
+	TwitterUsername string   `json:"twitter_username"`
+	WalletType      int      `json:"wallet_type"`
+}
+
+// WalletTagsRequest 增删标签，同一标签同时出现在 add 与 remove 时以 remove 为准
+type WalletTagsRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// WalletProfileRequest 修改钱包资料，字段为 nil 表示不修改
+type WalletProfileRequest struct {
+	TwitterName     *string `json:"twitter_name"`
+	TwitterUsername *string `json:"twitter_username"`
+	WalletType      *int    `json:"wallet_type"`
+}
+
+type WalletAdminService struct {
+	cfg        config.Config
+	tl         *zap.Logger
+	daoManager *dao.DAOManager
+	esWriter   writer.BatchWriter[model.WalletSummary]
+}
+
+func NewWalletAdminService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *WalletAdminService {
+	return &WalletAdminService{
+		cfg:        cfg,
+		tl:         logger,
+		daoManager: repo.GetDAOManager(),
+		esWriter:   wallet.NewESWalletWriter(repo.GetElasticsearchClient(), logger, cfg.Elasticsearch.WalletsIndexName),
+	}
+}
+
+// Track 新增跟踪钱包，钱包已存在时返回 dao.ErrWalletExists
+func (s *WalletAdminService) Track(ctx context.Context, operator string, req TrackWalletRequest) (*model.WalletSummary, error) {
+	if req.ChainID != bip0044.SOLANA && req.ChainID != bip0044.BSC {
+		return nil, fmt.Errorf("%w: unsupported chain_id %d", ErrInvalidWalletInput, req.ChainID)
+	}
+	address := normalizeWalletAddress(req.WalletAddress)
+	if address == "" {
+		return nil, fmt.Errorf("%w: wallet_address is required", ErrInvalidWalletInput)
+	}
+	tags, err := normalizeManualTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	req.TwitterName = strings.TrimSpace(req.TwitterName)
+	req.TwitterUsername = strings.TrimPrefix(strings.TrimSpace(req.TwitterUsername), "@")
+	if err := validateProfile(req.TwitterName, req.TwitterUsername, req.WalletType); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	w := &model.WalletSummary{
+		WalletAddress:   address,
+		ChainID:         req.ChainID,
+		Tags:            pq.StringArray(tags),
+		TwitterName:     req.TwitterName,
+		TwitterUsername: req.TwitterUsername,
+		WalletType:      req.WalletType,
+		TokenList:       model.TokenList{},
+		IsActive:        true,
+		UpdatedAt:       now,
+		CreatedAt:       now,
+	}
+	audit := newWalletAudit(req.ChainID, address, model.WALLET_AUDIT_ACTION_TRACK, operator)
+	if err := s.daoManager.WalletDAO.CreateTracked(ctx, w, audit); err != nil {
+		return nil, err
+	}
+
+	s.tl.Info("wallet tracked by admin",
+		zap.Uint64("chain_id", w.ChainID),
+		zap.String("wallet", w.WalletAddress),
+		zap.String("operator", operator))
+	s.reindex(ctx, w)
+	return w, nil
+}
+
+// UpdateTags 增删钱包标签
+func (s *WalletAdminService) UpdateTags(ctx context.Context, operator string, chainID uint64, walletAddress string, req WalletTagsRequest) (*model.WalletSummary, error) {
+	add, err := normalizeManualTags(req.Add)
+	if err != nil {
+		return nil, err
+	}
+	remove, err := normalizeManualTags(req.Remove)
+	if err != nil {
+		return nil, err
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("%w: add or remove is required", ErrInvalidWalletInput)
+	}
+
+	return s.update(ctx, operator, chainID, walletAddress, model.WALLET_AUDIT_ACTION_TAGS, func(w *model.WalletSummary) error {
+		w.Tags = pq.StringArray(mergeTags(w.Tags, add, remove))
+		return nil
+	})
+}
+
+// UpdateProfile 修改 twitter 信息与 wallet_type
+func (s *WalletAdminService) UpdateProfile(ctx context.Context, operator string, chainID uint64, walletAddress string, req WalletProfileRequest) (*model.WalletSummary, error) {
+	if req.TwitterName == nil && req.TwitterUsername == nil && req.WalletType == nil {
+		return nil, fmt.Errorf("%w: nothing to update", ErrInvalidWalletInput)
+	}
+
+	return s.update(ctx, operator, chainID, walletAddress, model.WALLET_AUDIT_ACTION_PROFILE, func(w *model.WalletSummary) error {
+		if req.TwitterName != nil {
+			w.TwitterName = strings.TrimSpace(*req.TwitterName)
+		}
+		if req.TwitterUsername != nil {
+			w.TwitterUsername = strings.TrimPrefix(strings.TrimSpace(*req.TwitterUsername), "@")
+		}
+		if req.WalletType != nil {
+			w.WalletType = *req.WalletType
+		}
+		return validateProfile(w.TwitterName, w.TwitterUsername, w.WalletType)
+	})
+}
+
+// Get 获取钱包详情，人工变更提交后会覆盖缓存，读缓存即可
+func (s *WalletAdminService) Get(ctx context.Context, chainID uint64, walletAddress string) (*model.WalletSummary, error) {
+	w, err := s.daoManager.WalletDAO.GetByWalletAddress(ctx, chainID, normalizeWalletAddress(walletAddress))
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrWalletNotFound
+	}
+	return w, nil
+}
+
+// ListAudits 钱包人工变更审计记录
+func (s *WalletAdminService) ListAudits(ctx context.Context, chainID uint64, walletAddress string, limit, offset int) ([]*model.WalletAudit, error) {
+	return s.daoManager.WalletDAO.ListAudits(ctx, chainID, normalizeWalletAddress(walletAddress), limit, offset)
+}
+
+func (s *WalletAdminService) update(ctx context.Context, operator string, chainID uint64, walletAddress, action string, mutate func(w *model.WalletSummary) error) (*model.WalletSummary, error) {
+	address := normalizeWalletAddress(walletAddress)
+	audit := newWalletAudit(chainID, address, action, operator)
+
+	w, err := s.daoManager.WalletDAO.UpdateManualFields(ctx, chainID, address, mutate, audit)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, ErrWalletNotFound
+	}
+
+	s.tl.Info("wallet updated by admin",
+		zap.Uint64("chain_id", chainID),
+		zap.String("wallet", address),
+		zap.String("action", action),
+		zap.String("operator", operator),
+		zap.ByteString("after", audit.After))
+	s.reindex(ctx, w)
+	return w, nil
+}
+
+// reindex 同步重建钱包 ES 文档
+func (s *WalletAdminService) reindex(ctx context.Context, w *model.WalletSummary) {
+	if err := s.esWriter.BWrite(ctx, []model.WalletSummary{*w}); err != nil {
+		s.tl.Warn("reindex wallet es document failed",
+			zap.Uint64("chain_id", w.ChainID),
+			zap.String("wallet", w.WalletAddress),
+			zap.Error(err))
+	}
+}
+
+func newWalletAudit(chainID uint64, walletAddress, action, operator string) *model.WalletAudit {
+	return &model.WalletAudit{
+		ChainID:       chainID,
+		WalletAddress: walletAddress,
+		Action:        action,
+		Operator:      operator,
+		CreatedAt:     time.Now().UnixMilli(),
+	}
+}
+
+// normalizeWalletAddress EVM 地址统一小写，与交易侧地址保持一致
+func normalizeWalletAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
+
+// normalizeManualTags 校验并去重人工标签
+func normalizeManualTags(tags []string) ([]string, error) {
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !manualTagPattern.MatchString(t) {
+			return nil, fmt.Errorf("%w: invalid tag %q", ErrInvalidWalletInput, t)
+		}
+		if _, ok := reservedTags[t]; ok {
+			return nil, fmt.Errorf("%w: tag %q is computed from trades", ErrInvalidWalletInput, t)
+		}
+		if _, ok := seen[t]; ok {
+			continue
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// mergeTags 在保留原有顺序的前提下追加 add、剔除 remove
+func mergeTags(current, add, remove []string) []string {
+	removed := make(map[string]struct{}, len(remove))
+	for _, t := range remove {
+		removed[t] = struct{}{}
+	}
+
+	out := make([]string, 0, len(current)+len(add))
+	seen := make(map[string]struct{}, len(current)+len(add))
+	for _, list := range [][]string{current, add} {
+		for _, t := range list {
+			if _, ok := removed[t]; ok {
+				continue
+			}
+			if _, ok := seen[t]; ok {
+				continue
+			}
+			seen[t] = struct{}{}
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func validateProfile(twitterName, twitterUsername string, walletType int) error {
+	if len(twitterName) > maxTwitterFieldLen || len(twitterUsername) > maxTwitterFieldLen {
+		return fmt.Errorf("%w: twitter fields must be at most %d characters", ErrInvalidWalletInput, maxTwitterFieldLen)
+	}
+	// 0:一般聪明钱，1:pump聪明钱，2:moonshot聪明钱
+	if walletType < 0 || walletType > 2 {
+		return fmt.Errorf("%w: invalid wallet_type %d", ErrInvalidWalletInput, walletType)
+	}
+	return nil
+}
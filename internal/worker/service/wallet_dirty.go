@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// WalletDirtySetService 维护有新交易的钱包集合，供 smart_money_analyze 增量重算
+// 数据结构：
+//
+//	key:    smart_money:dirty_wallets
+//	type:   set
+//	member: <bip0044_chain_id>:<wallet_address>
+//
+// 交易批次写入 t_smart_transaction 成功后按批 SADD 标记（tx_db_writer 的写入回调），
+// 保证增量任务取出钱包时交易已落库；增量任务用 SPOP 批量取出重算，失败的放回集合下次重试。
+// 取出后进程崩溃会丢失标记，这部分钱包由全量扫描兜底。
+type WalletDirtySetService struct {
+	cfg  config.Config
+	tl   *zap.Logger
+	repo repository.Repository
+}
+
+// DirtyWallet 待重算的钱包
+type DirtyWallet struct {
+	ChainID       uint64
+	WalletAddress string
+}
+
+// Key 集合成员
+func (d DirtyWallet) Key() string {
+	return fmt.Sprintf("%d:%s", d.ChainID, d.WalletAddress)
+}
+
+func NewWalletDirtySetService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *WalletDirtySetService {
+	return &WalletDirtySetService{
+		cfg:  cfg,
+		tl:   logger,
+		repo: repo,
+	}
+}
+
+// MarkTransactions 标记一批已落库交易涉及的钱包，同一批次去重后一次 SADD；失败只记日志
+func (s *WalletDirtySetService) MarkTransactions(txs []model.WalletTransaction) {
+	if len(txs) == 0 {
+		return
+	}
+	seen := make(map[string]struct{}, len(txs))
+	members := make([]interface{}, 0, len(txs))
+	for _, tx := range txs {
+		member := DirtyWallet{ChainID: tx.ChainID, WalletAddress: tx.WalletAddress}.Key()
+		if _, ok := seen[member]; ok {
+			continue
+		}
+		seen[member] = struct{}{}
+		members = append(members, member)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.repo.GetMainRDB().SAdd(ctx, utils.DirtyWalletsKey(), members...).Err(); err != nil {
+		s.tl.Warn("mark dirty wallets failed", zap.Int("count", len(members)), zap.Error(err))
+	}
+}
+
+// Size 待重算的钱包数量
+func (s *WalletDirtySetService) Size(ctx context.Context) (int64, error) {
+	return s.repo.GetMainRDB().SCard(ctx, utils.DirtyWalletsKey()).Result()
+}
+
+// Pop 随机取出最多 count 个待重算钱包
+func (s *WalletDirtySetService) Pop(ctx context.Context, count int64) ([]DirtyWallet, error) {
+	members, err := s.repo.GetMainRDB().SPopN(ctx, utils.DirtyWalletsKey(), count).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	wallets := make([]DirtyWallet, 0, len(members))
+	for _, member := range members {
+		chain, address, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		chainID, err := strconv.ParseUint(chain, 10, 64)
+		if err != nil {
+			continue
+		}
+		wallets = append(wallets, DirtyWallet{ChainID: chainID, WalletAddress: address})
+	}
+	return wallets, nil
+}
+
+// Restore 把重算失败的钱包放回集合
+func (s *WalletDirtySetService) Restore(ctx context.Context, wallets []DirtyWallet) error {
+	if len(wallets) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(wallets))
+	for _, w := range wallets {
+		members = append(members, w.Key())
+	}
+	return s.repo.GetMainRDB().SAdd(ctx, utils.DirtyWalletsKey(), members...).Err()
+}
@@ -14,6 +14,7 @@ import (
 	"web3-smart/internal/worker/writer/wallet"
 	"web3-smart/pkg/utils"
 	getOnchainInfo "web3-smart/pkg/utils/get_onchain_info"
+	walletUtils "web3-smart/pkg/utils/wallet_utils"
 
 	"github.com/shopspring/decimal"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
@@ -28,21 +29,28 @@ type WalletIndicatorStatistics struct {
 	tl             *zap.Logger
 	repo           repository.Repository
 	daoManager     *dao.DAOManager
-	walletDbWriter *writer.AsyncBatchWriter[model.WalletSummary]
-	walletEsWriter *writer.AsyncBatchWriter[model.WalletSummary]
+	walletDbWriter *writer.CoalescingBatchWriter[model.WalletSummary]
+	walletEsWriter *writer.CoalescingBatchWriter[model.WalletSummary]
 	txDbWriter     *writer.AsyncBatchWriter[model.WalletTransaction]
 	txKafkaWriter  *writer.AsyncBatchWriter[model.WalletTransaction]
 	latestTrades   *LatestTradesService
 	pairsService   *TransactionPairsService
+	flowSeries     *SmartFlowSeriesService
+	alerts         *SmartAlertService
 }
 
 func NewWalletIndicatorStatistics(cfg config.Config, logger *zap.Logger, repo repository.Repository) *WalletIndicatorStatistics {
-	walletDbWriter := writer.NewAsyncBatchWriter(logger, wallet.NewDbWalletWriter(repo.GetDB(), logger), 1000, 300*time.Millisecond, "wallet_db_writer", 1)
-	walletEsWriter := writer.NewAsyncBatchWriter(logger, wallet.NewESWalletWriter(repo.GetElasticsearchClient(), logger, cfg.Elasticsearch.WalletsIndexName), 1000, 300*time.Millisecond, "wallet_es_writer", 1)
-	txDbWriter := writer.NewAsyncBatchWriter(logger, transaction.NewDbTransactionWriter(repo.GetDB(), logger), 1000, 300*time.Millisecond, "tx_db_writer", 1)
-	txKafkaWriter := writer.NewAsyncBatchWriter(logger, transaction.NewKafkaSmartTxWriter(repo.GetMQ(), logger, cfg.Kafka.TopicSmartTrade), 1000, 100*time.Millisecond, "tx_kafka_writer", 1)
+	walletDbWriter := writer.NewCoalescingBatchWriter(logger, wallet.NewDbWalletWriter(repo.GetDB(), logger), "wallet_db_writer", walletUtils.WalletKey)
+	walletEsWriter := writer.NewCoalescingBatchWriter(logger, wallet.NewESWalletWriter(repo.GetElasticsearchClient(), logger, cfg.Elasticsearch.WalletsIndexName), "wallet_es_writer", walletUtils.WalletKey)
+	dirtyWallets := NewWalletDirtySetService(cfg, logger, repo)
+	// 交易落库后再标记钱包待增量重算，避免增量任务先于交易写入重算而消耗掉标记
+	txDbWriter := writer.NewAsyncBatchWriter(logger, transaction.NewDbTransactionWriter(repo.GetDB(), logger), "tx_db_writer",
+		writer.WithOnWritten(dirtyWallets.MarkTransactions))
+	txKafkaWriter := writer.NewAsyncBatchWriter(logger, transaction.NewKafkaSmartTxWriter(repo.GetMQ(), logger, cfg.Kafka.TopicSmartTrade), "tx_kafka_writer")
 	latestTrades := NewLatestTradesService(cfg, logger, repo)
 	pairsService := NewTransactionPairsService(cfg, logger, repo)
+	flowSeries := NewSmartFlowSeriesService(cfg, logger, repo)
+	alerts := NewSmartAlertService(cfg, logger, repo)
 	// 初始化后立即启动所有的 AsyncBatchWriter
 	walletDbWriter.Start(context.Background())
 	walletEsWriter.Start(context.Background())
@@ -60,6 +68,8 @@ func NewWalletIndicatorStatistics(cfg config.Config, logger *zap.Logger, repo re
 		txKafkaWriter:  txKafkaWriter,
 		latestTrades:   latestTrades,
 		pairsService:   pairsService,
+		flowSeries:     flowSeries,
+		alerts:         alerts,
 	}
 }
 
@@ -102,8 +112,8 @@ func (s *WalletIndicatorStatistics) Statistics(trade model.TradeEvent, smartMone
 	tx := model.NewWalletTransaction(trade, smartMoney, prevHolding, updatedHolding, txType, fromTokenInfo, toTokenInfo)
 
 	hashKey := trade.Event.TokenAddress
-	s.walletDbWriter.Submit(*smartMoney, hashKey)
-	s.walletEsWriter.Submit(*smartMoney, hashKey)
+	s.walletDbWriter.Submit(*smartMoney)
+	s.walletEsWriter.Submit(*smartMoney)
 	s.txDbWriter.Submit(*tx, hashKey)
 	s.txKafkaWriter.Submit(*tx, hashKey)
 
@@ -117,6 +127,16 @@ func (s *WalletIndicatorStatistics) Statistics(trade model.TradeEvent, smartMone
 		s.pairsService.Record(tx)
 	}
 
+	// 更新 token 聪明钱资金流时间序列（1m/5m/1h）
+	if s.flowSeries != nil {
+		s.flowSeries.Record(tx)
+	}
+
+	// 聪明钱告警（集群买入 / 头部钱包开仓）
+	if s.alerts != nil {
+		s.alerts.Record(tx, smartMoney)
+	}
+
 	// 更新wallet缓存
 	s.daoManager.WalletDAO.UpdateWalletCache(ctx, utils.WalletSummaryKey(smartMoney.ChainID, smartMoney.WalletAddress), smartMoney)
 }
@@ -233,4 +253,5 @@ func (s *WalletIndicatorStatistics) Close() {
 	s.walletDbWriter.Close()
 	//s.walletEsWriter.Close()
 	s.txDbWriter.Close()
+	s.alerts.Close()
 }
@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"time"
 	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/dao"
@@ -12,6 +11,7 @@ import (
 	"web3-smart/internal/worker/writer/holding"
 	missingtokeninfo "web3-smart/internal/worker/writer/missing_tokeninfo"
 	"web3-smart/pkg/utils"
+	holdingUtils "web3-smart/pkg/utils/holding_utils"
 
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
 	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/quotecoin"
@@ -25,19 +25,19 @@ type WalletPositonAnalyze struct {
 	tl              *zap.Logger
 	repo            repository.Repository
 	daoManager      *dao.DAOManager
-	holdingDbWriter *writer.AsyncBatchWriter[model.WalletHolding]
-	//holdingEsWriter *writer.AsyncBatchWriter[model.WalletHolding]
+	holdingDbWriter *writer.CoalescingBatchWriter[model.WalletHolding]
+	//holdingEsWriter *writer.CoalescingBatchWriter[model.WalletHolding]
 	missingTokenInfoWriter *writer.AsyncBatchWriter[model.TradeEvent]
 }
 
 func NewWalletPositonAnalyze(cfg config.Config, logger *zap.Logger, repo repository.Repository) *WalletPositonAnalyze {
-	holdingDbWriter := writer.NewAsyncBatchWriter(logger, holding.NewDbHoldingWriter(repo.GetDB(), logger), 1000, 300*time.Millisecond, "holding_db_writer", 3)
+	holdingDbWriter := writer.NewCoalescingBatchWriter(logger, holding.NewDbHoldingWriter(repo.GetDB(), logger), "holding_db_writer", holdingUtils.HoldingKey)
 	holdingDbWriter.Start(context.Background())
 
-	//holdingEsWriter := writer.NewAsyncBatchWriter(logger, holding.NewESHoldingWriter(repo.GetElasticsearchClient(), logger, cfg.Elasticsearch.HoldingsIndexName), 1000, 300*time.Millisecond, "holding_es_writer", 3)
+	//holdingEsWriter := writer.NewCoalescingBatchWriter(logger, holding.NewESHoldingWriter(repo.GetElasticsearchClient(), logger, cfg.Elasticsearch.HoldingsIndexName), "holding_es_writer", holdingUtils.HoldingKey)
 	//holdingEsWriter.Start(context.Background())
 
-	missingTokenInfoWriter := writer.NewAsyncBatchWriter(logger, missingtokeninfo.NewRedisMissingTokenInfoWriter(repo.GetMainRDB(), logger), 100, 300*time.Millisecond, "missing_tokeninfo_writer", 3)
+	missingTokenInfoWriter := writer.NewAsyncBatchWriter(logger, missingtokeninfo.NewRedisMissingTokenInfoWriter(repo.GetMainRDB(), logger), "missing_tokeninfo_writer")
 	missingTokenInfoWriter.Start(context.Background())
 
 	return &WalletPositonAnalyze{
@@ -127,9 +127,8 @@ func (s *WalletPositonAnalyze) ProcessTrade(trade model.TradeEvent) (smartMoney
 		txType = holding.AggregateTrade(trade, *tokenInfo, isDev, tags)
 	}
 
-	// 异步写入数据库
-	hashKey := fmt.Sprintf("%s_%s", trade.Event.Network, trade.Event.TokenAddress)
-	s.holdingDbWriter.Submit(*holding, hashKey)
+	// 异步写入数据库，同一持仓在 flush 前只保留最新状态
+	s.holdingDbWriter.Submit(*holding)
 	//s.holdingEsWriter.Submit(*holding)
 
 	if smartMoney != nil && tokenInfo.Logo == "" { // 只对系统聪明钱显示负责，如果代币信息中logo为空，则记录到redis，便于后续补全
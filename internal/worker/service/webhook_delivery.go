@@ -0,0 +1,270 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
+
+	"go.uber.org/zap"
+)
+
+// WebhookDeliveryService 将聪明钱交易推送到外部订阅的 HTTPS endpoint
+//
+// 投递流程：
+//  1. Dispatch 按订阅条件过滤，命中的 (订阅, 事件) 投递到内存队列
+//  2. 投递协程发送 POST 请求，请求体为 SmartTxEvent 原始 JSON
+//  3. 非 2xx 或网络错误按指数退避重试，超过最大次数写入 t_smart_webhook_delivery（parked）
+//
+// 签名：
+//
+//	X-Webhook-Timestamp: 毫秒时间戳
+//	X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+//
+// 接收方应校验签名并拒绝时间戳偏差过大的请求以防重放。
+type WebhookDeliveryService struct {
+	cfg        config.Config
+	tl         *zap.Logger
+	repo       repository.Repository
+	httpClient *http.Client
+
+	queue  chan webhookTask
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// webhookTask 单个待投递任务
+type webhookTask struct {
+	sub     *model.WebhookSubscription
+	eventID string
+	payload []byte
+}
+
+func NewWebhookDeliveryService(cfg config.Config, logger *zap.Logger, repo repository.Repository) *WebhookDeliveryService {
+	queueSize := cfg.Webhook.QueueSize
+	if queueSize <= 0 {
+		queueSize = 2000
+	}
+	timeout := time.Duration(cfg.Webhook.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	return &WebhookDeliveryService{
+		cfg:        cfg,
+		tl:         logger,
+		repo:       repo,
+		httpClient: &http.Client{Timeout: timeout},
+		queue:      make(chan webhookTask, queueSize),
+		stopCh:     make(chan struct{}),
+	}
+}
+
+// Start 启动投递协程
+func (s *WebhookDeliveryService) Start() {
+	workers := s.cfg.Webhook.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	for i := 0; i < workers; i++ {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			for {
+				select {
+				case task := <-s.queue:
+					s.deliver(task)
+				case <-s.stopCh:
+					return
+				}
+			}
+		}()
+	}
+}
+
+// Dispatch 按订阅条件过滤并投递一条聪明钱交易
+func (s *WebhookDeliveryService) Dispatch(ctx context.Context, event *model.SmartTxEvent, payload []byte) {
+	subs, err := s.repo.GetDAOManager().WebhookDAO.ListEnabledSubscriptions(ctx)
+	if err != nil {
+		s.tl.Warn("list webhook subscriptions failed", zap.Error(err))
+		return
+	}
+
+	for _, sub := range subs {
+		if !sub.Match(&event.Event) {
+			continue
+		}
+		task := webhookTask{sub: sub, eventID: event.Event.ID, payload: payload}
+		select {
+		case s.queue <- task:
+		default:
+			// 队列已满，直接 parked，避免阻塞消费
+			s.park(task, 0, fmt.Errorf("delivery queue full"))
+		}
+	}
+}
+
+// deliver 带指数退避的投递
+func (s *WebhookDeliveryService) deliver(task webhookTask) {
+	maxAttempts := s.cfg.Webhook.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if lastErr = s.send(task); lastErr == nil {
+			return
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		select {
+		case <-time.After(s.backoff(attempt)):
+		case <-s.stopCh:
+			s.park(task, attempt, fmt.Errorf("service stopped: %w", lastErr))
+			return
+		}
+	}
+
+	s.tl.Warn("webhook delivery failed, parked",
+		zap.Int64("subscription_id", task.sub.ID),
+		zap.String("event_id", task.eventID),
+		zap.Int("attempts", maxAttempts),
+		zap.Error(lastErr))
+	s.park(task, maxAttempts, lastErr)
+}
+
+// backoff 第 attempt 次失败后的等待时间：base * 2^(attempt-1)，不超过 max
+func (s *WebhookDeliveryService) backoff(attempt int) time.Duration {
+	base := time.Duration(s.cfg.Webhook.BackoffBase) * time.Millisecond
+	if base <= 0 {
+		base = time.Second
+	}
+	maxBackoff := time.Duration(s.cfg.Webhook.BackoffMax) * time.Millisecond
+	if maxBackoff <= 0 {
+		maxBackoff = 30 * time.Second
+	}
+
+	d := base << uint(attempt-1)
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// send 发送一次签名请求
+func (s *WebhookDeliveryService) send(task webhookTask) error {
+	ts := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	signed := make([]byte, 0, len(ts)+1+len(task.payload))
+	signed = append(signed, ts...)
+	signed = append(signed, '.')
+	signed = append(signed, task.payload...)
+
+	req, err := http.NewRequest(http.MethodPost, task.sub.URL, bytes.NewReader(task.payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Id", task.eventID)
+	req.Header.Set("X-Webhook-Subscription", strconv.FormatInt(task.sub.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", ts)
+	req.Header.Set("X-Webhook-Signature", "sha256="+utils.HmacSHA256Hex(task.sub.Secret, signed))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// park 记录永久失败的投递，供人工排查与重投
+func (s *WebhookDeliveryService) park(task webhookTask, attempts int, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	delivery := &model.WebhookDelivery{
+		SubscriptionID: task.sub.ID,
+		EventID:        task.eventID,
+		Payload:        string(task.payload),
+		Attempts:       attempts,
+		Status:         model.WEBHOOK_DELIVERY_STATUS_PARKED,
+	}
+	if cause != nil {
+		delivery.LastError = cause.Error()
+	}
+	if err := s.repo.GetDAOManager().WebhookDAO.CreateDelivery(ctx, delivery); err != nil {
+		s.tl.Error("park webhook delivery failed",
+			zap.Int64("subscription_id", task.sub.ID),
+			zap.String("event_id", task.eventID),
+			zap.Error(err))
+	}
+}
+
+// ErrDeliveryNotFound 投递记录或其订阅不存在
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+// Redeliver 手动重投一条 parked 记录（单次发送，不重试）。
+// 记录或订阅不存在时返回 ErrDeliveryNotFound；发送失败时同时返回更新后的记录与发送错误。
+func (s *WebhookDeliveryService) Redeliver(ctx context.Context, deliveryID int64) (*model.WebhookDelivery, error) {
+	webhookDAO := s.repo.GetDAOManager().WebhookDAO
+
+	delivery, err := webhookDAO.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery == nil {
+		return nil, fmt.Errorf("%w: delivery %d", ErrDeliveryNotFound, deliveryID)
+	}
+	sub, err := webhookDAO.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, fmt.Errorf("%w: subscription %d deleted", ErrDeliveryNotFound, delivery.SubscriptionID)
+	}
+
+	delivery.Attempts++
+	sendErr := s.send(webhookTask{sub: sub, eventID: delivery.EventID, payload: []byte(delivery.Payload)})
+	if sendErr != nil {
+		delivery.LastError = sendErr.Error()
+	} else {
+		delivery.Status = model.WEBHOOK_DELIVERY_STATUS_DELIVERED
+	}
+	if err := webhookDAO.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, sendErr
+}
+
+// Close 停止投递协程，队列中尚未投递的任务写入 parked
+func (s *WebhookDeliveryService) Close() {
+	close(s.stopCh)
+	s.wg.Wait()
+
+	for {
+		select {
+		case task := <-s.queue:
+			s.park(task, 0, fmt.Errorf("service stopped before delivery"))
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package stream
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"web3-smart/internal/worker/model"
+
+	"github.com/shopspring/decimal"
+	"gitlab.codetech.pro/web3/chain_data/chain/dex_data_broker/common/bip0044"
+)
+
+const (
+	maxFilterWallets = 200
+	maxFilterTokens  = 50
+)
+
+// supportedChains 未指定 chain_id 时回放的链
+var supportedChains = []uint64{bip0044.SOLANA, bip0044.BSC}
+
+// Filter 单个连接的订阅条件，各条件之间为 AND，同一条件内多个值为 OR，空表示不限
+type Filter struct {
+	chains   map[uint64]struct{}
+	wallets  map[string]struct{}
+	tokens   map[string]struct{}
+	minValue decimal.Decimal
+}
+
+// ParseFilter 从查询参数解析订阅条件
+//
+//	chain_id=501,56  wallets=addr1,addr2  tokens=addr1,addr2  min_value=1000
+func ParseFilter(q url.Values) (*Filter, error) {
+	var chains []uint64
+	for _, v := range splitList(q.Get("chain_id")) {
+		id, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chain_id %q", v)
+		}
+		chains = append(chains, id)
+	}
+	return NewFilter(chains, splitList(q.Get("wallets")), splitList(q.Get("tokens")), q.Get("min_value"))
+}
+
+// NewFilter 由各条件的取值构建订阅条件，minValue 为空表示不限
+func NewFilter(chains []uint64, wallets, tokens []string, minValue string) (*Filter, error) {
+	f := &Filter{
+		chains:  make(map[uint64]struct{}),
+		wallets: make(map[string]struct{}),
+		tokens:  make(map[string]struct{}),
+	}
+
+	for _, id := range chains {
+		f.chains[id] = struct{}{}
+	}
+	for _, v := range wallets {
+		f.wallets[normalizeAddress(v)] = struct{}{}
+	}
+	if len(f.wallets) > maxFilterWallets {
+		return nil, fmt.Errorf("too many wallets, max %d", maxFilterWallets)
+	}
+	for _, v := range tokens {
+		f.tokens[normalizeAddress(v)] = struct{}{}
+	}
+	if len(f.tokens) > maxFilterTokens {
+		return nil, fmt.Errorf("too many tokens, max %d", maxFilterTokens)
+	}
+	if minValue != "" {
+		d, err := decimal.NewFromString(minValue)
+		if err != nil || d.IsNegative() {
+			return nil, fmt.Errorf("invalid min_value %q", minValue)
+		}
+		f.minValue = d
+	}
+	return f, nil
+}
+
+// Match 判断成交是否满足订阅条件
+func (f *Filter) Match(e *model.SmartTxEventDetails) bool {
+	if len(f.chains) > 0 {
+		if _, ok := f.chains[e.ChainID]; !ok {
+			return false
+		}
+	}
+	if len(f.wallets) > 0 {
+		if _, ok := f.wallets[normalizeAddress(e.WalletAddress)]; !ok {
+			return false
+		}
+	}
+	if len(f.tokens) > 0 {
+		if _, ok := f.tokens[normalizeAddress(e.TokenAddress)]; !ok {
+			return false
+		}
+	}
+	if !f.minValue.IsZero() && e.Value.LessThan(f.minValue) {
+		return false
+	}
+	return true
+}
+
+// Chains 回放时需要读取的链
+func (f *Filter) Chains() []uint64 {
+	if len(f.chains) == 0 {
+		return supportedChains
+	}
+	chains := make([]uint64, 0, len(f.chains))
+	for id := range f.chains {
+		chains = append(chains, id)
+	}
+	return chains
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	parts := strings.Split(v, ",")
+	out := parts[:0]
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// normalizeAddress EVM 地址统一小写
+func normalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") || strings.HasPrefix(address, "0X") {
+		return strings.ToLower(address)
+	}
+	return address
+}
@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"errors"
+	"web3-smart/internal/worker/model"
+	"web3-smart/pkg/smartmoneypb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const transportGRPC = "grpc"
+
+// StreamSmartTrades gRPC 推送，订阅条件与 since 语义同 WebSocket / SSE；
+// 连接保活由 gRPC keepalive 负责，慢消费者被断开时返回 RESOURCE_EXHAUSTED，客户端带 since 重连
+func (s *Server) StreamSmartTrades(req *smartmoneypb.StreamSmartTradesRequest, ss grpc.ServerStreamingServer[smartmoneypb.SmartTrade]) error {
+	f, err := NewFilter(req.GetChainIds(), req.GetWalletAddresses(), req.GetTokenAddresses(), req.GetMinValue())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sub, err := s.register(ss.Context(), f, req.GetSince(), transportGRPC)
+	if err != nil {
+		code := codes.Unavailable
+		if errors.Is(err, ErrTooManyConnections) {
+			code = codes.ResourceExhausted
+		}
+		return status.Error(code, err.Error())
+	}
+	c := sub.client
+	defer s.hub.unregister(c, transportGRPC)
+
+	for _, m := range sub.backfill {
+		if err := ss.Send(smartTradeToPB(m.event)); err != nil {
+			c.close(closeReasonClientGone)
+			return err
+		}
+	}
+
+	for {
+		select {
+		case m := <-c.send:
+			if sub.skip(m) {
+				continue
+			}
+			if err := ss.Send(smartTradeToPB(m.event)); err != nil {
+				c.close(closeReasonClientGone)
+				return err
+			}
+		case <-ss.Context().Done():
+			c.close(closeReasonClientGone)
+			return nil
+		case <-c.done:
+			s.tl.Debug("closing stream connection", zap.String("transport", transportGRPC), zap.String("reason", c.reason))
+			if c.reason == closeReasonSlowConsumer {
+				return status.Error(codes.ResourceExhausted, c.reason)
+			}
+			return status.Error(codes.Unavailable, c.reason)
+		}
+	}
+}
+
+func smartTradeToPB(e *model.SmartTxEventDetails) *smartmoneypb.SmartTrade {
+	return &smartmoneypb.SmartTrade{
+		Id:                       e.ID,
+		ChainId:                  e.ChainID,
+		WalletAddress:            e.WalletAddress,
+		WalletBalance:            e.WalletBalance.String(),
+		TokenAddress:             e.TokenAddress,
+		TokenName:                e.TokenName,
+		TokenIcon:                e.TokenIcon,
+		TransactionType:          e.TransactionType,
+		TransactionTime:          e.TransactionTime,
+		Signature:                e.Signature,
+		LogIndex:                 int32(e.LogIndex),
+		Price:                    e.Price.String(),
+		Amount:                   e.Amount.String(),
+		Value:                    e.Value.String(),
+		Marketcap:                e.MarketCap.String(),
+		HoldingPercentage:        e.HoldingPercentage.String(),
+		RealizedProfit:           e.RealizedProfit.String(),
+		RealizedProfitPercentage: e.RealizedProfitPercentage.String(),
+		FromTokenAddress:         e.FromTokenAddress,
+		FromTokenSymbol:          e.FromTokenSymbol,
+		FromTokenAmount:          e.FromTokenAmount.String(),
+		DestTokenAddress:         e.DestTokenAddress,
+		DestTokenSymbol:          e.DestTokenSymbol,
+		DestTokenAmount:          e.DestTokenAmount.String(),
+	}
+}
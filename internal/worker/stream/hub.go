@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/monitor"
+
+	"go.uber.org/zap"
+)
+
+var (
+	ErrTooManyConnections = errors.New("too many connections")
+	ErrHubClosed          = errors.New("server shutting down")
+)
+
+// 连接关闭原因
+const (
+	closeReasonSlowConsumer = "slow consumer"
+	closeReasonShutdown     = "server shutdown"
+	closeReasonClientGone   = "client closed"
+)
+
+// message 一条待推送的成交
+type message struct {
+	id    string                     // SmartTxEvent.event.id，用于回放与实时数据去重
+	ts    int64                      // 成交时间（毫秒）
+	data  []byte                     // 原始 SmartTxEvent JSON，与 Kafka / latest_trades zset 中的内容一致，WebSocket / SSE 直接推送
+	event *model.SmartTxEventDetails // 解析后的成交，gRPC 推送时转换为 SmartTrade
+}
+
+// client 一个订阅连接，send 为有界队列：写满说明客户端消费不过来，直接断开，由客户端带 since 重连补数据
+type client struct {
+	filter *Filter
+	send   chan *message
+	done   chan struct{}
+
+	closeOnce sync.Once
+	reason    string
+}
+
+func (c *client) close(reason string) {
+	c.closeOnce.Do(func() {
+		c.reason = reason
+		close(c.done)
+	})
+}
+
+// Hub 将 Kafka 消费到的成交分发给所有匹配的连接
+type Hub struct {
+	tl         *zap.Logger
+	sendBuffer int
+	maxConns   int
+
+	mu      sync.RWMutex
+	clients map[*client]struct{}
+	closed  bool
+}
+
+func NewHub(cfg config.StreamConfig, logger *zap.Logger) *Hub {
+	sendBuffer := cfg.SendBuffer
+	if sendBuffer <= 0 {
+		sendBuffer = 256
+	}
+	return &Hub{
+		tl:         logger,
+		sendBuffer: sendBuffer,
+		maxConns:   cfg.MaxConnections,
+		clients:    make(map[*client]struct{}),
+	}
+}
+
+// Publish 分发一条成交，不阻塞：队列已满的连接会被断开
+func (h *Hub) Publish(event *model.SmartTxEvent, raw []byte) {
+	m := &message{id: event.Event.ID, ts: event.Event.TransactionTime, data: raw, event: &event.Event}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for c := range h.clients {
+		if !c.filter.Match(&event.Event) {
+			continue
+		}
+		select {
+		case c.send <- m:
+		case <-c.done:
+		default:
+			c.close(closeReasonSlowConsumer)
+			monitor.StreamClientsDropped.Inc()
+		}
+	}
+}
+
+// register 注册连接，超过最大连接数时返回 ErrTooManyConnections
+func (h *Hub) register(f *Filter, transport string) (*client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+	if h.maxConns > 0 && len(h.clients) >= h.maxConns {
+		return nil, ErrTooManyConnections
+	}
+	c := &client{
+		filter: f,
+		send:   make(chan *message, h.sendBuffer),
+		done:   make(chan struct{}),
+	}
+	h.clients[c] = struct{}{}
+	monitor.StreamConnections.WithLabelValues(transport).Inc()
+	return c, nil
+}
+
+func (h *Hub) unregister(c *client, transport string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; ok {
+		delete(h.clients, c)
+		monitor.StreamConnections.WithLabelValues(transport).Dec()
+	}
+}
+
+// Close 断开所有连接并拒绝新连接
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for c := range h.clients {
+		c.close(closeReasonShutdown)
+	}
+	h.tl.Info("stream hub closed", zap.Int("clients", len(h.clients)))
+}
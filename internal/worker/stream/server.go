@@ -0,0 +1,195 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+	"web3-smart/internal/worker/config"
+	"web3-smart/internal/worker/model"
+	"web3-smart/internal/worker/repository"
+	"web3-smart/pkg/utils"
+
+	"github.com/bytedance/sonic"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// 聪明钱成交实时推送网关
+//
+//	GET /stream/ws?chain_id=&wallets=&tokens=&min_value=&since=   WebSocket，每条成交一个文本帧
+//	GET /stream/sse?chain_id=&wallets=&tokens=&min_value=&since=  Server-Sent Events，event: trade
+//
+// 推送内容与 Kafka topic_smart_trade 的 SmartTxEvent JSON 一致。
+// since（毫秒，含边界）或 SSE 的 Last-Event-ID 用于断线续传：先从 latest_trades zset 回放，再接实时数据；
+// zset 每条链只保留最近 50 条，更早的数据不补；边界上可能重复，客户端按 event.id 去重。
+
+const writeWait = 10 * time.Second
+
+// Server 推送网关 HTTP 服务
+type Server struct {
+	cfg       config.StreamConfig
+	tl        *zap.Logger
+	repo      repository.Repository
+	hub       *Hub
+	heartbeat time.Duration
+	server    *http.Server
+}
+
+func NewServer(cfg config.Config, logger *zap.Logger, repo repository.Repository, hub *Hub) *Server {
+	heartbeat := time.Duration(cfg.Stream.Heartbeat) * time.Second
+	if heartbeat <= 0 {
+		heartbeat = 20 * time.Second
+	}
+	s := &Server{
+		cfg:       cfg.Stream,
+		tl:        logger,
+		repo:      repo,
+		hub:       hub,
+		heartbeat: heartbeat,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /stream/ws", s.serveWS)
+	mux.HandleFunc("GET /stream/sse", s.serveSSE)
+
+	// 长连接不设置 WriteTimeout，写超时由每次写入单独控制
+	s.server = &http.Server{
+		Addr:              cfg.Stream.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Run 启动推送网关
+func (s *Server) Run() {
+	go func() {
+		s.tl.Info("stream server listening", zap.String("addr", s.cfg.Addr))
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			s.tl.Error("stream server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Stop 先断开所有长连接，再关闭 HTTP 服务
+func (s *Server) Stop(ctx context.Context) error {
+	s.hub.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return s.server.Shutdown(shutdownCtx)
+}
+
+// subscription 一个已注册连接及其回放数据
+type subscription struct {
+	client   *client
+	backfill []*message
+	seen     map[string]struct{} // 回放过的成交 id，用于跳过排队中的重复实时数据
+	lastTs   int64               // 回放数据的最大成交时间，实时数据超过后不再去重
+}
+
+// skip 实时数据是否已在回放中推送过
+func (sub *subscription) skip(m *message) bool {
+	if sub.seen == nil {
+		return false
+	}
+	if m.ts > sub.lastTs {
+		sub.seen = nil
+		return false
+	}
+	_, ok := sub.seen[m.id]
+	return ok
+}
+
+// subscribe 从 HTTP 请求解析订阅条件并注册连接
+func (s *Server) subscribe(r *http.Request, transport string) (*subscription, int, error) {
+	f, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var since int64
+	if v := r.URL.Query().Get("since"); v != "" {
+		if since, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, http.StatusBadRequest, errors.New("invalid since")
+		}
+	} else if v := r.Header.Get("Last-Event-ID"); v != "" {
+		since, _ = strconv.ParseInt(v, 10, 64)
+	}
+
+	sub, err := s.register(r.Context(), f, since, transport)
+	if err != nil {
+		return nil, http.StatusServiceUnavailable, err
+	}
+	return sub, http.StatusOK, nil
+}
+
+// register 注册连接并加载 since 之后的回放数据；先注册再回放，保证回放期间的实时数据不丢
+func (s *Server) register(ctx context.Context, f *Filter, since int64, transport string) (*subscription, error) {
+	c, err := s.hub.register(f, transport)
+	if err != nil {
+		return nil, err
+	}
+	sub := &subscription{client: c}
+	if since <= 0 {
+		return sub, nil
+	}
+
+	sub.backfill, err = s.loadBackfill(ctx, f, since)
+	if err != nil {
+		// 回放失败不影响实时推送
+		s.tl.Warn("load stream backfill failed", zap.Int64("since", since), zap.Error(err))
+		return sub, nil
+	}
+	sub.seen = make(map[string]struct{}, len(sub.backfill))
+	for _, m := range sub.backfill {
+		sub.seen[m.id] = struct{}{}
+		if m.ts > sub.lastTs {
+			sub.lastTs = m.ts
+		}
+	}
+	return sub, nil
+}
+
+// loadBackfill 从 latest_trades zset 读取 since 之后（含）的成交，按时间升序
+func (s *Server) loadBackfill(ctx context.Context, f *Filter, since int64) ([]*message, error) {
+	rdb := s.repo.GetMetricsRDB()
+	if rdb == nil {
+		return nil, nil
+	}
+
+	var msgs []*message
+	for _, chainID := range f.Chains() {
+		members, err := rdb.ZRangeByScore(ctx, utils.LatestTradesKey(chainID), &redis.ZRangeBy{
+			Min: strconv.FormatInt(since, 10),
+			Max: "+inf",
+		}).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			var event model.SmartTxEvent
+			if err := sonic.Unmarshal([]byte(member), &event); err != nil {
+				continue
+			}
+			if !f.Match(&event.Event) {
+				continue
+			}
+			msgs = append(msgs, &message{id: event.Event.ID, ts: event.Event.TransactionTime, data: []byte(member), event: &event.Event})
+		}
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].ts < msgs[j].ts })
+	return msgs, nil
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	data, _ := sonic.Marshal(map[string]string{"error": msg})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
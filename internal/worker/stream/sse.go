@@ -0,0 +1,85 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const transportSSE = "sse"
+
+// serveSSE Server-Sent Events 推送，id 为成交时间（毫秒），浏览器重连时通过 Last-Event-ID 自动续传
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request) {
+	if _, ok := w.(http.Flusher); !ok {
+		writeError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	sub, status, err := s.subscribe(r, transportSSE)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	c := sub.client
+	defer s.hub.unregister(c, transportSSE)
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flush := func(format string, args ...interface{}) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(writeWait))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			c.close(closeReasonClientGone)
+			return false
+		}
+		if err := rc.Flush(); err != nil {
+			c.close(closeReasonClientGone)
+			return false
+		}
+		return true
+	}
+	write := func(m *message) bool {
+		return flush("id: %d\nevent: trade\ndata: %s\n\n", m.ts, m.data)
+	}
+
+	if !flush("retry: 3000\n\n") {
+		return
+	}
+	for _, m := range sub.backfill {
+		if !write(m) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case m := <-c.send:
+			if sub.skip(m) {
+				continue
+			}
+			if !write(m) {
+				return
+			}
+		case <-ticker.C:
+			if !flush(": ping\n\n") {
+				return
+			}
+		case <-r.Context().Done():
+			c.close(closeReasonClientGone)
+			return
+		case <-c.done:
+			if c.reason != closeReasonClientGone {
+				s.tl.Debug("closing stream connection", zap.String("transport", transportSSE), zap.String("reason", c.reason))
+				flush("event: close\ndata: {\"reason\":%q}\n\n", c.reason)
+			}
+			return
+		}
+	}
+}
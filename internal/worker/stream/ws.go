@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+)
+
+const transportWS = "ws"
+
+// serveWS WebSocket 推送，服务端定时发送 ping，客户端超过两个心跳周期没有 pong 即断开
+func (s *Server) serveWS(w http.ResponseWriter, r *http.Request) {
+	sub, status, err := s.subscribe(r, transportWS)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	c := sub.client
+	defer s.hub.unregister(c, transportWS)
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     s.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade 已写回错误响应
+		return
+	}
+	defer conn.Close()
+
+	// 客户端不需要发送数据，读协程只处理 pong / close
+	pongWait := 2 * s.heartbeat
+	go func() {
+		conn.SetReadLimit(1024)
+		_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(pongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				c.close(closeReasonClientGone)
+				return
+			}
+		}
+	}()
+
+	write := func(m *message) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteMessage(websocket.TextMessage, m.data); err != nil {
+			c.close(closeReasonClientGone)
+			return false
+		}
+		return true
+	}
+
+	for _, m := range sub.backfill {
+		if !write(m) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case m := <-c.send:
+			if sub.skip(m) {
+				continue
+			}
+			if !write(m) {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close(closeReasonClientGone)
+				return
+			}
+		case <-c.done:
+			if c.reason != closeReasonClientGone {
+				s.tl.Debug("closing stream connection", zap.String("transport", transportWS), zap.String("reason", c.reason))
+				code := websocket.CloseGoingAway
+				if c.reason == closeReasonSlowConsumer {
+					code = websocket.CloseTryAgainLater
+				}
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, c.reason), time.Now().Add(writeWait))
+			}
+			return
+		}
+	}
+}
+
+// checkOrigin 未配置 allowed_origins 时不校验
+func (s *Server) checkOrigin(r *http.Request) bool {
+	if len(s.cfg.AllowedOrigins) == 0 {
+		return true
+	}
+	origin := r.Header.Get("Origin")
+	for _, o := range s.cfg.AllowedOrigins {
+		if o == origin {
+			return true
+		}
+	}
+	return false
+}
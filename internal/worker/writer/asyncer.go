@@ -2,51 +2,233 @@ package writer
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
+	"web3-smart/internal/worker/config"
 	"web3-smart/internal/worker/monitor"
 	"web3-smart/pkg/utils"
 
+	"github.com/bytedance/sonic"
 	"go.uber.org/zap"
 )
 
+// queues 所有 AsyncBatchWriter 的队列使用率，供 readiness 检查
+var queues sync.Map // id -> func() float64
+
+// registerQueue 登记 writer 队列；id 同时决定指标标签与落盘文件名，重复创建同 id 的 writer 会让两个实例
+// 争用同一落盘文件并互相重放，视为编码错误直接 panic。writer Close 后可用同一 id 重新创建。
+func registerQueue(id string, saturation func() float64) {
+	if _, loaded := queues.LoadOrStore(id, saturation); loaded {
+		panic(fmt.Sprintf("writer: duplicate writer id %q", id))
+	}
+}
+
+// QueueSaturation 返回各 writer 的队列使用率（0-1，取最满的 worker 队列）
+func QueueSaturation() map[string]float64 {
+	out := make(map[string]float64)
+	queues.Range(func(key, value interface{}) bool {
+		out[key.(string)] = value.(func() float64)()
+		return true
+	})
+	return out
+}
+
+// writerConfig 异步 writer 配置，由 Configure 在启动时设置，对之后创建的 writer 生效
+var writerConfig = config.AsyncWriterConfig{
+	MaxRetries:     5,
+	RetryBackoff:   500,
+	MaxBackoff:     30000,
+	RetryQueueSize: 100,
+	ReplayInterval: 30,
+	Defaults: config.AsyncWriterQueueConfig{
+		QueueSize:     2000,
+		BatchSize:     1000,
+		FlushInterval: 300,
+		Workers:       1,
+		Policy:        string(PolicyDropNewest),
+		BlockTimeout:  1000,
+	},
+}
+
+// Configure 设置队列参数及写入失败的重试与落盘参数，需在创建 writer 之前调用；未设置的字段保留默认值
+func Configure(cfg config.AsyncWriterConfig) {
+	failureConfig := &writerConfig
+	if cfg.MaxRetries > 0 {
+		failureConfig.MaxRetries = cfg.MaxRetries
+	}
+	if cfg.RetryBackoff > 0 {
+		failureConfig.RetryBackoff = cfg.RetryBackoff
+	}
+	if cfg.MaxBackoff > 0 {
+		failureConfig.MaxBackoff = cfg.MaxBackoff
+	}
+	if cfg.RetryQueueSize > 0 {
+		failureConfig.RetryQueueSize = cfg.RetryQueueSize
+	}
+	if cfg.ReplayInterval > 0 {
+		failureConfig.ReplayInterval = cfg.ReplayInterval
+	}
+	failureConfig.SpillDir = cfg.SpillDir
+
+	writerConfig.Defaults = mergeQueueConfig(writerConfig.Defaults, cfg.Defaults)
+	writerConfig.Writers = cfg.Writers
+}
+
+// mergeQueueConfig 用 override 中已设置的字段覆盖 base
+func mergeQueueConfig(base, override config.AsyncWriterQueueConfig) config.AsyncWriterQueueConfig {
+	if override.QueueSize > 0 {
+		base.QueueSize = override.QueueSize
+	}
+	if override.BatchSize > 0 {
+		base.BatchSize = override.BatchSize
+	}
+	if override.FlushInterval > 0 {
+		base.FlushInterval = override.FlushInterval
+	}
+	if override.Workers > 0 {
+		base.Workers = override.Workers
+	}
+	if override.Policy != "" {
+		base.Policy = override.Policy
+	}
+	if override.BlockTimeout > 0 {
+		base.BlockTimeout = override.BlockTimeout
+	}
+	return base
+}
+
+// Option AsyncBatchWriter 可选项
+type Option[T any] func(*AsyncBatchWriter[T])
+
+// WithOnWritten 批次成功写入下游后回调（含重试与落盘重放），在写入协程中同步执行，不应阻塞过久
+func WithOnWritten[T any](fn func(batch []T)) Option[T] {
+	return func(b *AsyncBatchWriter[T]) {
+		b.onWritten = fn
+	}
+}
+
+// WithKeyFunc 设置条目的业务唯一键（如持仓的 钱包+代币+链），coalesce 策略按此合并。
+// hashKey 只用于分桶，可能多个实体共用，不能作为合并依据；未设置时 coalesce 退化为 drop_newest。
+func WithKeyFunc[T any](fn func(T) string) Option[T] {
+	return func(b *AsyncBatchWriter[T]) {
+		b.keyFunc = fn
+	}
+}
+
+// AsyncBatchWriter 按 hashKey 分桶、攒批异步写入
+//
+// 队列长度、攒批参数、worker 数与队列满时的策略取自配置 async_writer.writers.<id>，
+// 未配置的字段取 async_writer.defaults。
+//
+// 写入失败的批次进入重试队列按指数退避重试；重试耗尽（或重试队列已满、writer 关闭）时
+// 追加写入本地落盘文件，由重放协程在重试队列清空后定期重放。
+// 重放是至少一次语义，且晚于之后的新数据写入，upsert 类下游可能被旧快照短暂覆盖，
+// 由后续交易或定时重算修正；持仓与钱包的 DB writer 按 last_transaction_time 拒绝旧快照。
 type AsyncBatchWriter[T any] struct {
 	id            string
 	workers       int
 	tl            *zap.Logger
 	writer        BatchWriter[T]
-	inputChans    []chan T
+	queues        []*workerQueue[T]
 	wg            sync.WaitGroup
 	batchSize     int
 	flushInterval time.Duration
+
+	// 队列满时的处理
+	policy       BackpressurePolicy
+	blockTimeout time.Duration
+	keyFunc      func(T) string
+	onWritten    func(batch []T) // 成功写入后回调，可为 nil
+	lastDropLog  atomic.Int64    // 最近一次丢弃日志的秒级时间戳
+
+	// 写入失败处理
+	retry   config.AsyncWriterConfig
+	retryCh chan []T
+	spill   *spillFile // 为 nil 时重试耗尽直接丢弃
+	closing chan struct{}
+	failWg  sync.WaitGroup // 重试与重放协程
 }
 
-func NewAsyncBatchWriter[T any](tl *zap.Logger, writer BatchWriter[T], batchSize int, flushInterval time.Duration, id string, workers int) *AsyncBatchWriter[T] {
+func NewAsyncBatchWriter[T any](tl *zap.Logger, writer BatchWriter[T], id string, opts ...Option[T]) *AsyncBatchWriter[T] {
+	qc := mergeQueueConfig(writerConfig.Defaults, writerConfig.Writers[id])
 	a := &AsyncBatchWriter[T]{
 		id:            id,
-		workers:       workers,
+		workers:       qc.Workers,
 		tl:            tl,
 		writer:        writer,
-		batchSize:     batchSize,
-		flushInterval: flushInterval,
+		batchSize:     qc.BatchSize,
+		flushInterval: time.Duration(qc.FlushInterval) * time.Millisecond,
+		blockTimeout:  time.Duration(qc.BlockTimeout) * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
 
-	a.inputChans = make([]chan T, workers)
+	policy, ok := parsePolicy(qc.Policy)
+	if !ok {
+		tl.Warn("unknown async writer policy, fallback to drop_newest", zap.String("id", id), zap.String("policy", qc.Policy))
+	}
+	if policy == PolicyCoalesce && a.keyFunc == nil {
+		tl.Warn("coalesce policy requires a key func, fallback to drop_newest", zap.String("id", id))
+		policy = PolicyDropNewest
+	}
+	a.policy = policy
 
-	chanSize := 2000
-	for i := 0; i < workers; i++ {
-		a.inputChans[i] = make(chan T, chanSize)
+	a.queues = make([]*workerQueue[T], a.workers)
+	for i := 0; i < a.workers; i++ {
+		a.queues[i] = newWorkerQueue[T](qc.QueueSize)
 	}
+	registerQueue(id, a.saturation)
 
+	a.initFailure()
 	return a
 }
 
+// initFailure 初始化重试队列与落盘文件
+func (b *AsyncBatchWriter[T]) initFailure() {
+	b.retry = writerConfig
+	b.retryCh = make(chan []T, b.retry.RetryQueueSize)
+	b.closing = make(chan struct{})
+	if b.retry.SpillDir != "" {
+		spill, err := newSpillFile(b.retry.SpillDir, b.id)
+		if err != nil {
+			b.tl.Error("create spill file failed, failed batches will be dropped after retries", zap.String("id", b.id), zap.Error(err))
+		} else {
+			b.spill = spill
+		}
+	}
+}
+
+// saturation 最满的 worker 队列使用率
+func (b *AsyncBatchWriter[T]) saturation() float64 {
+	var max float64
+	for _, q := range b.queues {
+		if r := float64(len(q.ch)) / float64(cap(q.ch)); r > max {
+			max = r
+		}
+	}
+	return max
+}
+
 func (b *AsyncBatchWriter[T]) Start(ctx context.Context) {
 	for i := 0; i < b.workers; i++ {
 		b.wg.Add(1)
 		workerID := i
 		go b.processItems(ctx, workerID)
 	}
+	b.startFailure(ctx)
+}
+
+// startFailure 启动重试与重放协程
+func (b *AsyncBatchWriter[T]) startFailure(ctx context.Context) {
+	b.failWg.Add(1)
+	go b.retryLoop(ctx)
+	if b.spill != nil {
+		b.failWg.Add(1)
+		go b.replayLoop(ctx)
+	}
 }
 
 func (b *AsyncBatchWriter[T]) processItems(ctx context.Context, workerID int) {
@@ -54,44 +236,91 @@ func (b *AsyncBatchWriter[T]) processItems(ctx context.Context, workerID int) {
 	ticker := time.NewTicker(b.flushInterval)
 	defer ticker.Stop()
 
+	q := b.queues[workerID]
 	var batch = make([]T, 0, b.batchSize)
+	add := func(items ...T) {
+		for _, item := range items {
+			batch = append(batch, item)
+			if len(batch) >= b.batchSize {
+				b.writeAndRecord(ctx, batch)
+				batch = make([]T, 0, b.batchSize)
+			}
+		}
+	}
+	flush := func() {
+		if len(batch) > 0 {
+			b.writeAndRecord(ctx, batch)
+			batch = make([]T, 0, b.batchSize)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			if len(batch) > 0 {
-				b.writeAndRecord(ctx, batch)
-			}
+			add(q.drainOverflow()...)
+			flush()
 			return
-		case item, ok := <-b.inputChans[workerID]:
+		case item, ok := <-q.ch:
 			if !ok {
+				// Close 后写完已攒的批次及溢出区
+				add(q.drainOverflow()...)
+				flush()
 				return
 			}
-			batch = append(batch, item)
-			if len(batch) >= b.batchSize {
-				b.writeAndRecord(ctx, batch)
-				batch = make([]T, 0, b.batchSize)
+			add(item)
+			// 溢出区条目都晚于通道中的条目提交，通道读空后再取
+			if len(q.ch) == 0 {
+				add(q.drainOverflow()...)
 			}
 			ticker.Reset(b.flushInterval) // 重置ticker 避免出现小数据提交
 		case <-ticker.C:
-			if len(batch) > 0 {
-				b.writeAndRecord(ctx, batch)
-				batch = make([]T, 0, b.batchSize)
+			if len(q.ch) == 0 {
+				add(q.drainOverflow()...)
 			}
+			flush()
+			b.reportDepth()
 		}
 	}
 }
 
-// 封装写入操作并记录指标
+// reportDepth 上报队列深度（含 coalesce 溢出区）
+func (b *AsyncBatchWriter[T]) reportDepth() {
+	var depth int
+	for _, q := range b.queues {
+		depth += q.depth()
+	}
+	monitor.AsyncWriterQueueDepth.WithLabelValues(b.id).Set(float64(depth))
+}
+
+// 封装写入操作并记录指标，失败交给重试队列
 func (b *AsyncBatchWriter[T]) writeAndRecord(ctx context.Context, batch []T) {
+	if err := b.write(ctx, batch); err != nil {
+		b.tl.Warn("batch write failed, queued for retry", zap.String("id", b.id), zap.Int("size", len(batch)), zap.Error(err))
+		b.enqueueRetry(batch)
+	}
+	b.reportDepth()
+}
+
+// written 成功写入后的回调
+func (b *AsyncBatchWriter[T]) written(batch []T) {
+	if b.onWritten != nil {
+		b.onWritten(batch)
+	}
+}
+
+// write 写入一个批次并记录指标
+func (b *AsyncBatchWriter[T]) write(ctx context.Context, batch []T) error {
 	startTime := time.Now()
 	size := len(batch)
 
 	// 记录 batch size
 	monitor.AsyncWriterBatchSize.WithLabelValues(b.id).Observe(float64(size))
-	monitor.AsyncWriterItemsWritten.WithLabelValues(b.id).Add(float64(size))
 
-	// 执行写入
-	_ = b.writer.BWrite(ctx, batch)
+	err := b.writer.BWrite(ctx, batch)
+	if err == nil {
+		monitor.AsyncWriterItemsWritten.WithLabelValues(b.id).Add(float64(size))
+		b.written(batch)
+	}
 
 	// 统计耗时
 	elapsed := time.Since(startTime).Seconds()
@@ -99,33 +328,159 @@ func (b *AsyncBatchWriter[T]) writeAndRecord(ctx context.Context, batch []T) {
 
 	// flush 次数统计
 	monitor.AsyncWriterFlushCount.WithLabelValues(b.id).Inc()
+	return err
 }
 
-// Submit 非阻塞
+// Submit 按配置的策略提交，除 block 策略外不阻塞
 func (b *AsyncBatchWriter[T]) Submit(item T, hashKey string) {
 	idx := utils.GetHashBucket(hashKey, uint32(b.workers))
-
-	for {
-		select {
-		case b.inputChans[idx] <- item:
-			return
-		default:
-			b.tl.Warn("Batch input channel submit timeout, dropping item", zap.String("id", b.id))
-			return
-		}
+	if b.submit(b.queues[idx], item) {
+		monitor.AsyncWriterMessagesQueued.WithLabelValues(b.id).Inc()
 	}
 }
 
-// MustSubmit 通道满时阻塞提交
+// MustSubmit 通道满时阻塞提交，不受策略影响
 func (b *AsyncBatchWriter[T]) MustSubmit(item T, hashKey string) {
 	idx := utils.GetHashBucket(hashKey, uint32(b.workers))
-	b.inputChans[idx] <- item
+	b.queues[idx].ch <- item
+	monitor.AsyncWriterMessagesQueued.WithLabelValues(b.id).Inc()
 }
 
 func (b *AsyncBatchWriter[T]) Close() {
-	for _, inputChan := range b.inputChans {
-		close(inputChan)
+	queues.Delete(b.id)
+	for _, q := range b.queues {
+		close(q.ch)
 	}
 	b.wg.Wait()
+	b.closeFailure()
+}
+
+// closeFailure 在写入 worker 全部退出后调用，不会再有新的失败批次；等待重试的批次直接落盘
+func (b *AsyncBatchWriter[T]) closeFailure() {
+	close(b.closing)
+	close(b.retryCh)
+	b.failWg.Wait()
 	_ = b.writer.Close()
 }
+
+// enqueueRetry 失败批次进入重试队列，队列已满时直接落盘，不阻塞写入 worker
+func (b *AsyncBatchWriter[T]) enqueueRetry(batch []T) {
+	if b.retry.MaxRetries <= 0 {
+		b.spillBatch(batch)
+		return
+	}
+	select {
+	case b.retryCh <- batch:
+		monitor.AsyncWriterRetryPending.WithLabelValues(b.id).Add(float64(len(batch)))
+	default:
+		b.tl.Warn("retry queue full, spilling batch", zap.String("id", b.id), zap.Int("size", len(batch)))
+		b.spillBatch(batch)
+	}
+}
+
+// retryLoop 按入队顺序逐个重试失败批次；下游故障时所有批次都会失败，串行重试即可
+func (b *AsyncBatchWriter[T]) retryLoop(ctx context.Context) {
+	defer b.failWg.Done()
+	for batch := range b.retryCh {
+		b.retryBatch(ctx, batch)
+		monitor.AsyncWriterRetryPending.WithLabelValues(b.id).Sub(float64(len(batch)))
+	}
+}
+
+func (b *AsyncBatchWriter[T]) retryBatch(ctx context.Context, batch []T) {
+	backoff := time.Duration(b.retry.RetryBackoff) * time.Millisecond
+	maxBackoff := time.Duration(b.retry.MaxBackoff) * time.Millisecond
+
+	for attempt := 1; attempt <= b.retry.MaxRetries; attempt++ {
+		select {
+		case <-time.After(backoff):
+		case <-b.closing:
+			b.spillBatch(batch)
+			return
+		case <-ctx.Done():
+			b.spillBatch(batch)
+			return
+		}
+
+		monitor.AsyncWriterRetries.WithLabelValues(b.id).Inc()
+		err := b.writer.BWrite(ctx, batch)
+		if err == nil {
+			monitor.AsyncWriterItemsWritten.WithLabelValues(b.id).Add(float64(len(batch)))
+			b.written(batch)
+			return
+		}
+		b.tl.Warn("batch retry failed", zap.String("id", b.id), zap.Int("attempt", attempt), zap.Error(err))
+		backoff = min(backoff*2, maxBackoff)
+	}
+	b.spillBatch(batch)
+}
+
+// spillBatch 追加写入落盘文件，未启用落盘或写文件失败时丢弃并计数
+func (b *AsyncBatchWriter[T]) spillBatch(batch []T) {
+	if b.spill == nil {
+		b.tl.Error("batch dropped after retries, spill disabled", zap.String("id", b.id), zap.Int("size", len(batch)))
+		monitor.AsyncWriterItemsLost.WithLabelValues(b.id).Add(float64(len(batch)))
+		return
+	}
+
+	data, err := sonic.Marshal(batch)
+	if err == nil {
+		err = b.spill.append(data)
+	}
+	if err != nil {
+		b.tl.Error("spill batch failed, batch dropped", zap.String("id", b.id), zap.Int("size", len(batch)), zap.Error(err))
+		monitor.AsyncWriterItemsLost.WithLabelValues(b.id).Add(float64(len(batch)))
+		return
+	}
+	monitor.AsyncWriterItemsSpilled.WithLabelValues(b.id).Add(float64(len(batch)))
+	monitor.AsyncWriterSpillPendingBytes.WithLabelValues(b.id).Set(float64(b.spill.pendingBytes()))
+}
+
+// replayLoop 启动时及之后定期重放落盘数据，包括上次进程遗留的
+func (b *AsyncBatchWriter[T]) replayLoop(ctx context.Context) {
+	defer b.failWg.Done()
+	ticker := time.NewTicker(time.Duration(b.retry.ReplayInterval) * time.Second)
+	defer ticker.Stop()
+
+	b.replaySpilled(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			b.replaySpilled(ctx)
+		case <-b.closing:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (b *AsyncBatchWriter[T]) replaySpilled(ctx context.Context) {
+	// 仍有批次在重试说明下游尚未恢复，等重试队列清空后再重放
+	if len(b.retryCh) > 0 {
+		return
+	}
+
+	replayed, err := b.spill.replay(func(line []byte) (int, error) {
+		var batch []T
+		if err := sonic.Unmarshal(line, &batch); err != nil {
+			// 损坏的行（如进程崩溃时写了一半）跳过，避免阻塞后续重放
+			b.tl.Error("skip corrupt spilled batch", zap.String("id", b.id), zap.Error(err))
+			return 0, nil
+		}
+		if err := b.writer.BWrite(ctx, batch); err != nil {
+			return 0, err
+		}
+		b.written(batch)
+		return len(batch), nil
+	})
+	if replayed > 0 {
+		monitor.AsyncWriterItemsReplayed.WithLabelValues(b.id).Add(float64(replayed))
+		monitor.AsyncWriterItemsWritten.WithLabelValues(b.id).Add(float64(replayed))
+		b.tl.Info("replayed spilled batches", zap.String("id", b.id), zap.Int("items", replayed))
+	}
+	if err != nil {
+		b.tl.Warn("replay spilled batches stopped", zap.String("id", b.id), zap.Error(err))
+	}
+	monitor.AsyncWriterSpillPendingBytes.WithLabelValues(b.id).Set(float64(b.spill.pendingBytes()))
+}
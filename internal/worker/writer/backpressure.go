@@ -0,0 +1,162 @@
+package writer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+	"web3-smart/internal/worker/monitor"
+
+	"go.uber.org/zap"
+)
+
+// BackpressurePolicy 队列满时 Submit 的处理策略
+type BackpressurePolicy string
+
+const (
+	PolicyDropNewest BackpressurePolicy = "drop_newest" // 丢弃新提交的条目（默认）
+	PolicyDropOldest BackpressurePolicy = "drop_oldest" // 丢弃队列中最早的条目，为新条目腾出位置
+	PolicyBlock      BackpressurePolicy = "block"       // 阻塞等待，超过 block_timeout_ms 仍满则丢弃新条目
+	PolicyCoalesce   BackpressurePolicy = "coalesce"    // 进入溢出区按 key 合并，同一 key 只保留最新，溢出区也满时丢弃新条目
+)
+
+func parsePolicy(s string) (BackpressurePolicy, bool) {
+	switch p := BackpressurePolicy(s); p {
+	case PolicyDropNewest, PolicyDropOldest, PolicyBlock, PolicyCoalesce:
+		return p, true
+	case "":
+		return PolicyDropNewest, true
+	}
+	return PolicyDropNewest, false
+}
+
+// 丢弃原因，对应 async_writer_messages_dropped_total 的 reason 标签
+const (
+	dropReasonFull    = "full"    // 队列（或溢出区）已满，丢弃新条目
+	dropReasonEvicted = "evicted" // drop_oldest 挤出的旧条目
+	dropReasonTimeout = "timeout" // block 等待超时
+)
+
+// workerQueue 单个写入 worker 的输入队列
+//
+// coalesce 策略下通道满时条目进入溢出区。溢出区非空期间新条目也只进溢出区，
+// worker 读空通道后才取走溢出区，保证同一 key 先提交的先写入。
+type workerQueue[T any] struct {
+	ch chan T
+
+	mu          sync.Mutex
+	overflow    map[string]T
+	order       []string     // 溢出区 key 的首次进入顺序
+	overflowLen atomic.Int64 // 供无锁判断溢出区是否为空
+}
+
+func newWorkerQueue[T any](size int) *workerQueue[T] {
+	return &workerQueue[T]{ch: make(chan T, size)}
+}
+
+func (q *workerQueue[T]) depth() int {
+	return len(q.ch) + int(q.overflowLen.Load())
+}
+
+// submit 按策略入队，返回是否入队
+func (b *AsyncBatchWriter[T]) submit(q *workerQueue[T], item T) bool {
+	switch b.policy {
+	case PolicyDropOldest:
+		for {
+			select {
+			case q.ch <- item:
+				return true
+			default:
+			}
+			select {
+			case <-q.ch:
+				monitor.AsyncWriterMessagesDropped.WithLabelValues(b.id, dropReasonEvicted).Inc()
+			default:
+			}
+		}
+
+	case PolicyBlock:
+		select {
+		case q.ch <- item:
+			return true
+		default:
+		}
+		timer := time.NewTimer(b.blockTimeout)
+		defer timer.Stop()
+		select {
+		case q.ch <- item:
+			return true
+		case <-timer.C:
+			b.dropped(dropReasonTimeout)
+			return false
+		}
+
+	case PolicyCoalesce:
+		if q.overflowLen.Load() == 0 {
+			select {
+			case q.ch <- item:
+				return true
+			default:
+			}
+		}
+		return b.coalesce(q, item)
+
+	default:
+		select {
+		case q.ch <- item:
+			return true
+		default:
+			b.dropped(dropReasonFull)
+			return false
+		}
+	}
+}
+
+// coalesce 放入溢出区，同一 key 覆盖旧条目；溢出区 key 数上限与通道长度相同
+func (b *AsyncBatchWriter[T]) coalesce(q *workerQueue[T], item T) bool {
+	key := b.keyFunc(item)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.overflow == nil {
+		q.overflow = make(map[string]T)
+	}
+	if _, ok := q.overflow[key]; ok {
+		q.overflow[key] = item
+		monitor.AsyncWriterItemsCoalesced.WithLabelValues(b.id).Inc()
+		return true
+	}
+	if len(q.overflow) >= cap(q.ch) {
+		b.dropped(dropReasonFull)
+		return false
+	}
+	q.overflow[key] = item
+	q.order = append(q.order, key)
+	q.overflowLen.Store(int64(len(q.overflow)))
+	return true
+}
+
+// drainOverflow 取走溢出区全部条目，按 key 首次进入顺序返回
+func (q *workerQueue[T]) drainOverflow() []T {
+	if q.overflowLen.Load() == 0 {
+		return nil
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := make([]T, 0, len(q.order))
+	for _, key := range q.order {
+		items = append(items, q.overflow[key])
+	}
+	q.overflow = nil
+	q.order = nil
+	q.overflowLen.Store(0)
+	return items
+}
+
+func (b *AsyncBatchWriter[T]) dropped(reason string) {
+	monitor.AsyncWriterMessagesDropped.WithLabelValues(b.id, reason).Inc()
+	// 持续丢弃时每条都打日志会刷屏，按秒限流
+	now := time.Now().Unix()
+	if last := b.lastDropLog.Load(); now > last && b.lastDropLog.CompareAndSwap(last, now) {
+		b.tl.Warn("async writer queue full, dropping item", zap.String("id", b.id), zap.String("reason", reason))
+	}
+}
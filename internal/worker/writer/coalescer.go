@@ -0,0 +1,427 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+	"web3-smart/internal/worker/monitor"
+	"web3-smart/pkg/utils"
+
+	"github.com/bytedance/sonic"
+	"go.uber.org/zap"
+)
+
+// errReplayPaused 待写表已满或 writer 正在关闭，本次重放暂停，剩余落盘数据下次继续
+var errReplayPaused = errors.New("coalesce replay paused")
+
+// CoalescingBatchWriter 按业务唯一键合并的异步批量写入，用于持仓、钱包这类 upsert 状态表
+//
+// 条目不进通道，而是按 key 放入待写表，两次 flush 之间同一 key 只保留最新一份，
+// 热点钱包的大量中间状态不再占用队列与内存。key 决定分桶，同一 key 始终由同一 worker 串行写入；
+// 写入失败的条目合并回待写表（期间已有更新状态的直接丢弃旧的）并按退避重新 flush，
+// 因此同一 key 不会被旧状态覆盖。连续失败超过 max_retries 时落盘释放内存。
+//
+// 落盘数据在各 worker 都恢复写入后重放：不直接写下游，而是合并回待写表，按同样的规则与新状态合并——
+// 待写表中已有实时提交的 key、或落盘之后已取走写出过实时状态的 key，跳过落盘中的旧状态；
+// 落盘中同一 key 的多份状态按落盘顺序保留最新一份。
+//
+// 参数取自 async_writer.writers.<id>，其中 queue_size 为每个 worker 的待写 key 上限，
+// 超过时丢弃新 key 的条目；policy 不生效。
+type CoalescingBatchWriter[T any] struct {
+	base    *AsyncBatchWriter[T] // 复用写入、指标与落盘文件
+	keyFunc func(T) string
+	buckets []*coalesceBucket[T]
+	maxKeys int
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	// 落盘重放，见 replaySpilled
+	spillMu sync.Mutex   // 串行化落盘与重放完成后的状态清理
+	spilled atomic.Bool  // 有待重放的落盘数据，期间记录已写出实时状态的 key
+	failing atomic.Int32 // 处于写入失败退避中的 worker 数，大于 0 时不重放
+
+	// 合并率统计窗口，每次 flush 后重置
+	submitted atomic.Int64
+	coalesced atomic.Int64
+}
+
+type coalesceBucket[T any] struct {
+	mu      sync.Mutex
+	items   map[string]coalesceEntry[T]
+	order   []string            // key 首次进入顺序
+	flushed map[string]struct{} // 有待重放落盘数据期间已取走写出实时状态的 key，重放时跳过
+	closed  bool                // worker 已做最后一次写入，不再接收重放数据
+	notify  chan struct{}       // 待写 key 数达到 batchSize 时通知 worker 提前 flush
+}
+
+// coalesceEntry 待写条目，replayed 表示来自落盘重放，比任何实时提交的状态都旧
+type coalesceEntry[T any] struct {
+	item     T
+	replayed bool
+}
+
+func NewCoalescingBatchWriter[T any](tl *zap.Logger, writer BatchWriter[T], id string, keyFunc func(T) string) *CoalescingBatchWriter[T] {
+	qc := mergeQueueConfig(writerConfig.Defaults, writerConfig.Writers[id])
+	base := &AsyncBatchWriter[T]{
+		id:            id,
+		workers:       qc.Workers,
+		tl:            tl,
+		writer:        writer,
+		batchSize:     qc.BatchSize,
+		flushInterval: time.Duration(qc.FlushInterval) * time.Millisecond,
+	}
+	base.initFailure()
+
+	c := &CoalescingBatchWriter[T]{
+		base:    base,
+		keyFunc: keyFunc,
+		buckets: make([]*coalesceBucket[T], qc.Workers),
+		maxKeys: qc.QueueSize,
+		done:    make(chan struct{}),
+	}
+	for i := range c.buckets {
+		c.buckets[i] = &coalesceBucket[T]{
+			items:   make(map[string]coalesceEntry[T]),
+			flushed: make(map[string]struct{}),
+			notify:  make(chan struct{}, 1),
+		}
+	}
+	if base.spill != nil && base.spill.pendingBytes() > 0 {
+		c.spilled.Store(true) // 上次进程遗留的落盘数据
+	}
+	registerQueue(id, c.saturation)
+	return c
+}
+
+// saturation 最满的 worker 待写表使用率
+func (c *CoalescingBatchWriter[T]) saturation() float64 {
+	var max float64
+	for _, bk := range c.buckets {
+		bk.mu.Lock()
+		n := len(bk.items)
+		bk.mu.Unlock()
+		if r := float64(n) / float64(c.maxKeys); r > max {
+			max = r
+		}
+	}
+	return max
+}
+
+func (c *CoalescingBatchWriter[T]) Start(ctx context.Context) {
+	for _, bk := range c.buckets {
+		c.wg.Add(1)
+		go c.run(ctx, bk)
+	}
+	// 失败重试在各 worker 内完成，不使用 base 的重试队列；落盘数据由自己的重放协程合并回待写表
+	if c.base.spill != nil {
+		c.base.failWg.Add(1)
+		go c.replayLoop(ctx)
+	}
+}
+
+// Submit 放入待写表，同一 key 覆盖尚未写入的旧状态，不阻塞
+func (c *CoalescingBatchWriter[T]) Submit(item T) {
+	key := c.keyFunc(item)
+	bk := c.buckets[utils.GetHashBucket(key, uint32(len(c.buckets)))]
+	c.submitted.Add(1)
+
+	bk.mu.Lock()
+	if _, ok := bk.items[key]; ok {
+		bk.items[key] = coalesceEntry[T]{item: item}
+		bk.mu.Unlock()
+		c.coalesced.Add(1)
+		monitor.AsyncWriterItemsCoalesced.WithLabelValues(c.base.id).Inc()
+		monitor.AsyncWriterMessagesQueued.WithLabelValues(c.base.id).Inc()
+		return
+	}
+	if len(bk.items) >= c.maxKeys {
+		bk.mu.Unlock()
+		c.base.dropped(dropReasonFull)
+		return
+	}
+	bk.items[key] = coalesceEntry[T]{item: item}
+	bk.order = append(bk.order, key)
+	n := len(bk.items)
+	bk.mu.Unlock()
+
+	monitor.AsyncWriterMessagesQueued.WithLabelValues(c.base.id).Inc()
+	if n >= c.base.batchSize {
+		select {
+		case bk.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close 写完待写表后关闭，写入失败的落盘
+func (c *CoalescingBatchWriter[T]) Close() {
+	queues.Delete(c.base.id)
+	close(c.done)
+	c.wg.Wait()
+	c.base.closeFailure()
+}
+
+func (c *CoalescingBatchWriter[T]) run(ctx context.Context, bk *coalesceBucket[T]) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.base.flushInterval)
+	defer ticker.Stop()
+
+	var failures int
+	var retryAt time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			c.flushFinal(ctx, bk)
+			return
+		case <-c.done:
+			c.flushFinal(ctx, bk)
+			return
+		case <-bk.notify:
+		case <-ticker.C:
+		}
+
+		if failures > 0 && time.Now().Before(retryAt) {
+			continue
+		}
+		if failures > 0 {
+			monitor.AsyncWriterRetries.WithLabelValues(c.base.id).Inc()
+		}
+		if c.flush(ctx, bk) {
+			if failures > 0 {
+				c.failing.Add(-1)
+			}
+			failures = 0
+			continue
+		}
+
+		if failures == 0 {
+			c.failing.Add(1)
+		}
+		failures++
+		if failures > c.base.retry.MaxRetries {
+			// 下游长时间不可用，落盘释放内存，恢复后由重放协程补写
+			c.spill(bk, c.drain(bk, false))
+			c.failing.Add(-1)
+			failures = 0
+			continue
+		}
+		backoff := time.Duration(c.base.retry.RetryBackoff) * time.Millisecond << min(failures-1, 16)
+		retryAt = time.Now().Add(min(backoff, time.Duration(c.base.retry.MaxBackoff)*time.Millisecond))
+	}
+}
+
+// flush 写出待写表，失败的条目合并回待写表，返回是否全部写入
+func (c *CoalescingBatchWriter[T]) flush(ctx context.Context, bk *coalesceBucket[T]) bool {
+	entries := c.drain(bk, false)
+	defer c.report()
+	for i := 0; i < len(entries); i += c.base.batchSize {
+		end := min(i+c.base.batchSize, len(entries))
+		if err := c.base.write(ctx, entryItems(entries[i:end])); err != nil {
+			c.base.tl.Warn("coalesced batch write failed, requeued", zap.String("id", c.base.id), zap.Int("size", len(entries)-i), zap.Error(err))
+			c.requeue(bk, entries[i:])
+			return false
+		}
+	}
+	return true
+}
+
+// flushFinal 退出前最后写一次，失败直接落盘；之后不再接收重放数据
+func (c *CoalescingBatchWriter[T]) flushFinal(ctx context.Context, bk *coalesceBucket[T]) {
+	entries := c.drain(bk, true)
+	for i := 0; i < len(entries); i += c.base.batchSize {
+		end := min(i+c.base.batchSize, len(entries))
+		if err := c.base.write(ctx, entryItems(entries[i:end])); err != nil {
+			c.base.tl.Warn("final coalesced batch write failed, spilling", zap.String("id", c.base.id), zap.Int("size", len(entries)-i), zap.Error(err))
+			c.spill(bk, entries[i:])
+			return
+		}
+	}
+}
+
+// drain 取走待写表全部条目，按 key 首次进入顺序返回；closing 为 true 时标记 worker 已关闭。
+// 有待重放的落盘数据时，取走实时状态的 key 记入 flushed：写入失败会合并回待写表、耗尽重试会落盘（同时移出 flushed），
+// 重放对这些 key 都应跳过；在取走时记录，避免写入进行中被重放的旧状态插到后面
+func (c *CoalescingBatchWriter[T]) drain(bk *coalesceBucket[T], closing bool) []coalesceEntry[T] {
+	bk.mu.Lock()
+	defer bk.mu.Unlock()
+	if closing {
+		bk.closed = true
+	}
+	if len(bk.order) == 0 {
+		return nil
+	}
+	track := c.spilled.Load()
+	entries := make([]coalesceEntry[T], 0, len(bk.order))
+	for _, key := range bk.order {
+		e := bk.items[key]
+		entries = append(entries, e)
+		if track && !e.replayed {
+			bk.flushed[key] = struct{}{}
+		}
+	}
+	bk.items = make(map[string]coalesceEntry[T], len(entries))
+	bk.order = nil
+	return entries
+}
+
+// requeue 失败条目合并回待写表并排在最前；期间已提交更新状态的 key 保留新状态
+func (c *CoalescingBatchWriter[T]) requeue(bk *coalesceBucket[T], failed []coalesceEntry[T]) {
+	bk.mu.Lock()
+	defer bk.mu.Unlock()
+	order := make([]string, 0, len(failed)+len(bk.order))
+	for _, e := range failed {
+		key := c.keyFunc(e.item)
+		if _, ok := bk.items[key]; ok {
+			continue
+		}
+		bk.items[key] = e
+		order = append(order, key)
+	}
+	bk.order = append(order, bk.order...)
+}
+
+// spill 落盘；落盘的是这些 key 当前最新的状态，从已写出记录中移除，重放时不再跳过
+func (c *CoalescingBatchWriter[T]) spill(bk *coalesceBucket[T], entries []coalesceEntry[T]) {
+	if len(entries) == 0 {
+		return
+	}
+	c.spillMu.Lock()
+	defer c.spillMu.Unlock()
+	c.spilled.Store(true)
+	bk.mu.Lock()
+	for _, e := range entries {
+		delete(bk.flushed, c.keyFunc(e.item))
+	}
+	bk.mu.Unlock()
+	c.base.spillBatch(entryItems(entries))
+}
+
+// replayLoop 启动时及之后定期重放落盘数据，包括上次进程遗留的
+func (c *CoalescingBatchWriter[T]) replayLoop(ctx context.Context) {
+	defer c.base.failWg.Done()
+	ticker := time.NewTicker(time.Duration(c.base.retry.ReplayInterval) * time.Second)
+	defer ticker.Stop()
+
+	c.replaySpilled()
+	for {
+		select {
+		case <-ticker.C:
+			c.replaySpilled()
+		case <-c.done:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// replaySpilled 落盘数据合并回待写表，由 worker 正常写出；全部重放完后清空已写出记录
+func (c *CoalescingBatchWriter[T]) replaySpilled() {
+	// 仍有 worker 在退避重试说明下游尚未恢复，等恢复后再重放
+	if c.failing.Load() > 0 {
+		return
+	}
+
+	replayed, err := c.base.spill.replay(func(line []byte) (int, error) {
+		var batch []T
+		if err := sonic.Unmarshal(line, &batch); err != nil {
+			// 损坏的行（如进程崩溃时写了一半）跳过，避免阻塞后续重放
+			c.base.tl.Error("skip corrupt spilled batch", zap.String("id", c.base.id), zap.Error(err))
+			return 0, nil
+		}
+		return c.merge(batch)
+	})
+	if replayed > 0 {
+		monitor.AsyncWriterItemsReplayed.WithLabelValues(c.base.id).Add(float64(replayed))
+		c.base.tl.Info("merged spilled items back", zap.String("id", c.base.id), zap.Int("items", replayed))
+	}
+	if err != nil && !errors.Is(err, errReplayPaused) {
+		c.base.tl.Warn("replay spilled batches stopped", zap.String("id", c.base.id), zap.Error(err))
+	}
+
+	c.spillMu.Lock()
+	pending := c.base.spill.pendingBytes()
+	if err == nil && pending == 0 && c.spilled.Load() {
+		c.spilled.Store(false)
+		for _, bk := range c.buckets {
+			bk.mu.Lock()
+			clear(bk.flushed)
+			bk.mu.Unlock()
+		}
+	}
+	c.spillMu.Unlock()
+	monitor.AsyncWriterSpillPendingBytes.WithLabelValues(c.base.id).Set(float64(pending))
+}
+
+// merge 一行落盘数据合并回待写表，返回合并的条目数；待写表已满或 worker 已关闭时返回 errReplayPaused，
+// 该行保留到下次重放（已合并的部分再次合并时结果相同）
+func (c *CoalescingBatchWriter[T]) merge(batch []T) (int, error) {
+	var merged int
+	for _, item := range batch {
+		key := c.keyFunc(item)
+		bk := c.buckets[utils.GetHashBucket(key, uint32(len(c.buckets)))]
+
+		bk.mu.Lock()
+		if bk.closed {
+			bk.mu.Unlock()
+			return merged, errReplayPaused
+		}
+		if _, ok := bk.flushed[key]; ok {
+			bk.mu.Unlock()
+			continue
+		}
+		if e, ok := bk.items[key]; ok {
+			// 实时提交的状态更新，保留；之前重放进来的落盘更早，用本行覆盖
+			if e.replayed {
+				bk.items[key] = coalesceEntry[T]{item: item, replayed: true}
+				merged++
+			}
+			bk.mu.Unlock()
+			continue
+		}
+		if len(bk.items) >= c.maxKeys {
+			bk.mu.Unlock()
+			return merged, errReplayPaused
+		}
+		bk.items[key] = coalesceEntry[T]{item: item, replayed: true}
+		bk.order = append(bk.order, key)
+		n := len(bk.items)
+		bk.mu.Unlock()
+
+		merged++
+		if n >= c.base.batchSize {
+			select {
+			case bk.notify <- struct{}{}:
+			default:
+			}
+		}
+	}
+	return merged, nil
+}
+
+func entryItems[T any](entries []coalesceEntry[T]) []T {
+	items := make([]T, len(entries))
+	for i, e := range entries {
+		items[i] = e.item
+	}
+	return items
+}
+
+// report 上报合并率与待写条目数
+func (c *CoalescingBatchWriter[T]) report() {
+	submitted := c.submitted.Swap(0)
+	coalesced := c.coalesced.Swap(0)
+	if submitted > 0 {
+		monitor.AsyncWriterCoalesceRatio.WithLabelValues(c.base.id).Set(float64(coalesced) / float64(submitted))
+	}
+
+	var depth int
+	for _, bk := range c.buckets {
+		bk.mu.Lock()
+		depth += len(bk.items)
+		bk.mu.Unlock()
+	}
+	monitor.AsyncWriterQueueDepth.WithLabelValues(c.base.id).Set(float64(depth))
+}
@@ -0,0 +1,104 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type kv struct {
+	K string `json:"k"`
+	V int    `json:"v"`
+}
+
+type memWriter struct {
+	mu   sync.Mutex
+	fail bool
+	rows map[string]int
+}
+
+func (w *memWriter) BWrite(_ context.Context, items []kv) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.fail {
+		return errors.New("downstream unavailable")
+	}
+	for _, it := range items {
+		w.rows[it.K] = it.V
+	}
+	return nil
+}
+
+func (w *memWriter) Close() error { return nil }
+
+func (w *memWriter) setFail(fail bool) {
+	w.mu.Lock()
+	w.fail = fail
+	w.mu.Unlock()
+}
+
+func (w *memWriter) get(k string) (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	v, ok := w.rows[k]
+	return v, ok
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// 落盘的旧状态重放时不能覆盖之后已写出的新状态
+func TestCoalescingReplayKeepsNewerState(t *testing.T) {
+	saved := writerConfig
+	defer func() { writerConfig = saved }()
+	writerConfig.SpillDir = t.TempDir()
+	writerConfig.MaxRetries = 0
+	writerConfig.ReplayInterval = 3600
+	writerConfig.Defaults.Workers = 1
+	writerConfig.Defaults.FlushInterval = 5
+
+	w := &memWriter{rows: make(map[string]int)}
+	c := NewCoalescingBatchWriter[kv](zap.NewNop(), w, "coalesce_replay_test", func(it kv) string { return it.K })
+	c.Start(context.Background())
+	defer c.Close()
+
+	// 下游不可用：a=1、b=1 落盘
+	w.setFail(true)
+	c.Submit(kv{K: "a", V: 1})
+	c.Submit(kv{K: "b", V: 1})
+	waitFor(t, func() bool { return c.base.spill.pendingBytes() > 0 && c.failing.Load() == 0 })
+
+	// 恢复后 a 先写出新状态
+	w.setFail(false)
+	c.Submit(kv{K: "a", V: 2})
+	waitFor(t, func() bool { v, _ := w.get("a"); return v == 2 })
+
+	c.replaySpilled()
+	waitFor(t, func() bool { _, ok := w.get("b"); return ok })
+	time.Sleep(20 * time.Millisecond)
+
+	if v, _ := w.get("a"); v != 2 {
+		t.Fatalf("a overwritten by spilled state: got %d, want 2", v)
+	}
+	if v, _ := w.get("b"); v != 1 {
+		t.Fatalf("b = %d, want 1", v)
+	}
+	if n := c.base.spill.pendingBytes(); n != 0 {
+		t.Fatalf("spill pending bytes = %d, want 0", n)
+	}
+	if c.spilled.Load() {
+		t.Fatal("spilled flag not cleared after full replay")
+	}
+}
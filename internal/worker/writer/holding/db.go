@@ -113,6 +113,10 @@ func (w *DbHoldingWriter) BWrite(ctx context.Context, holdings []model.WalletHol
 				"updated_at":             gorm.Expr("EXCLUDED.updated_at"),
 				"created_at":             gorm.Expr("EXCLUDED.created_at"),
 			}),
+			// 落盘重放的是旧快照，只在不早于库中最后交易时间时覆盖，避免冲掉之后已写入的新状态
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "COALESCE(t_smart_holding.last_transaction_time, 0) <= COALESCE(EXCLUDED.last_transaction_time, 0)"},
+			}},
 		}).CreateInBatches(holdings, 1000).Error
 
 		if err == nil {
@@ -122,7 +126,8 @@ func (w *DbHoldingWriter) BWrite(ctx context.Context, holdings []model.WalletHol
 	}
 	if err != nil {
 		//w.tl.Warn("❌ DB write failed, exceeded the maximum number of retries", zap.Error(err))
-		w.tl.Warn("❌ DB write failed, exceeded the maximum number of retries", zap.Error(err), zap.Any("holdings", holdings))
+		// 由 AsyncBatchWriter 的重试队列继续重试，重试期间不打印整批数据
+		w.tl.Warn("❌ DB write failed, exceeded the maximum number of retries", zap.Error(err), zap.Int("count", len(holdings)))
 		return err
 	}
 	return nil
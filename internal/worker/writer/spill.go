@@ -0,0 +1,129 @@
+package writer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// spillFile 单个 writer 的本地落盘文件，每行一个批次（JSON 数组）
+//
+//	<dir>/<id>.spill         重试耗尽的批次追加写入
+//	<dir>/<id>.spill.replay  重放中的文件：没有时由 .spill 重命名而来，重放中断后剩余内容留在此文件
+//
+// 先重放 .replay 再轮转 .spill，保证按落盘顺序重放。
+type spillFile struct {
+	mu         sync.Mutex // 保护 .spill 的追加与轮转
+	path       string
+	replayPath string
+}
+
+func newSpillFile(dir, id string) (*spillFile, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, id+".spill")
+	return &spillFile{
+		path:       path,
+		replayPath: path + ".replay",
+	}, nil
+}
+
+// append 追加一个批次，落盘频率很低，每次打开关闭文件即可
+func (f *spillFile) append(line []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// pendingBytes 待重放数据大小
+func (f *spillFile) pendingBytes() int64 {
+	var size int64
+	for _, p := range []string{f.replayPath, f.path} {
+		if st, err := os.Stat(p); err == nil {
+			size += st.Size()
+		}
+	}
+	return size
+}
+
+// replay 逐行调用 write 重放，write 返回错误时停止并返回该错误，失败行及之后的内容保留到下次。
+// 返回成功重放的条目数（由 write 返回）。
+func (f *spillFile) replay(write func(line []byte) (int, error)) (int, error) {
+	if _, err := os.Stat(f.replayPath); errors.Is(err, os.ErrNotExist) {
+		f.mu.Lock()
+		err = os.Rename(f.path, f.replayPath)
+		f.mu.Unlock()
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil // 没有落盘数据
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	file, err := os.Open(f.replayPath)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	var replayed int
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if data := bytes.TrimSuffix(line, []byte{'\n'}); len(data) > 0 {
+			n, err := write(data)
+			if err != nil {
+				// 失败行及剩余内容写回 .replay，下次从这里继续
+				if keepErr := f.keepRemaining(line, reader); keepErr != nil {
+					return replayed, keepErr
+				}
+				return replayed, err
+			}
+			replayed += n
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return replayed, readErr
+		}
+	}
+	file.Close()
+	return replayed, os.Remove(f.replayPath)
+}
+
+// keepRemaining 用 line + reader 中未读的内容替换 .replay 文件
+func (f *spillFile) keepRemaining(line []byte, reader io.Reader) error {
+	tmpPath := f.replayPath + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(line); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(tmp, reader); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, f.replayPath)
+}
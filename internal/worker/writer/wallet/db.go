@@ -116,15 +116,13 @@ func (w *DbWalletWriter) BWrite(ctx context.Context, wallets []model.WalletSumma
 				{Name: "wallet_address"},
 			},
 			// 使用DO UPDATE SET代替DoUpdates，减少SQL解析开销
+			// tags / twitter_name / twitter_username / wallet_type 由管理接口维护（见 service.WalletAdminService），
+			// 只在插入新钱包时写入，交易侧的 upsert 不覆盖，避免用处理交易时读到的旧值冲掉人工修改
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"avatar":                               gorm.Expr("EXCLUDED.avatar"),
 				"balance":                              gorm.Expr("EXCLUDED.balance"),
 				"balance_usd":                          gorm.Expr("EXCLUDED.balance_usd"),
 				"chain_id":                             gorm.Expr("EXCLUDED.chain_id"),
-				"tags":                                 gorm.Expr("EXCLUDED.tags"),
-				"twitter_name":                         gorm.Expr("EXCLUDED.twitter_name"),
-				"twitter_username":                     gorm.Expr("EXCLUDED.twitter_username"),
-				"wallet_type":                          gorm.Expr("EXCLUDED.wallet_type"),
 				"asset_multiple":                       gorm.Expr("EXCLUDED.asset_multiple"),
 				"token_list":                           gorm.Expr("EXCLUDED.token_list"),
 				"avg_cost_30d":                         gorm.Expr("EXCLUDED.avg_cost_30d"),
@@ -180,6 +178,10 @@ func (w *DbWalletWriter) BWrite(ctx context.Context, wallets []model.WalletSumma
 				"updated_at":                           gorm.Expr("EXCLUDED.updated_at"),
 				"created_at":                           gorm.Expr("EXCLUDED.created_at"),
 			}),
+			// 落盘重放的是旧快照，只在不早于库中最后交易时间时覆盖，避免冲掉之后已写入的新状态
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "COALESCE(t_smart_wallet.last_transaction_time, 0) <= COALESCE(EXCLUDED.last_transaction_time, 0)"},
+			}},
 		}).CreateInBatches(wallets, 1000).Error
 
 		if err == nil {
@@ -61,6 +61,20 @@ func NewClient(cfg Config, log *zap.Logger) (*Client, error) {
 	return client, nil
 }
 
+// Ping 检查集群是否可用
+func (c *Client) Ping(ctx context.Context) error {
+	res, err := esapi.PingRequest{}.Do(ctx, c.es)
+	if err != nil {
+		return fmt.Errorf("failed to ping elasticsearch: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to ping elasticsearch: %s", res.Status())
+	}
+	return nil
+}
+
 // initializeIndex 初始化索引
 func (c *Client) initializeIndex(ctx context.Context, indexName string, mapping map[string]interface{}) error {
 	return c.CreateIndex(ctx, indexName, mapping)
@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"web3-smart/pkg/httpclient"
+
+	"go.uber.org/zap"
+)
+
+// LarkNotifier 通过 Lark 自定义机器人 webhook 发送卡片消息
+type LarkNotifier struct {
+	webhook    string
+	httpClient *httpclient.HTTPClient
+}
+
+// larkResponse Lark webhook 返回结构
+type larkResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+func NewLarkNotifier(webhook string, logger *zap.Logger) *LarkNotifier {
+	httpClient := httpclient.NewHTTPClient(httpclient.HTTPClientConfig{
+		Timeout:    5 * time.Second,
+		RateLimit:  100, // Lark 自定义机器人限制 100 次/分钟
+		MaxRetries: 2,
+	}, logger)
+
+	return &LarkNotifier{
+		webhook:    webhook,
+		httpClient: httpClient,
+	}
+}
+
+// Notify 发送一条卡片消息
+func (n *LarkNotifier) Notify(ctx context.Context, msg Message) error {
+	if n.webhook == "" {
+		return fmt.Errorf("lark webhook not configured")
+	}
+
+	var resp larkResponse
+	if err := n.httpClient.PostJSON(ctx, n.webhook, buildLarkCard(msg), nil, &resp); err != nil {
+		return err
+	}
+	if resp.Code != 0 {
+		return fmt.Errorf("lark webhook error: code=%d, msg=%s", resp.Code, resp.Msg)
+	}
+	return nil
+}
+
+// buildLarkCard 构建 interactive 卡片消息体
+func buildLarkCard(msg Message) map[string]interface{} {
+	return map[string]interface{}{
+		"msg_type": "interactive",
+		"card": map[string]interface{}{
+			"config": map[string]interface{}{
+				"wide_screen_mode": true,
+			},
+			"header": map[string]interface{}{
+				"title": map[string]interface{}{
+					"tag":     "plain_text",
+					"content": msg.Title,
+				},
+				"template": larkHeaderColor(msg.Level),
+			},
+			"elements": []interface{}{
+				map[string]interface{}{
+					"tag": "div",
+					"text": map[string]interface{}{
+						"tag":     "lark_md",
+						"content": msg.Content,
+					},
+				},
+			},
+		},
+	}
+}
+
+func larkHeaderColor(level string) string {
+	switch level {
+	case LevelCritical:
+		return "red"
+	case LevelWarning:
+		return "orange"
+	default:
+		return "blue"
+	}
+}
@@ -0,0 +1,56 @@
+package notifier_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"web3-smart/pkg/notifier"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLarkNotifier(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode request failed: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	n := notifier.NewLarkNotifier(server.URL, zaptest.NewLogger(t))
+	err := n.Notify(context.Background(), notifier.Message{
+		Title:   "cluster buy",
+		Content: "**3** smart wallets bought",
+		Level:   notifier.LevelWarning,
+	})
+	if err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+
+	if received["msg_type"] != "interactive" {
+		t.Errorf("unexpected msg_type: %v", received["msg_type"])
+	}
+	card, _ := received["card"].(map[string]interface{})
+	header, _ := card["header"].(map[string]interface{})
+	if header["template"] != "orange" {
+		t.Errorf("unexpected header template: %v", header["template"])
+	}
+}
+
+func TestLarkNotifierErrorCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":9499,"msg":"Bad Request"}`))
+	}))
+	defer server.Close()
+
+	n := notifier.NewLarkNotifier(server.URL, zaptest.NewLogger(t))
+	if err := n.Notify(context.Background(), notifier.Message{Title: "t", Content: "c"}); err == nil {
+		t.Errorf("expected error for non-zero lark code")
+	}
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import "context"
+
+// 消息级别，决定卡片颜色等展示样式
+const (
+	LevelInfo     = "info"
+	LevelWarning  = "warning"
+	LevelCritical = "critical"
+)
+
+// Message 通用告警消息
+type Message struct {
+	Title   string // 标题
+	Content string // 正文（markdown）
+	Level   string // info / warning / critical
+}
+
+// Notifier 告警通知发送接口
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,7 @@
+// Package smartmoneypb 聪明钱 gRPC 服务（SmartMoneyService）的 protobuf 定义与生成代码，供其他服务引用。
+//
+// 修改 smart_money.proto 后执行 make proto 重新生成 smart_money.pb.go 与 smart_money_grpc.pb.go，
+// 需要 protoc、protoc-gen-go 与 protoc-gen-go-grpc。
+package smartmoneypb
+
+//go:generate protoc --proto_path=. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative smart_money.proto
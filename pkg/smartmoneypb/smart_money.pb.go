@@ -0,0 +1,1752 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: smart_money.proto
+
+package smartmoneypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletRequest) Reset() {
+	*x = GetWalletRequest{}
+	mi := &file_smart_money_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletRequest) ProtoMessage() {}
+
+func (x *GetWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletRequest) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetWalletRequest) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetWalletRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+type WalletPeriodStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Pnl              string                 `protobuf:"bytes,1,opt,name=pnl,proto3" json:"pnl,omitempty"`
+	PnlPercentage    string                 `protobuf:"bytes,2,opt,name=pnl_percentage,json=pnlPercentage,proto3" json:"pnl_percentage,omitempty"`
+	UnrealizedProfit string                 `protobuf:"bytes,3,opt,name=unrealized_profit,json=unrealizedProfit,proto3" json:"unrealized_profit,omitempty"`
+	TotalCost        string                 `protobuf:"bytes,4,opt,name=total_cost,json=totalCost,proto3" json:"total_cost,omitempty"`
+	AvgCost          string                 `protobuf:"bytes,5,opt,name=avg_cost,json=avgCost,proto3" json:"avg_cost,omitempty"`
+	WinRate          string                 `protobuf:"bytes,6,opt,name=win_rate,json=winRate,proto3" json:"win_rate,omitempty"`
+	BuyNum           int32                  `protobuf:"varint,7,opt,name=buy_num,json=buyNum,proto3" json:"buy_num,omitempty"`
+	SellNum          int32                  `protobuf:"varint,8,opt,name=sell_num,json=sellNum,proto3" json:"sell_num,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WalletPeriodStats) Reset() {
+	*x = WalletPeriodStats{}
+	mi := &file_smart_money_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletPeriodStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletPeriodStats) ProtoMessage() {}
+
+func (x *WalletPeriodStats) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletPeriodStats.ProtoReflect.Descriptor instead.
+func (*WalletPeriodStats) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WalletPeriodStats) GetPnl() string {
+	if x != nil {
+		return x.Pnl
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetPnlPercentage() string {
+	if x != nil {
+		return x.PnlPercentage
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetUnrealizedProfit() string {
+	if x != nil {
+		return x.UnrealizedProfit
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetTotalCost() string {
+	if x != nil {
+		return x.TotalCost
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetAvgCost() string {
+	if x != nil {
+		return x.AvgCost
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetWinRate() string {
+	if x != nil {
+		return x.WinRate
+	}
+	return ""
+}
+
+func (x *WalletPeriodStats) GetBuyNum() int32 {
+	if x != nil {
+		return x.BuyNum
+	}
+	return 0
+}
+
+func (x *WalletPeriodStats) GetSellNum() int32 {
+	if x != nil {
+		return x.SellNum
+	}
+	return 0
+}
+
+type Wallet struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ChainId             uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress       string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Avatar              string                 `protobuf:"bytes,3,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	Tags                []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	TwitterName         string                 `protobuf:"bytes,5,opt,name=twitter_name,json=twitterName,proto3" json:"twitter_name,omitempty"`
+	TwitterUsername     string                 `protobuf:"bytes,6,opt,name=twitter_username,json=twitterUsername,proto3" json:"twitter_username,omitempty"`
+	WalletType          int32                  `protobuf:"varint,7,opt,name=wallet_type,json=walletType,proto3" json:"wallet_type,omitempty"` // 0:一般聪明钱，1:pump聪明钱，2:moonshot聪明钱
+	Balance             string                 `protobuf:"bytes,8,opt,name=balance,proto3" json:"balance,omitempty"`
+	BalanceUsd          string                 `protobuf:"bytes,9,opt,name=balance_usd,json=balanceUsd,proto3" json:"balance_usd,omitempty"`
+	Stats_1D            *WalletPeriodStats     `protobuf:"bytes,10,opt,name=stats_1d,json=stats1d,proto3" json:"stats_1d,omitempty"`
+	Stats_7D            *WalletPeriodStats     `protobuf:"bytes,11,opt,name=stats_7d,json=stats7d,proto3" json:"stats_7d,omitempty"`
+	Stats_30D           *WalletPeriodStats     `protobuf:"bytes,12,opt,name=stats_30d,json=stats30d,proto3" json:"stats_30d,omitempty"`
+	MaxDrawdown_30D     string                 `protobuf:"bytes,13,opt,name=max_drawdown_30d,json=maxDrawdown30d,proto3" json:"max_drawdown_30d,omitempty"`
+	LastTransactionTime int64                  `protobuf:"varint,14,opt,name=last_transaction_time,json=lastTransactionTime,proto3" json:"last_transaction_time,omitempty"`
+	IsActive            bool                   `protobuf:"varint,15,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	UpdatedAt           int64                  `protobuf:"varint,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Wallet) Reset() {
+	*x = Wallet{}
+	mi := &file_smart_money_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Wallet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Wallet) ProtoMessage() {}
+
+func (x *Wallet) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
+func (*Wallet) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Wallet) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Wallet) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Wallet) GetAvatar() string {
+	if x != nil {
+		return x.Avatar
+	}
+	return ""
+}
+
+func (x *Wallet) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Wallet) GetTwitterName() string {
+	if x != nil {
+		return x.TwitterName
+	}
+	return ""
+}
+
+func (x *Wallet) GetTwitterUsername() string {
+	if x != nil {
+		return x.TwitterUsername
+	}
+	return ""
+}
+
+func (x *Wallet) GetWalletType() int32 {
+	if x != nil {
+		return x.WalletType
+	}
+	return 0
+}
+
+func (x *Wallet) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *Wallet) GetBalanceUsd() string {
+	if x != nil {
+		return x.BalanceUsd
+	}
+	return ""
+}
+
+func (x *Wallet) GetStats_1D() *WalletPeriodStats {
+	if x != nil {
+		return x.Stats_1D
+	}
+	return nil
+}
+
+func (x *Wallet) GetStats_7D() *WalletPeriodStats {
+	if x != nil {
+		return x.Stats_7D
+	}
+	return nil
+}
+
+func (x *Wallet) GetStats_30D() *WalletPeriodStats {
+	if x != nil {
+		return x.Stats_30D
+	}
+	return nil
+}
+
+func (x *Wallet) GetMaxDrawdown_30D() string {
+	if x != nil {
+		return x.MaxDrawdown_30D
+	}
+	return ""
+}
+
+func (x *Wallet) GetLastTransactionTime() int64 {
+	if x != nil {
+		return x.LastTransactionTime
+	}
+	return 0
+}
+
+func (x *Wallet) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *Wallet) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ListHoldingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	TokenAddress  string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"` // 可选
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                                 // all / active / closed，默认 all
+	MinValueUsd   float64                `protobuf:"fixed64,5,opt,name=min_value_usd,json=minValueUsd,proto3" json:"min_value_usd,omitempty"`
+	SortBy        string                 `protobuf:"bytes,6,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"` // value_usd / unrealized_profits / pnl / last_transaction_time
+	Limit         int32                  `protobuf:"varint,7,opt,name=limit,proto3" json:"limit,omitempty"`                // 默认 50，最大 200
+	Offset        int32                  `protobuf:"varint,8,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldingsRequest) Reset() {
+	*x = ListHoldingsRequest{}
+	mi := &file_smart_money_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldingsRequest) ProtoMessage() {}
+
+func (x *ListHoldingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldingsRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldingsRequest) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListHoldingsRequest) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ListHoldingsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetMinValueUsd() float64 {
+	if x != nil {
+		return x.MinValueUsd
+	}
+	return 0
+}
+
+func (x *ListHoldingsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListHoldingsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListHoldingsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type Holding struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ChainId             uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress       string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	TokenAddress        string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	TokenName           string                 `protobuf:"bytes,4,opt,name=token_name,json=tokenName,proto3" json:"token_name,omitempty"`
+	TokenIcon           string                 `protobuf:"bytes,5,opt,name=token_icon,json=tokenIcon,proto3" json:"token_icon,omitempty"`
+	Amount              string                 `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	ValueUsd            string                 `protobuf:"bytes,7,opt,name=value_usd,json=valueUsd,proto3" json:"value_usd,omitempty"`
+	UnrealizedProfits   string                 `protobuf:"bytes,8,opt,name=unrealized_profits,json=unrealizedProfits,proto3" json:"unrealized_profits,omitempty"`
+	Pnl                 string                 `protobuf:"bytes,9,opt,name=pnl,proto3" json:"pnl,omitempty"`
+	PnlPercentage       string                 `protobuf:"bytes,10,opt,name=pnl_percentage,json=pnlPercentage,proto3" json:"pnl_percentage,omitempty"`
+	AvgPrice            string                 `protobuf:"bytes,11,opt,name=avg_price,json=avgPrice,proto3" json:"avg_price,omitempty"`
+	CurrentTotalCost    string                 `protobuf:"bytes,12,opt,name=current_total_cost,json=currentTotalCost,proto3" json:"current_total_cost,omitempty"`
+	Marketcap           string                 `protobuf:"bytes,13,opt,name=marketcap,proto3" json:"marketcap,omitempty"`
+	IsDev               bool                   `protobuf:"varint,14,opt,name=is_dev,json=isDev,proto3" json:"is_dev,omitempty"`
+	Tags                []string               `protobuf:"bytes,15,rep,name=tags,proto3" json:"tags,omitempty"`
+	PositionOpenedAt    int64                  `protobuf:"varint,16,opt,name=position_opened_at,json=positionOpenedAt,proto3" json:"position_opened_at,omitempty"`
+	LastTransactionTime int64                  `protobuf:"varint,17,opt,name=last_transaction_time,json=lastTransactionTime,proto3" json:"last_transaction_time,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Holding) Reset() {
+	*x = Holding{}
+	mi := &file_smart_money_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Holding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holding) ProtoMessage() {}
+
+func (x *Holding) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holding.ProtoReflect.Descriptor instead.
+func (*Holding) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Holding) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Holding) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Holding) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *Holding) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *Holding) GetTokenIcon() string {
+	if x != nil {
+		return x.TokenIcon
+	}
+	return ""
+}
+
+func (x *Holding) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Holding) GetValueUsd() string {
+	if x != nil {
+		return x.ValueUsd
+	}
+	return ""
+}
+
+func (x *Holding) GetUnrealizedProfits() string {
+	if x != nil {
+		return x.UnrealizedProfits
+	}
+	return ""
+}
+
+func (x *Holding) GetPnl() string {
+	if x != nil {
+		return x.Pnl
+	}
+	return ""
+}
+
+func (x *Holding) GetPnlPercentage() string {
+	if x != nil {
+		return x.PnlPercentage
+	}
+	return ""
+}
+
+func (x *Holding) GetAvgPrice() string {
+	if x != nil {
+		return x.AvgPrice
+	}
+	return ""
+}
+
+func (x *Holding) GetCurrentTotalCost() string {
+	if x != nil {
+		return x.CurrentTotalCost
+	}
+	return ""
+}
+
+func (x *Holding) GetMarketcap() string {
+	if x != nil {
+		return x.Marketcap
+	}
+	return ""
+}
+
+func (x *Holding) GetIsDev() bool {
+	if x != nil {
+		return x.IsDev
+	}
+	return false
+}
+
+func (x *Holding) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Holding) GetPositionOpenedAt() int64 {
+	if x != nil {
+		return x.PositionOpenedAt
+	}
+	return 0
+}
+
+func (x *Holding) GetLastTransactionTime() int64 {
+	if x != nil {
+		return x.LastTransactionTime
+	}
+	return 0
+}
+
+type ListHoldingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Holding             `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldingsResponse) Reset() {
+	*x = ListHoldingsResponse{}
+	mi := &file_smart_money_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldingsResponse) ProtoMessage() {}
+
+func (x *ListHoldingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldingsResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldingsResponse) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListHoldingsResponse) GetItems() []*Holding {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListHoldingsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type ListTransactionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainId         uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress   string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	TokenAddress    string                 `protobuf:"bytes,3,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`          // 可选
+	TransactionType string                 `protobuf:"bytes,4,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"` // 可选：build / buy / sell / clean
+	Cursor          string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`                                          // 上一页返回的 next_cursor，首页为空
+	Limit           int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`                                           // 默认 50，最大 200
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_smart_money_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListTransactionsRequest) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Transaction struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Id                       int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChainId                  uint64                 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress            string                 `protobuf:"bytes,3,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	TokenAddress             string                 `protobuf:"bytes,4,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	TokenName                string                 `protobuf:"bytes,5,opt,name=token_name,json=tokenName,proto3" json:"token_name,omitempty"`
+	TokenIcon                string                 `protobuf:"bytes,6,opt,name=token_icon,json=tokenIcon,proto3" json:"token_icon,omitempty"`
+	TransactionType          string                 `protobuf:"bytes,7,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	TransactionTime          int64                  `protobuf:"varint,8,opt,name=transaction_time,json=transactionTime,proto3" json:"transaction_time,omitempty"`
+	Signature                string                 `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+	LogIndex                 int32                  `protobuf:"varint,10,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Price                    string                 `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`
+	Amount                   string                 `protobuf:"bytes,12,opt,name=amount,proto3" json:"amount,omitempty"`
+	Value                    string                 `protobuf:"bytes,13,opt,name=value,proto3" json:"value,omitempty"`
+	Marketcap                string                 `protobuf:"bytes,14,opt,name=marketcap,proto3" json:"marketcap,omitempty"`
+	RealizedProfit           string                 `protobuf:"bytes,15,opt,name=realized_profit,json=realizedProfit,proto3" json:"realized_profit,omitempty"`
+	RealizedProfitPercentage string                 `protobuf:"bytes,16,opt,name=realized_profit_percentage,json=realizedProfitPercentage,proto3" json:"realized_profit_percentage,omitempty"`
+	FromTokenAddress         string                 `protobuf:"bytes,17,opt,name=from_token_address,json=fromTokenAddress,proto3" json:"from_token_address,omitempty"`
+	FromTokenSymbol          string                 `protobuf:"bytes,18,opt,name=from_token_symbol,json=fromTokenSymbol,proto3" json:"from_token_symbol,omitempty"`
+	FromTokenAmount          string                 `protobuf:"bytes,19,opt,name=from_token_amount,json=fromTokenAmount,proto3" json:"from_token_amount,omitempty"`
+	DestTokenAddress         string                 `protobuf:"bytes,20,opt,name=dest_token_address,json=destTokenAddress,proto3" json:"dest_token_address,omitempty"`
+	DestTokenSymbol          string                 `protobuf:"bytes,21,opt,name=dest_token_symbol,json=destTokenSymbol,proto3" json:"dest_token_symbol,omitempty"`
+	DestTokenAmount          string                 `protobuf:"bytes,22,opt,name=dest_token_amount,json=destTokenAmount,proto3" json:"dest_token_amount,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_smart_money_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Transaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Transaction) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *Transaction) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *Transaction) GetTokenIcon() string {
+	if x != nil {
+		return x.TokenIcon
+	}
+	return ""
+}
+
+func (x *Transaction) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *Transaction) GetTransactionTime() int64 {
+	if x != nil {
+		return x.TransactionTime
+	}
+	return 0
+}
+
+func (x *Transaction) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Transaction) GetLogIndex() int32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *Transaction) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Transaction) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Transaction) GetMarketcap() string {
+	if x != nil {
+		return x.Marketcap
+	}
+	return ""
+}
+
+func (x *Transaction) GetRealizedProfit() string {
+	if x != nil {
+		return x.RealizedProfit
+	}
+	return ""
+}
+
+func (x *Transaction) GetRealizedProfitPercentage() string {
+	if x != nil {
+		return x.RealizedProfitPercentage
+	}
+	return ""
+}
+
+func (x *Transaction) GetFromTokenAddress() string {
+	if x != nil {
+		return x.FromTokenAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetFromTokenSymbol() string {
+	if x != nil {
+		return x.FromTokenSymbol
+	}
+	return ""
+}
+
+func (x *Transaction) GetFromTokenAmount() string {
+	if x != nil {
+		return x.FromTokenAmount
+	}
+	return ""
+}
+
+func (x *Transaction) GetDestTokenAddress() string {
+	if x != nil {
+		return x.DestTokenAddress
+	}
+	return ""
+}
+
+func (x *Transaction) GetDestTokenSymbol() string {
+	if x != nil {
+		return x.DestTokenSymbol
+	}
+	return ""
+}
+
+func (x *Transaction) GetDestTokenAmount() string {
+	if x != nil {
+		return x.DestTokenAmount
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Transaction         `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // 为空表示没有更多数据
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_smart_money_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListTransactionsResponse) GetItems() []*Transaction {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetTokenSmartMoneyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TokenAddress  string                 `protobuf:"bytes,2,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenSmartMoneyRequest) Reset() {
+	*x = GetTokenSmartMoneyRequest{}
+	mi := &file_smart_money_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenSmartMoneyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenSmartMoneyRequest) ProtoMessage() {}
+
+func (x *GetTokenSmartMoneyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenSmartMoneyRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenSmartMoneyRequest) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTokenSmartMoneyRequest) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *GetTokenSmartMoneyRequest) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+type TokenSmartMoney struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ChainId              uint64                 `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	TokenAddress         string                 `protobuf:"bytes,2,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	HolderCount          int64                  `protobuf:"varint,3,opt,name=holder_count,json=holderCount,proto3" json:"holder_count,omitempty"`
+	HoldingValueUsd      string                 `protobuf:"bytes,4,opt,name=holding_value_usd,json=holdingValueUsd,proto3" json:"holding_value_usd,omitempty"`
+	HoldingAmount        string                 `protobuf:"bytes,5,opt,name=holding_amount,json=holdingAmount,proto3" json:"holding_amount,omitempty"`
+	HoldingSupplyPercent string                 `protobuf:"bytes,6,opt,name=holding_supply_percent,json=holdingSupplyPercent,proto3" json:"holding_supply_percent,omitempty"`
+	AvgEntryMarketcap    string                 `protobuf:"bytes,7,opt,name=avg_entry_marketcap,json=avgEntryMarketcap,proto3" json:"avg_entry_marketcap,omitempty"`
+	BuyValue_24H         string                 `protobuf:"bytes,8,opt,name=buy_value_24h,json=buyValue24h,proto3" json:"buy_value_24h,omitempty"`
+	SellValue_24H        string                 `protobuf:"bytes,9,opt,name=sell_value_24h,json=sellValue24h,proto3" json:"sell_value_24h,omitempty"`
+	NetFlow_24H          string                 `protobuf:"bytes,10,opt,name=net_flow_24h,json=netFlow24h,proto3" json:"net_flow_24h,omitempty"`
+	FirstBuyTime         int64                  `protobuf:"varint,11,opt,name=first_buy_time,json=firstBuyTime,proto3" json:"first_buy_time,omitempty"`
+	LastBuyTime          int64                  `protobuf:"varint,12,opt,name=last_buy_time,json=lastBuyTime,proto3" json:"last_buy_time,omitempty"`
+	UpdatedAt            int64                  `protobuf:"varint,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *TokenSmartMoney) Reset() {
+	*x = TokenSmartMoney{}
+	mi := &file_smart_money_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenSmartMoney) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenSmartMoney) ProtoMessage() {}
+
+func (x *TokenSmartMoney) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenSmartMoney.ProtoReflect.Descriptor instead.
+func (*TokenSmartMoney) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TokenSmartMoney) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *TokenSmartMoney) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetHolderCount() int64 {
+	if x != nil {
+		return x.HolderCount
+	}
+	return 0
+}
+
+func (x *TokenSmartMoney) GetHoldingValueUsd() string {
+	if x != nil {
+		return x.HoldingValueUsd
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetHoldingAmount() string {
+	if x != nil {
+		return x.HoldingAmount
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetHoldingSupplyPercent() string {
+	if x != nil {
+		return x.HoldingSupplyPercent
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetAvgEntryMarketcap() string {
+	if x != nil {
+		return x.AvgEntryMarketcap
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetBuyValue_24H() string {
+	if x != nil {
+		return x.BuyValue_24H
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetSellValue_24H() string {
+	if x != nil {
+		return x.SellValue_24H
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetNetFlow_24H() string {
+	if x != nil {
+		return x.NetFlow_24H
+	}
+	return ""
+}
+
+func (x *TokenSmartMoney) GetFirstBuyTime() int64 {
+	if x != nil {
+		return x.FirstBuyTime
+	}
+	return 0
+}
+
+func (x *TokenSmartMoney) GetLastBuyTime() int64 {
+	if x != nil {
+		return x.LastBuyTime
+	}
+	return 0
+}
+
+func (x *TokenSmartMoney) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type StreamSmartTradesRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ChainIds        []uint64               `protobuf:"varint,1,rep,packed,name=chain_ids,json=chainIds,proto3" json:"chain_ids,omitempty"`              // 为空不限链
+	WalletAddresses []string               `protobuf:"bytes,2,rep,name=wallet_addresses,json=walletAddresses,proto3" json:"wallet_addresses,omitempty"` // 最多 200 个
+	TokenAddresses  []string               `protobuf:"bytes,3,rep,name=token_addresses,json=tokenAddresses,proto3" json:"token_addresses,omitempty"`    // 最多 50 个
+	MinValue        string                 `protobuf:"bytes,4,opt,name=min_value,json=minValue,proto3" json:"min_value,omitempty"`                      // USD
+	Since           int64                  `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`                                           // 毫秒，含边界，客户端按 id 去重
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StreamSmartTradesRequest) Reset() {
+	*x = StreamSmartTradesRequest{}
+	mi := &file_smart_money_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSmartTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSmartTradesRequest) ProtoMessage() {}
+
+func (x *StreamSmartTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSmartTradesRequest.ProtoReflect.Descriptor instead.
+func (*StreamSmartTradesRequest) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamSmartTradesRequest) GetChainIds() []uint64 {
+	if x != nil {
+		return x.ChainIds
+	}
+	return nil
+}
+
+func (x *StreamSmartTradesRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+func (x *StreamSmartTradesRequest) GetTokenAddresses() []string {
+	if x != nil {
+		return x.TokenAddresses
+	}
+	return nil
+}
+
+func (x *StreamSmartTradesRequest) GetMinValue() string {
+	if x != nil {
+		return x.MinValue
+	}
+	return ""
+}
+
+func (x *StreamSmartTradesRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type SmartTrade struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	Id                       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ChainId                  uint64                 `protobuf:"varint,2,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	WalletAddress            string                 `protobuf:"bytes,3,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	WalletBalance            string                 `protobuf:"bytes,4,opt,name=wallet_balance,json=walletBalance,proto3" json:"wallet_balance,omitempty"`
+	TokenAddress             string                 `protobuf:"bytes,5,opt,name=token_address,json=tokenAddress,proto3" json:"token_address,omitempty"`
+	TokenName                string                 `protobuf:"bytes,6,opt,name=token_name,json=tokenName,proto3" json:"token_name,omitempty"`
+	TokenIcon                string                 `protobuf:"bytes,7,opt,name=token_icon,json=tokenIcon,proto3" json:"token_icon,omitempty"`
+	TransactionType          string                 `protobuf:"bytes,8,opt,name=transaction_type,json=transactionType,proto3" json:"transaction_type,omitempty"`
+	TransactionTime          int64                  `protobuf:"varint,9,opt,name=transaction_time,json=transactionTime,proto3" json:"transaction_time,omitempty"`
+	Signature                string                 `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+	LogIndex                 int32                  `protobuf:"varint,11,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Price                    string                 `protobuf:"bytes,12,opt,name=price,proto3" json:"price,omitempty"`
+	Amount                   string                 `protobuf:"bytes,13,opt,name=amount,proto3" json:"amount,omitempty"`
+	Value                    string                 `protobuf:"bytes,14,opt,name=value,proto3" json:"value,omitempty"`
+	Marketcap                string                 `protobuf:"bytes,15,opt,name=marketcap,proto3" json:"marketcap,omitempty"`
+	HoldingPercentage        string                 `protobuf:"bytes,16,opt,name=holding_percentage,json=holdingPercentage,proto3" json:"holding_percentage,omitempty"`
+	RealizedProfit           string                 `protobuf:"bytes,17,opt,name=realized_profit,json=realizedProfit,proto3" json:"realized_profit,omitempty"`
+	RealizedProfitPercentage string                 `protobuf:"bytes,18,opt,name=realized_profit_percentage,json=realizedProfitPercentage,proto3" json:"realized_profit_percentage,omitempty"`
+	FromTokenAddress         string                 `protobuf:"bytes,19,opt,name=from_token_address,json=fromTokenAddress,proto3" json:"from_token_address,omitempty"`
+	FromTokenSymbol          string                 `protobuf:"bytes,20,opt,name=from_token_symbol,json=fromTokenSymbol,proto3" json:"from_token_symbol,omitempty"`
+	FromTokenAmount          string                 `protobuf:"bytes,21,opt,name=from_token_amount,json=fromTokenAmount,proto3" json:"from_token_amount,omitempty"`
+	DestTokenAddress         string                 `protobuf:"bytes,22,opt,name=dest_token_address,json=destTokenAddress,proto3" json:"dest_token_address,omitempty"`
+	DestTokenSymbol          string                 `protobuf:"bytes,23,opt,name=dest_token_symbol,json=destTokenSymbol,proto3" json:"dest_token_symbol,omitempty"`
+	DestTokenAmount          string                 `protobuf:"bytes,24,opt,name=dest_token_amount,json=destTokenAmount,proto3" json:"dest_token_amount,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *SmartTrade) Reset() {
+	*x = SmartTrade{}
+	mi := &file_smart_money_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SmartTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SmartTrade) ProtoMessage() {}
+
+func (x *SmartTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_smart_money_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SmartTrade.ProtoReflect.Descriptor instead.
+func (*SmartTrade) Descriptor() ([]byte, []int) {
+	return file_smart_money_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SmartTrade) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetChainId() uint64 {
+	if x != nil {
+		return x.ChainId
+	}
+	return 0
+}
+
+func (x *SmartTrade) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetWalletBalance() string {
+	if x != nil {
+		return x.WalletBalance
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetTokenAddress() string {
+	if x != nil {
+		return x.TokenAddress
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetTokenName() string {
+	if x != nil {
+		return x.TokenName
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetTokenIcon() string {
+	if x != nil {
+		return x.TokenIcon
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetTransactionType() string {
+	if x != nil {
+		return x.TransactionType
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetTransactionTime() int64 {
+	if x != nil {
+		return x.TransactionTime
+	}
+	return 0
+}
+
+func (x *SmartTrade) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetLogIndex() int32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
+}
+
+func (x *SmartTrade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetMarketcap() string {
+	if x != nil {
+		return x.Marketcap
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetHoldingPercentage() string {
+	if x != nil {
+		return x.HoldingPercentage
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetRealizedProfit() string {
+	if x != nil {
+		return x.RealizedProfit
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetRealizedProfitPercentage() string {
+	if x != nil {
+		return x.RealizedProfitPercentage
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetFromTokenAddress() string {
+	if x != nil {
+		return x.FromTokenAddress
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetFromTokenSymbol() string {
+	if x != nil {
+		return x.FromTokenSymbol
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetFromTokenAmount() string {
+	if x != nil {
+		return x.FromTokenAmount
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetDestTokenAddress() string {
+	if x != nil {
+		return x.DestTokenAddress
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetDestTokenSymbol() string {
+	if x != nil {
+		return x.DestTokenSymbol
+	}
+	return ""
+}
+
+func (x *SmartTrade) GetDestTokenAmount() string {
+	if x != nil {
+		return x.DestTokenAmount
+	}
+	return ""
+}
+
+var File_smart_money_proto protoreflect.FileDescriptor
+
+const file_smart_money_proto_rawDesc = "" +
+	"\n" +
+	"\x11smart_money.proto\x12\rsmartmoney.v1\"T\n" +
+	"\x10GetWalletRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\"\x82\x02\n" +
+	"\x11WalletPeriodStats\x12\x10\n" +
+	"\x03pnl\x18\x01 \x01(\tR\x03pnl\x12%\n" +
+	"\x0epnl_percentage\x18\x02 \x01(\tR\rpnlPercentage\x12+\n" +
+	"\x11unrealized_profit\x18\x03 \x01(\tR\x10unrealizedProfit\x12\x1d\n" +
+	"\n" +
+	"total_cost\x18\x04 \x01(\tR\ttotalCost\x12\x19\n" +
+	"\bavg_cost\x18\x05 \x01(\tR\aavgCost\x12\x19\n" +
+	"\bwin_rate\x18\x06 \x01(\tR\awinRate\x12\x17\n" +
+	"\abuy_num\x18\a \x01(\x05R\x06buyNum\x12\x19\n" +
+	"\bsell_num\x18\b \x01(\x05R\asellNum\"\xf3\x04\n" +
+	"\x06Wallet\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12\x16\n" +
+	"\x06avatar\x18\x03 \x01(\tR\x06avatar\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12!\n" +
+	"\ftwitter_name\x18\x05 \x01(\tR\vtwitterName\x12)\n" +
+	"\x10twitter_username\x18\x06 \x01(\tR\x0ftwitterUsername\x12\x1f\n" +
+	"\vwallet_type\x18\a \x01(\x05R\n" +
+	"walletType\x12\x18\n" +
+	"\abalance\x18\b \x01(\tR\abalance\x12\x1f\n" +
+	"\vbalance_usd\x18\t \x01(\tR\n" +
+	"balanceUsd\x12;\n" +
+	"\bstats_1d\x18\n" +
+	" \x01(\v2 .smartmoney.v1.WalletPeriodStatsR\astats1d\x12;\n" +
+	"\bstats_7d\x18\v \x01(\v2 .smartmoney.v1.WalletPeriodStatsR\astats7d\x12=\n" +
+	"\tstats_30d\x18\f \x01(\v2 .smartmoney.v1.WalletPeriodStatsR\bstats30d\x12(\n" +
+	"\x10max_drawdown_30d\x18\r \x01(\tR\x0emaxDrawdown30d\x122\n" +
+	"\x15last_transaction_time\x18\x0e \x01(\x03R\x13lastTransactionTime\x12\x1b\n" +
+	"\tis_active\x18\x0f \x01(\bR\bisActive\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\x03R\tupdatedAt\"\xff\x01\n" +
+	"\x13ListHoldingsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12#\n" +
+	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\"\n" +
+	"\rmin_value_usd\x18\x05 \x01(\x01R\vminValueUsd\x12\x17\n" +
+	"\asort_by\x18\x06 \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05limit\x18\a \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\b \x01(\x05R\x06offset\"\xc1\x04\n" +
+	"\aHolding\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12#\n" +
+	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12\x1d\n" +
+	"\n" +
+	"token_name\x18\x04 \x01(\tR\ttokenName\x12\x1d\n" +
+	"\n" +
+	"token_icon\x18\x05 \x01(\tR\ttokenIcon\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\tR\x06amount\x12\x1b\n" +
+	"\tvalue_usd\x18\a \x01(\tR\bvalueUsd\x12-\n" +
+	"\x12unrealized_profits\x18\b \x01(\tR\x11unrealizedProfits\x12\x10\n" +
+	"\x03pnl\x18\t \x01(\tR\x03pnl\x12%\n" +
+	"\x0epnl_percentage\x18\n" +
+	" \x01(\tR\rpnlPercentage\x12\x1b\n" +
+	"\tavg_price\x18\v \x01(\tR\bavgPrice\x12,\n" +
+	"\x12current_total_cost\x18\f \x01(\tR\x10currentTotalCost\x12\x1c\n" +
+	"\tmarketcap\x18\r \x01(\tR\tmarketcap\x12\x15\n" +
+	"\x06is_dev\x18\x0e \x01(\bR\x05isDev\x12\x12\n" +
+	"\x04tags\x18\x0f \x03(\tR\x04tags\x12,\n" +
+	"\x12position_opened_at\x18\x10 \x01(\x03R\x10positionOpenedAt\x122\n" +
+	"\x15last_transaction_time\x18\x11 \x01(\x03R\x13lastTransactionTime\"Z\n" +
+	"\x14ListHoldingsResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.smartmoney.v1.HoldingR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\"\xd9\x01\n" +
+	"\x17ListTransactionsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12#\n" +
+	"\rtoken_address\x18\x03 \x01(\tR\ftokenAddress\x12)\n" +
+	"\x10transaction_type\x18\x04 \x01(\tR\x0ftransactionType\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\"\xa8\x06\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x03 \x01(\tR\rwalletAddress\x12#\n" +
+	"\rtoken_address\x18\x04 \x01(\tR\ftokenAddress\x12\x1d\n" +
+	"\n" +
+	"token_name\x18\x05 \x01(\tR\ttokenName\x12\x1d\n" +
+	"\n" +
+	"token_icon\x18\x06 \x01(\tR\ttokenIcon\x12)\n" +
+	"\x10transaction_type\x18\a \x01(\tR\x0ftransactionType\x12)\n" +
+	"\x10transaction_time\x18\b \x01(\x03R\x0ftransactionTime\x12\x1c\n" +
+	"\tsignature\x18\t \x01(\tR\tsignature\x12\x1b\n" +
+	"\tlog_index\x18\n" +
+	" \x01(\x05R\blogIndex\x12\x14\n" +
+	"\x05price\x18\v \x01(\tR\x05price\x12\x16\n" +
+	"\x06amount\x18\f \x01(\tR\x06amount\x12\x14\n" +
+	"\x05value\x18\r \x01(\tR\x05value\x12\x1c\n" +
+	"\tmarketcap\x18\x0e \x01(\tR\tmarketcap\x12'\n" +
+	"\x0frealized_profit\x18\x0f \x01(\tR\x0erealizedProfit\x12<\n" +
+	"\x1arealized_profit_percentage\x18\x10 \x01(\tR\x18realizedProfitPercentage\x12,\n" +
+	"\x12from_token_address\x18\x11 \x01(\tR\x10fromTokenAddress\x12*\n" +
+	"\x11from_token_symbol\x18\x12 \x01(\tR\x0ffromTokenSymbol\x12*\n" +
+	"\x11from_token_amount\x18\x13 \x01(\tR\x0ffromTokenAmount\x12,\n" +
+	"\x12dest_token_address\x18\x14 \x01(\tR\x10destTokenAddress\x12*\n" +
+	"\x11dest_token_symbol\x18\x15 \x01(\tR\x0fdestTokenSymbol\x12*\n" +
+	"\x11dest_token_amount\x18\x16 \x01(\tR\x0fdestTokenAmount\"m\n" +
+	"\x18ListTransactionsResponse\x120\n" +
+	"\x05items\x18\x01 \x03(\v2\x1a.smartmoney.v1.TransactionR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"[\n" +
+	"\x19GetTokenSmartMoneyRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12#\n" +
+	"\rtoken_address\x18\x02 \x01(\tR\ftokenAddress\"\x82\x04\n" +
+	"\x0fTokenSmartMoney\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\x04R\achainId\x12#\n" +
+	"\rtoken_address\x18\x02 \x01(\tR\ftokenAddress\x12!\n" +
+	"\fholder_count\x18\x03 \x01(\x03R\vholderCount\x12*\n" +
+	"\x11holding_value_usd\x18\x04 \x01(\tR\x0fholdingValueUsd\x12%\n" +
+	"\x0eholding_amount\x18\x05 \x01(\tR\rholdingAmount\x124\n" +
+	"\x16holding_supply_percent\x18\x06 \x01(\tR\x14holdingSupplyPercent\x12.\n" +
+	"\x13avg_entry_marketcap\x18\a \x01(\tR\x11avgEntryMarketcap\x12\"\n" +
+	"\rbuy_value_24h\x18\b \x01(\tR\vbuyValue24h\x12$\n" +
+	"\x0esell_value_24h\x18\t \x01(\tR\fsellValue24h\x12 \n" +
+	"\fnet_flow_24h\x18\n" +
+	" \x01(\tR\n" +
+	"netFlow24h\x12$\n" +
+	"\x0efirst_buy_time\x18\v \x01(\x03R\ffirstBuyTime\x12\"\n" +
+	"\rlast_buy_time\x18\f \x01(\x03R\vlastBuyTime\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\x03R\tupdatedAt\"\xbe\x01\n" +
+	"\x18StreamSmartTradesRequest\x12\x1b\n" +
+	"\tchain_ids\x18\x01 \x03(\x04R\bchainIds\x12)\n" +
+	"\x10wallet_addresses\x18\x02 \x03(\tR\x0fwalletAddresses\x12'\n" +
+	"\x0ftoken_addresses\x18\x03 \x03(\tR\x0etokenAddresses\x12\x1b\n" +
+	"\tmin_value\x18\x04 \x01(\tR\bminValue\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\x03R\x05since\"\xfd\x06\n" +
+	"\n" +
+	"SmartTrade\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\bchain_id\x18\x02 \x01(\x04R\achainId\x12%\n" +
+	"\x0ewallet_address\x18\x03 \x01(\tR\rwalletAddress\x12%\n" +
+	"\x0ewallet_balance\x18\x04 \x01(\tR\rwalletBalance\x12#\n" +
+	"\rtoken_address\x18\x05 \x01(\tR\ftokenAddress\x12\x1d\n" +
+	"\n" +
+	"token_name\x18\x06 \x01(\tR\ttokenName\x12\x1d\n" +
+	"\n" +
+	"token_icon\x18\a \x01(\tR\ttokenIcon\x12)\n" +
+	"\x10transaction_type\x18\b \x01(\tR\x0ftransactionType\x12)\n" +
+	"\x10transaction_time\x18\t \x01(\x03R\x0ftransactionTime\x12\x1c\n" +
+	"\tsignature\x18\n" +
+	" \x01(\tR\tsignature\x12\x1b\n" +
+	"\tlog_index\x18\v \x01(\x05R\blogIndex\x12\x14\n" +
+	"\x05price\x18\f \x01(\tR\x05price\x12\x16\n" +
+	"\x06amount\x18\r \x01(\tR\x06amount\x12\x14\n" +
+	"\x05value\x18\x0e \x01(\tR\x05value\x12\x1c\n" +
+	"\tmarketcap\x18\x0f \x01(\tR\tmarketcap\x12-\n" +
+	"\x12holding_percentage\x18\x10 \x01(\tR\x11holdingPercentage\x12'\n" +
+	"\x0frealized_profit\x18\x11 \x01(\tR\x0erealizedProfit\x12<\n" +
+	"\x1arealized_profit_percentage\x18\x12 \x01(\tR\x18realizedProfitPercentage\x12,\n" +
+	"\x12from_token_address\x18\x13 \x01(\tR\x10fromTokenAddress\x12*\n" +
+	"\x11from_token_symbol\x18\x14 \x01(\tR\x0ffromTokenSymbol\x12*\n" +
+	"\x11from_token_amount\x18\x15 \x01(\tR\x0ffromTokenAmount\x12,\n" +
+	"\x12dest_token_address\x18\x16 \x01(\tR\x10destTokenAddress\x12*\n" +
+	"\x11dest_token_symbol\x18\x17 \x01(\tR\x0fdestTokenSymbol\x12*\n" +
+	"\x11dest_token_amount\x18\x18 \x01(\tR\x0fdestTokenAmount2\xd1\x03\n" +
+	"\x11SmartMoneyService\x12C\n" +
+	"\tGetWallet\x12\x1f.smartmoney.v1.GetWalletRequest\x1a\x15.smartmoney.v1.Wallet\x12W\n" +
+	"\fListHoldings\x12\".smartmoney.v1.ListHoldingsRequest\x1a#.smartmoney.v1.ListHoldingsResponse\x12c\n" +
+	"\x10ListTransactions\x12&.smartmoney.v1.ListTransactionsRequest\x1a'.smartmoney.v1.ListTransactionsResponse\x12^\n" +
+	"\x12GetTokenSmartMoney\x12(.smartmoney.v1.GetTokenSmartMoneyRequest\x1a\x1e.smartmoney.v1.TokenSmartMoney\x12Y\n" +
+	"\x11StreamSmartTrades\x12'.smartmoney.v1.StreamSmartTradesRequest\x1a\x19.smartmoney.v1.SmartTrade0\x01B*Z(web3-smart/pkg/smartmoneypb;smartmoneypbb\x06proto3"
+
+var (
+	file_smart_money_proto_rawDescOnce sync.Once
+	file_smart_money_proto_rawDescData []byte
+)
+
+func file_smart_money_proto_rawDescGZIP() []byte {
+	file_smart_money_proto_rawDescOnce.Do(func() {
+		file_smart_money_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_smart_money_proto_rawDesc), len(file_smart_money_proto_rawDesc)))
+	})
+	return file_smart_money_proto_rawDescData
+}
+
+var file_smart_money_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_smart_money_proto_goTypes = []any{
+	(*GetWalletRequest)(nil),          // 0: smartmoney.v1.GetWalletRequest
+	(*WalletPeriodStats)(nil),         // 1: smartmoney.v1.WalletPeriodStats
+	(*Wallet)(nil),                    // 2: smartmoney.v1.Wallet
+	(*ListHoldingsRequest)(nil),       // 3: smartmoney.v1.ListHoldingsRequest
+	(*Holding)(nil),                   // 4: smartmoney.v1.Holding
+	(*ListHoldingsResponse)(nil),      // 5: smartmoney.v1.ListHoldingsResponse
+	(*ListTransactionsRequest)(nil),   // 6: smartmoney.v1.ListTransactionsRequest
+	(*Transaction)(nil),               // 7: smartmoney.v1.Transaction
+	(*ListTransactionsResponse)(nil),  // 8: smartmoney.v1.ListTransactionsResponse
+	(*GetTokenSmartMoneyRequest)(nil), // 9: smartmoney.v1.GetTokenSmartMoneyRequest
+	(*TokenSmartMoney)(nil),           // 10: smartmoney.v1.TokenSmartMoney
+	(*StreamSmartTradesRequest)(nil),  // 11: smartmoney.v1.StreamSmartTradesRequest
+	(*SmartTrade)(nil),                // 12: smartmoney.v1.SmartTrade
+}
+var file_smart_money_proto_depIdxs = []int32{
+	1,  // 0: smartmoney.v1.Wallet.stats_1d:type_name -> smartmoney.v1.WalletPeriodStats
+	1,  // 1: smartmoney.v1.Wallet.stats_7d:type_name -> smartmoney.v1.WalletPeriodStats
+	1,  // 2: smartmoney.v1.Wallet.stats_30d:type_name -> smartmoney.v1.WalletPeriodStats
+	4,  // 3: smartmoney.v1.ListHoldingsResponse.items:type_name -> smartmoney.v1.Holding
+	7,  // 4: smartmoney.v1.ListTransactionsResponse.items:type_name -> smartmoney.v1.Transaction
+	0,  // 5: smartmoney.v1.SmartMoneyService.GetWallet:input_type -> smartmoney.v1.GetWalletRequest
+	3,  // 6: smartmoney.v1.SmartMoneyService.ListHoldings:input_type -> smartmoney.v1.ListHoldingsRequest
+	6,  // 7: smartmoney.v1.SmartMoneyService.ListTransactions:input_type -> smartmoney.v1.ListTransactionsRequest
+	9,  // 8: smartmoney.v1.SmartMoneyService.GetTokenSmartMoney:input_type -> smartmoney.v1.GetTokenSmartMoneyRequest
+	11, // 9: smartmoney.v1.SmartMoneyService.StreamSmartTrades:input_type -> smartmoney.v1.StreamSmartTradesRequest
+	2,  // 10: smartmoney.v1.SmartMoneyService.GetWallet:output_type -> smartmoney.v1.Wallet
+	5,  // 11: smartmoney.v1.SmartMoneyService.ListHoldings:output_type -> smartmoney.v1.ListHoldingsResponse
+	8,  // 12: smartmoney.v1.SmartMoneyService.ListTransactions:output_type -> smartmoney.v1.ListTransactionsResponse
+	10, // 13: smartmoney.v1.SmartMoneyService.GetTokenSmartMoney:output_type -> smartmoney.v1.TokenSmartMoney
+	12, // 14: smartmoney.v1.SmartMoneyService.StreamSmartTrades:output_type -> smartmoney.v1.SmartTrade
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_smart_money_proto_init() }
+func file_smart_money_proto_init() {
+	if File_smart_money_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_smart_money_proto_rawDesc), len(file_smart_money_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_smart_money_proto_goTypes,
+		DependencyIndexes: file_smart_money_proto_depIdxs,
+		MessageInfos:      file_smart_money_proto_msgTypes,
+	}.Build()
+	File_smart_money_proto = out.File
+	file_smart_money_proto_goTypes = nil
+	file_smart_money_proto_depIdxs = nil
+}
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: smart_money.proto
+
+package smartmoneypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SmartMoneyService_GetWallet_FullMethodName          = "/smartmoney.v1.SmartMoneyService/GetWallet"
+	SmartMoneyService_ListHoldings_FullMethodName       = "/smartmoney.v1.SmartMoneyService/ListHoldings"
+	SmartMoneyService_ListTransactions_FullMethodName   = "/smartmoney.v1.SmartMoneyService/ListTransactions"
+	SmartMoneyService_GetTokenSmartMoney_FullMethodName = "/smartmoney.v1.SmartMoneyService/GetTokenSmartMoney"
+	SmartMoneyService_StreamSmartTrades_FullMethodName  = "/smartmoney.v1.SmartMoneyService/StreamSmartTrades"
+)
+
+// SmartMoneyServiceClient is the client API for SmartMoneyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// # SmartMoneyService 聪明钱数据查询与成交推送
+//
+// 金额、价格等 decimal 字段统一用字符串传输，避免精度丢失；时间字段均为毫秒时间戳。
+type SmartMoneyServiceClient interface {
+	// GetWallet 钱包摘要，钱包不存在时返回 NOT_FOUND
+	GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*Wallet, error)
+	// ListHoldings 钱包持仓，offset 分页
+	ListHoldings(ctx context.Context, in *ListHoldingsRequest, opts ...grpc.CallOption) (*ListHoldingsResponse, error)
+	// ListTransactions 钱包交易记录，按 (transaction_time, id) 倒序游标分页
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// GetTokenSmartMoney token 维度聪明钱聚合信息（web3_tokens.smart_money_info）
+	GetTokenSmartMoney(ctx context.Context, in *GetTokenSmartMoneyRequest, opts ...grpc.CallOption) (*TokenSmartMoney, error)
+	// StreamSmartTrades 聪明钱实时成交，since 不为 0 时先回放 latest_trades 中 since 之后的成交
+	StreamSmartTrades(ctx context.Context, in *StreamSmartTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SmartTrade], error)
+}
+
+type smartMoneyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSmartMoneyServiceClient(cc grpc.ClientConnInterface) SmartMoneyServiceClient {
+	return &smartMoneyServiceClient{cc}
+}
+
+func (c *smartMoneyServiceClient) GetWallet(ctx context.Context, in *GetWalletRequest, opts ...grpc.CallOption) (*Wallet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Wallet)
+	err := c.cc.Invoke(ctx, SmartMoneyService_GetWallet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartMoneyServiceClient) ListHoldings(ctx context.Context, in *ListHoldingsRequest, opts ...grpc.CallOption) (*ListHoldingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHoldingsResponse)
+	err := c.cc.Invoke(ctx, SmartMoneyService_ListHoldings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartMoneyServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, SmartMoneyService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartMoneyServiceClient) GetTokenSmartMoney(ctx context.Context, in *GetTokenSmartMoneyRequest, opts ...grpc.CallOption) (*TokenSmartMoney, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenSmartMoney)
+	err := c.cc.Invoke(ctx, SmartMoneyService_GetTokenSmartMoney_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *smartMoneyServiceClient) StreamSmartTrades(ctx context.Context, in *StreamSmartTradesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SmartTrade], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SmartMoneyService_ServiceDesc.Streams[0], SmartMoneyService_StreamSmartTrades_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamSmartTradesRequest, SmartTrade]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SmartMoneyService_StreamSmartTradesClient = grpc.ServerStreamingClient[SmartTrade]
+
+// SmartMoneyServiceServer is the server API for SmartMoneyService service.
+// All implementations must embed UnimplementedSmartMoneyServiceServer
+// for forward compatibility.
+//
+// # SmartMoneyService 聪明钱数据查询与成交推送
+//
+// 金额、价格等 decimal 字段统一用字符串传输，避免精度丢失；时间字段均为毫秒时间戳。
+type SmartMoneyServiceServer interface {
+	// GetWallet 钱包摘要，钱包不存在时返回 NOT_FOUND
+	GetWallet(context.Context, *GetWalletRequest) (*Wallet, error)
+	// ListHoldings 钱包持仓，offset 分页
+	ListHoldings(context.Context, *ListHoldingsRequest) (*ListHoldingsResponse, error)
+	// ListTransactions 钱包交易记录，按 (transaction_time, id) 倒序游标分页
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// GetTokenSmartMoney token 维度聪明钱聚合信息（web3_tokens.smart_money_info）
+	GetTokenSmartMoney(context.Context, *GetTokenSmartMoneyRequest) (*TokenSmartMoney, error)
+	// StreamSmartTrades 聪明钱实时成交，since 不为 0 时先回放 latest_trades 中 since 之后的成交
+	StreamSmartTrades(*StreamSmartTradesRequest, grpc.ServerStreamingServer[SmartTrade]) error
+	mustEmbedUnimplementedSmartMoneyServiceServer()
+}
+
+// UnimplementedSmartMoneyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSmartMoneyServiceServer struct{}
+
+func (UnimplementedSmartMoneyServiceServer) GetWallet(context.Context, *GetWalletRequest) (*Wallet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWallet not implemented")
+}
+func (UnimplementedSmartMoneyServiceServer) ListHoldings(context.Context, *ListHoldingsRequest) (*ListHoldingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHoldings not implemented")
+}
+func (UnimplementedSmartMoneyServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedSmartMoneyServiceServer) GetTokenSmartMoney(context.Context, *GetTokenSmartMoneyRequest) (*TokenSmartMoney, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTokenSmartMoney not implemented")
+}
+func (UnimplementedSmartMoneyServiceServer) StreamSmartTrades(*StreamSmartTradesRequest, grpc.ServerStreamingServer[SmartTrade]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSmartTrades not implemented")
+}
+func (UnimplementedSmartMoneyServiceServer) mustEmbedUnimplementedSmartMoneyServiceServer() {}
+func (UnimplementedSmartMoneyServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeSmartMoneyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SmartMoneyServiceServer will
+// result in compilation errors.
+type UnsafeSmartMoneyServiceServer interface {
+	mustEmbedUnimplementedSmartMoneyServiceServer()
+}
+
+func RegisterSmartMoneyServiceServer(s grpc.ServiceRegistrar, srv SmartMoneyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSmartMoneyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SmartMoneyService_ServiceDesc, srv)
+}
+
+func _SmartMoneyService_GetWallet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWalletRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartMoneyServiceServer).GetWallet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SmartMoneyService_GetWallet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartMoneyServiceServer).GetWallet(ctx, req.(*GetWalletRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmartMoneyService_ListHoldings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHoldingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartMoneyServiceServer).ListHoldings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SmartMoneyService_ListHoldings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartMoneyServiceServer).ListHoldings(ctx, req.(*ListHoldingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmartMoneyService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartMoneyServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SmartMoneyService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartMoneyServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmartMoneyService_GetTokenSmartMoney_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenSmartMoneyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SmartMoneyServiceServer).GetTokenSmartMoney(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SmartMoneyService_GetTokenSmartMoney_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SmartMoneyServiceServer).GetTokenSmartMoney(ctx, req.(*GetTokenSmartMoneyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SmartMoneyService_StreamSmartTrades_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSmartTradesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SmartMoneyServiceServer).StreamSmartTrades(m, &grpc.GenericServerStream[StreamSmartTradesRequest, SmartTrade]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SmartMoneyService_StreamSmartTradesServer = grpc.ServerStreamingServer[SmartTrade]
+
+// SmartMoneyService_ServiceDesc is the grpc.ServiceDesc for SmartMoneyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SmartMoneyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "smartmoney.v1.SmartMoneyService",
+	HandlerType: (*SmartMoneyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWallet",
+			Handler:    _SmartMoneyService_GetWallet_Handler,
+		},
+		{
+			MethodName: "ListHoldings",
+			Handler:    _SmartMoneyService_ListHoldings_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _SmartMoneyService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "GetTokenSmartMoney",
+			Handler:    _SmartMoneyService_GetTokenSmartMoney_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSmartTrades",
+			Handler:       _SmartMoneyService_StreamSmartTrades_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "smart_money.proto",
+}
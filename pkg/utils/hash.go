@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"hash/crc32"
 )
@@ -14,3 +17,10 @@ func GetHashBucket(key string, bucketSize uint32) uint32 {
 	// 后续如果交易都集中在某几个池子里，导致worker负载不一致，修改hash算法
 	return crc32.ChecksumIEEE([]byte(key)) % bucketSize
 }
+
+// HmacSHA256Hex 计算 HMAC-SHA256 签名，返回十六进制字符串
+func HmacSHA256Hex(secret string, data []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
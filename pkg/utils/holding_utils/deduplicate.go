@@ -5,16 +5,23 @@ import (
 	"web3-smart/internal/worker/model"
 )
 
-// 根据wallet_address, token_address, chain_id去重
+// HoldingKey 持仓唯一键 wallet_address:token_address:chain_id
+func HoldingKey(holding model.WalletHolding) string {
+	return fmt.Sprintf("%s:%s:%d", holding.WalletAddress, holding.TokenAddress, holding.ChainID)
+}
+
+// 根据wallet_address, token_address, chain_id去重，重复时保留最后一条（最新状态）
 func DeduplicateHoldings(holdings []model.WalletHolding) []model.WalletHolding {
-	deduplicatedHoldings := make([]model.WalletHolding, 0)
-	seen := make(map[string]struct{})
+	deduplicatedHoldings := make([]model.WalletHolding, 0, len(holdings))
+	seen := make(map[string]int)
 	for _, holding := range holdings {
-		key := fmt.Sprintf("%s:%s:%d", holding.WalletAddress, holding.TokenAddress, holding.ChainID)
-		if _, ok := seen[key]; !ok {
-			seen[key] = struct{}{}
-			deduplicatedHoldings = append(deduplicatedHoldings, holding)
+		key := HoldingKey(holding)
+		if idx, ok := seen[key]; ok {
+			deduplicatedHoldings[idx] = holding
+			continue
 		}
+		seen[key] = len(deduplicatedHoldings)
+		deduplicatedHoldings = append(deduplicatedHoldings, holding)
 	}
 	return deduplicatedHoldings
 }
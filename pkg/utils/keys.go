@@ -25,3 +25,13 @@ func PairsEarliestBlockTimestampKey(chainId uint64, tokenAddress string) string
 func MissingTokenInfoKey() string {
 	return "smart_money:missing_tokeninfo:list"
 }
+
+// LatestTradesKey 系统聪明钱最新成交 zset，名称中带 24h，实际读取时由调用方控制时间窗口
+func LatestTradesKey(chainId uint64) string {
+	return fmt.Sprintf("smart_money:monitor:latest_trades:%d:24h", chainId)
+}
+
+// DirtyWalletsKey 有新交易、待 smart_money_analyze 增量重算的钱包集合，成员为 "<chain_id>:<wallet_address>"
+func DirtyWalletsKey() string {
+	return "smart_money:dirty_wallets"
+}
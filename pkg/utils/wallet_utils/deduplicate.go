@@ -4,16 +4,23 @@ import (
 	"web3-smart/internal/worker/model"
 )
 
-// 根据wallet_address去重
+// WalletKey 钱包唯一键 wallet_address
+func WalletKey(wallet model.WalletSummary) string {
+	return wallet.WalletAddress
+}
+
+// 根据wallet_address去重，重复时保留最后一条（最新状态）
 func DeduplicateWallets(wallets []model.WalletSummary) []model.WalletSummary {
-	deduplicatedWallets := make([]model.WalletSummary, 0)
-	seen := make(map[string]struct{})
+	deduplicatedWallets := make([]model.WalletSummary, 0, len(wallets))
+	seen := make(map[string]int)
 	for _, wallet := range wallets {
-		key := wallet.WalletAddress
-		if _, ok := seen[key]; !ok {
-			seen[key] = struct{}{}
-			deduplicatedWallets = append(deduplicatedWallets, wallet)
+		key := WalletKey(wallet)
+		if idx, ok := seen[key]; ok {
+			deduplicatedWallets[idx] = wallet
+			continue
 		}
+		seen[key] = len(deduplicatedWallets)
+		deduplicatedWallets = append(deduplicatedWallets, wallet)
 	}
 	return deduplicatedWallets
 }